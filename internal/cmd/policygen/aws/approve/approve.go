@@ -0,0 +1,99 @@
+package approve
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	awspolicy "github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/ledger"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+)
+
+const approveExample = `
+# approve every grant which is pending approval in policy-gen.lock
+policy-gen aws approve --input-path=./internal --recursive
+
+# approve pending grants in a custom ledger, removing grants which are no longer produced
+policy-gen aws approve --input-path=./internal --recursive --ledger=security/policy-gen.lock --prune
+
+# approve pending grants of policies which are generated with --enforce-expiry
+policy-gen aws approve --input-path=./internal --recursive --enforce-expiry
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewApproveFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "approve",
+		Short:   "Approve the grants of the policies generated from markers",
+		Long:    `Add the grants of the policies generated from markers which are pending approval to the approval ledger`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return run(flags) },
+		Example: approveExample,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags) error {
+	// convert our user input into a configuration for the processor
+	config, err := flags.ToProcessorConfig()
+	if err != nil {
+		return fmt.Errorf("unable to convert flags into a processor config - %w", err)
+	}
+
+	markerProcessor, err := common.NewProcessor(config)
+	if err != nil {
+		return err
+	}
+
+	// approve the grants as they are generated, including the condition which enforces expiry
+	markerProcessor.PolicyFileGenerator = &awspolicy.PolicyDocumentGenerator{
+		Directory:       config.OutputDirectory,
+		ExpiryCondition: flags.For(input.FlagEnforceExpiry).BooleanValue,
+	}
+
+	// read the existing approvals
+	ledgerPath := flags.For(input.FlagLedger).StringValue
+
+	approvals, err := ledger.Read(ledgerPath)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagLedger, err)
+	}
+
+	documents, err := common.PolicyDocuments(markerProcessor)
+	if err != nil {
+		return err
+	}
+
+	// approve the pending grants and remove the stale grants if requested
+	pending := approvals.Pending(documents)
+	for _, entry := range pending {
+		markerProcessor.Log.Info().Msgf("approving grant: [%s]", entry)
+	}
+
+	approvals.Approve(pending...)
+
+	if flags.For(input.FlagPrune).BooleanValue {
+		stale := approvals.Stale(documents)
+		for _, entry := range stale {
+			markerProcessor.Log.Info().Msgf("removing grant: [%s]", entry)
+		}
+
+		approvals.Remove(stale...)
+	}
+
+	content, err := approvals.Marshal()
+	if err != nil {
+		return err
+	}
+
+	markerProcessor.Log.Info().Msgf("writing ledger file: [%s]", ledgerPath)
+
+	return common.WriteReport(ledgerPath, content)
+}
@@ -0,0 +1,138 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/audit"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+)
+
+var (
+	ErrAuditFailed   = errors.New("policy does not match the policy generated from markers")
+	ErrInvalidFailOn = errors.New("invalid fail on value")
+)
+
+const (
+	failOnAny     = "any"
+	failOnExcess  = "excess"
+	failOnMissing = "missing"
+	failOnNone    = "none"
+)
+
+const auditExample = `
+# audit the policy deployed to production against the markers for the installer-local policy
+policy-gen aws audit --input-path=./internal --recursive --policy=deployed.json --name=installer-local
+
+# audit the policy deployed to production against the markers reachable from a binary
+policy-gen aws audit --input-path=. --recursive --entrypoint=./cmd/installer --policy=deployed.json --name=installer
+
+# only fail when the deployed policy grants more than the markers justify, reporting as json
+policy-gen aws audit --input-path=./internal --recursive --policy=deployed.json --name=installer-local --fail-on=excess --format=json
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewAuditFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "audit",
+		Short:   "Audit an AWS IAM policy against the policy generated from markers",
+		Long:    `Audit an AWS IAM policy, such as the policy deployed to production, against the policy generated from markers`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return run(flags) },
+		Example: auditExample,
+
+		// audit failures are reported as errors, so usage is not useful here
+		SilenceUsage: true,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags) error {
+	failOn := flags.For(input.FlagFailOn).StringValue
+
+	switch failOn {
+	case failOnAny, failOnExcess, failOnMissing, failOnNone:
+	default:
+		return fmt.Errorf(
+			"invalid flag: [--%s] - %w [%s] - must be one of [%s, %s, %s, %s]",
+			input.FlagFailOn, ErrInvalidFailOn, failOn, failOnAny, failOnExcess, failOnMissing, failOnNone,
+		)
+	}
+
+	// convert our user input into a configuration for the processor
+	config, err := flags.ToProcessorConfig()
+	if err != nil {
+		return fmt.Errorf("unable to convert flags into a processor config - %w", err)
+	}
+
+	// keep standard output clean for the report
+	config.LogWriter = os.Stderr
+
+	// read the policy to audit
+	policyPath := flags.For(input.FlagPolicy).StringValue
+
+	data, err := os.ReadFile(policyPath)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - unable to read policy file [%s] - %w", input.FlagPolicy, policyPath, err)
+	}
+
+	deployed, incomparable, err := audit.Parse(data)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagPolicy, err)
+	}
+
+	// collect the markers
+	markerProcessor, err := common.NewProcessor(config)
+	if err != nil {
+		return err
+	}
+
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	// compare and report
+	report, err := audit.NewReport(flags.For(input.FlagName).StringValue, deployed, policyMarkers)
+	if err != nil {
+		return fmt.Errorf("unable to create audit report - %w", err)
+	}
+
+	// statements which cannot be compared are reported, rather than failing the audit
+	report.Incomparable = incomparable
+
+	for _, statement := range incomparable {
+		markerProcessor.Log.Warn().Msgf(
+			"statement [%d] with sid [%s] is not comparable with markers: %s", statement.Index, statement.SID, statement.Reason,
+		)
+	}
+
+	content, err := report.Render(flags.For(input.FlagFormat).StringValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagFormat, err)
+	}
+
+	if err := common.WriteReport(flags.For(input.FlagReportFile).StringValue, content); err != nil {
+		return err
+	}
+
+	excess := report.HasExcess() && (failOn == failOnAny || failOn == failOnExcess)
+	missing := report.HasMissing() && (failOn == failOnAny || failOn == failOnMissing)
+
+	if excess || missing {
+		return &common.FindingsError{
+			Err: fmt.Errorf("%w - found [%d] excess and [%d] missing grants", ErrAuditFailed, len(report.Excess)+len(report.Lost), len(report.Missing)+len(report.Tighter)),
+		}
+	}
+
+	return nil
+}
@@ -1,15 +1,46 @@
 package aws
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/approve"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/audit"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/coverage"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/diff"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/importcloudtrail"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/importpolicy"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/lint"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/simulate"
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/unused"
+	awspolicy "github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/assertions"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/ledger"
 	"github.com/scottd018/policy-gen/internal/pkg/input"
 	"github.com/scottd018/policy-gen/internal/pkg/processor"
 )
 
+var (
+	ErrInvalidMarkers   = errors.New("found invalid markers")
+	ErrAssertionsFailed = errors.New("generated policies violate assertions")
+	ErrUnapprovedGrants = errors.New("generated policies contain grants which are not approved")
+	ErrExpiredMarkers   = errors.New("found markers with expired permissions")
+	ErrInvalidMode      = errors.New("invalid mode")
+)
+
+const (
+	modeFail = "fail"
+	modeWarn = "warn"
+
+	hoursPerDay = 24
+)
+
 const awsPolicyGenExample = `
 # generate policies using sensible defaults
 policy-gen aws
@@ -24,10 +55,28 @@ policy-gen aws --debug
 
 # generate policies and associated documentation at ./output/README.md
 policy-gen aws --output-path=./output --documentation=README.md
+
+# validate every marker before generating policies, writing any invalid markers as sarif
+policy-gen aws --input-path=./input --output-path=./output --format=sarif --report-file=results.sarif
+
+# fail without writing policies if the generated policies violate a set of assertions
+policy-gen aws --input-path=./input --output-path=./output --assertions=assertions.yaml
+
+# fail if the generated policies contain grants which are not approved in the ledger
+policy-gen aws --input-path=./input --output-path=./output --ledger=policy-gen.lock
+
+# enforce the expiry of temporary permissions in the generated policies themselves
+policy-gen aws --input-path=./input --output-path=./output --enforce-expiry
+
+# write an inventory of every permission which may be loaded into a spreadsheet
+policy-gen aws --input-path=./input --output-path=./output --inventory=inventory.csv
+
+# write a graph of the policies, services, actions and resources for embedding in markdown
+policy-gen aws --input-path=./input --output-path=./output --graph=graph.md
 `
 
 func NewCommand() *cobra.Command {
-	flags := input.NewFlags()
+	flags := input.NewGenerateFlags()
 
 	// create the command
 	command := &cobra.Command{
@@ -41,6 +90,17 @@ func NewCommand() *cobra.Command {
 	// initialize the flags
 	flags.Initialize(command)
 
+	// add the subcommands
+	command.AddCommand(approve.NewCommand())
+	command.AddCommand(audit.NewCommand())
+	command.AddCommand(coverage.NewCommand())
+	command.AddCommand(diff.NewCommand())
+	command.AddCommand(importcloudtrail.NewCommand())
+	command.AddCommand(importpolicy.NewCommand())
+	command.AddCommand(lint.NewCommand())
+	command.AddCommand(simulate.NewCommand())
+	command.AddCommand(unused.NewCommand())
+
 	return command
 }
 
@@ -51,15 +111,59 @@ func run(flags input.Flags) error {
 		return fmt.Errorf("unable to convert flags into a processor config - %w", err)
 	}
 
+	format := flags.For(input.FlagFormat).StringValue
+	reportFile := flags.For(input.FlagReportFile).StringValue
+
+	// keep standard output clean for a report which is written to it
+	if format != processor.FormatText && reportFile == "" {
+		config.LogWriter = os.Stderr
+	}
+
 	// create the processor
-	markerProcessor, err := processor.NewProcessor(
-		config,
-		aws.MarkerDefinition(),
-		aws.Marker{},
-		&aws.PolicyDocumentGenerator{Directory: config.OutputDirectory},
-	)
+	markerProcessor, err := common.NewProcessor(config)
 	if err != nil {
-		return fmt.Errorf("unable to create marker processor - %w", err)
+		return err
+	}
+
+	markerProcessor.PolicyFileGenerator = &awspolicy.PolicyDocumentGenerator{
+		Directory:       config.OutputDirectory,
+		ExpiryCondition: flags.For(input.FlagEnforceExpiry).BooleanValue,
+	}
+
+	// report every invalid marker before processing if requested
+	if format != processor.FormatText || reportFile != "" {
+		if err := diagnose(markerProcessor, format, reportFile); err != nil {
+			return err
+		}
+	}
+
+	// evaluate the generated policies against the assertions if requested
+	if assertionsPath := flags.For(input.FlagAssertions).StringValue; assertionsPath != "" {
+		if err := assert(markerProcessor, assertionsPath); err != nil {
+			return err
+		}
+	}
+
+	// compare the generated policies with the approved grants if requested
+	if ledgerPath := flags.For(input.FlagLedger).StringValue; ledgerPath != "" {
+		if err := checkLedger(
+			markerProcessor,
+			ledgerPath,
+			flags.For(input.FlagLedgerMode).StringValue,
+			flags.For(input.FlagEnforceExpiry).BooleanValue,
+		); err != nil {
+			return err
+		}
+	}
+
+	// warn about permissions which expire soon and fail on permissions which have expired
+	if err := checkExpiry(
+		markerProcessor,
+		time.Now(),
+		flags.For(input.FlagExpiryWarning).IntValue,
+		flags.For(input.FlagOnExpired).StringValue,
+	); err != nil {
+		return err
 	}
 
 	// execute
@@ -69,3 +173,161 @@ func run(flags input.Flags) error {
 
 	return nil
 }
+
+// diagnose parses the markers and writes a report of every invalid marker in the given format,
+// returning an error if any marker is invalid.  The report is written even if every marker is
+// valid so that previous results are cleared.
+func diagnose(markerProcessor *processor.Processor, format, reportFile string) error {
+	results, err := markerProcessor.Parse()
+	if err != nil {
+		return fmt.Errorf("unable to parse markers - %w", err)
+	}
+
+	diagnostics := markerProcessor.Diagnostics(results)
+
+	content, err := processor.RenderDiagnostics(diagnostics, format)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagFormat, err)
+	}
+
+	if err := common.WriteReport(reportFile, content); err != nil {
+		return err
+	}
+
+	if count := processor.CountErrors(diagnostics); count > 0 {
+		return fmt.Errorf("%w - found [%d] invalid markers", ErrInvalidMarkers, count)
+	}
+
+	return nil
+}
+
+// assert evaluates the policies generated from the markers against a file of assertions,
+// writing a report of the violated assertions and returning an error if any are violated.
+func assert(markerProcessor *processor.Processor, path string) error {
+	file, err := assertions.Read(path)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagAssertions, err)
+	}
+
+	documents, err := common.PolicyDocuments(markerProcessor)
+	if err != nil {
+		return err
+	}
+
+	report := assertions.NewReport(file, documents)
+
+	markerProcessor.Log.Info().Msgf("evaluated [%d] assertions from file: [%s]", report.Assertions, path)
+
+	if report.Failed() {
+		if _, err := os.Stderr.Write(report.Text()); err != nil {
+			return fmt.Errorf("unable to write assertions report - %w", err)
+		}
+
+		return fmt.Errorf("%w - [%d] of [%d] assertions failed", ErrAssertionsFailed, len(report.Violations), report.Assertions)
+	}
+
+	return nil
+}
+
+// checkLedger compares the grants of the policies generated from the markers with the grants
+// approved in a ledger, logging each grant which is pending approval.  An error is returned if
+// any grant is pending approval, unless the mode only warns.  Grants generated with a condition
+// which enforces expiry must be approved with the same condition.
+func checkLedger(markerProcessor *processor.Processor, path, mode string, enforceExpiry bool) error {
+	if err := validateMode(input.FlagLedgerMode, mode); err != nil {
+		return err
+	}
+
+	approvals, err := ledger.Read(path)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagLedger, err)
+	}
+
+	documents, err := common.PolicyDocuments(markerProcessor)
+	if err != nil {
+		return err
+	}
+
+	pending := approvals.Pending(documents)
+	if len(pending) == 0 {
+		return nil
+	}
+
+	for _, entry := range pending {
+		markerProcessor.Log.Warn().Msgf("grant is not approved in ledger [%s]: [%s]", path, entry)
+	}
+
+	if mode == modeWarn {
+		return nil
+	}
+
+	approve := "policy-gen aws approve"
+	if enforceExpiry {
+		approve += " --" + input.FlagEnforceExpiry
+	}
+
+	return fmt.Errorf(
+		"%w - found [%d] grants not approved in ledger [%s], which may be approved with '%s'",
+		ErrUnapprovedGrants, len(pending), path, approve,
+	)
+}
+
+// checkExpiry logs a warning for each marker whose permission expires within a number of days
+// of a given time, and for each marker whose permission has expired.  An error is returned if
+// any permission has expired, unless the mode only warns.
+func checkExpiry(markerProcessor *processor.Processor, now time.Time, warningDays int, mode string) error {
+	if err := validateMode(input.FlagOnExpired, mode); err != nil {
+		return err
+	}
+
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	expired := 0
+
+	for i := range policyMarkers {
+		marker, ok := policyMarkers[i].(*awspolicy.Marker)
+		if !ok {
+			return awspolicy.ErrMarkerConvert
+		}
+
+		expiresAt := marker.ExpiresAt()
+		if expiresAt == nil {
+			continue
+		}
+
+		remaining := int(math.Ceil(expiresAt.Sub(now).Hours() / hoursPerDay))
+
+		switch {
+		case marker.Expired(now):
+			expired++
+
+			markerProcessor.Log.Warn().Msgf(
+				"permission expired on [%s]: [%s] at [%s]",
+				marker.ExpiresColumn(), marker.PermissionColumn(), marker.GetSource(),
+			)
+		case remaining <= warningDays:
+			markerProcessor.Log.Warn().Msgf(
+				"permission expires in [%d] days on [%s]: [%s] at [%s]",
+				remaining, marker.ExpiresColumn(), marker.PermissionColumn(), marker.GetSource(),
+			)
+		}
+	}
+
+	if expired == 0 || mode == modeWarn {
+		return nil
+	}
+
+	return fmt.Errorf("%w - found [%d] expired permissions which must be removed or extended", ErrExpiredMarkers, expired)
+}
+
+// validateMode validates that the mode given with a flag either fails or warns.
+func validateMode(flag, mode string) error {
+	if mode != modeFail && mode != modeWarn {
+		return fmt.Errorf("invalid flag: [--%s] - %w [%s] - must be one of [%s, %s]", flag, ErrInvalidMode, mode, modeFail, modeWarn)
+	}
+
+	return nil
+}
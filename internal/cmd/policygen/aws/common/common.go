@@ -0,0 +1,104 @@
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/actions"
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/processor"
+)
+
+const (
+	// ExitCodeFindings is the exit code of a command which reported findings.
+	ExitCodeFindings = 2
+)
+
+// NewProcessor creates a new processor for AWS IAM policy markers.  The embedded catalog of
+// access levels is loaded first, so that an invalid catalog fails before any marker is processed.
+func NewProcessor(config *processor.Config) (*processor.Processor, error) {
+	if err := actions.Load(); err != nil {
+		return nil, fmt.Errorf("unable to create marker processor - %w", err)
+	}
+
+	markerProcessor, err := processor.NewProcessor(
+		config,
+		aws.MarkerDefinition(),
+		aws.Marker{},
+		&aws.PolicyDocumentGenerator{Directory: config.OutputDirectory},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create marker processor - %w", err)
+	}
+
+	return markerProcessor, nil
+}
+
+// PolicyDocuments generates the policy document for each policy name from the markers found by
+// a processor, without writing any files.
+func PolicyDocuments(markerProcessor *processor.Processor) (map[string]*aws.PolicyDocument, error) {
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	generator, ok := markerProcessor.PolicyFileGenerator.(*aws.PolicyDocumentGenerator)
+	if !ok {
+		return nil, fmt.Errorf("unexpected policy generator type [%T]", markerProcessor.PolicyFileGenerator)
+	}
+
+	documents, err := generator.ToPolicyDocuments(policyMarkers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate policies - %w", err)
+	}
+
+	return documents, nil
+}
+
+// WriteReport writes report content to the given path, overwriting any existing file, or to
+// standard output if no path is given.
+func WriteReport(path string, content []byte) error {
+	if path == "" {
+		if _, err := os.Stdout.Write(content); err != nil {
+			return fmt.Errorf("unable to write report to standard output - %w", err)
+		}
+
+		return nil
+	}
+
+	reportFile, err := files.NewFile(path, files.WithPreExistingDirectory)
+	if err != nil {
+		return fmt.Errorf("invalid report file [%s] - %w", path, err)
+	}
+
+	reportFile.Content = content
+
+	if err := reportFile.Write(files.ModeDocumentFile, files.WithOverwrite); err != nil {
+		return fmt.Errorf("unable to write report file [%s] - %w", path, err)
+	}
+
+	return nil
+}
+
+// FindingsError represents a command which completed but reported findings, such as an audit
+// which found differences, rather than failing with an error.
+type FindingsError struct {
+	Err error
+}
+
+// Error returns the error message of the findings.
+func (err *FindingsError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap returns the underlying error of the findings.
+func (err *FindingsError) Unwrap() error {
+	return err.Err
+}
+
+// ExitCode returns the exit code of a command which reported findings, which differs from the
+// exit code of a command which failed so that the two may be distinguished in CI.
+func (err *FindingsError) ExitCode() int {
+	return ExitCodeFindings
+}
@@ -0,0 +1,115 @@
+package coverage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/coverage"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+)
+
+var (
+	ErrCoverageGaps = errors.New("found aws sdk calls without a marker in scope")
+)
+
+const coverageExample = `
+# report aws sdk calls without markers and markers without aws sdk calls
+policy-gen aws coverage --input-path=./internal --recursive
+
+# consider markers anywhere within the same package of a call
+policy-gen aws coverage --input-path=./internal --recursive --scope=package
+
+# write the coverage report as sarif for code scanning
+policy-gen aws coverage --input-path=./internal --recursive --format=sarif --report-file=coverage.sarif
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewCoverageFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "coverage",
+		Short:   "Report AWS SDK for Go v2 calls without matching markers",
+		Long:    `Report AWS SDK for Go v2 calls without matching markers and markers without matching calls`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return run(flags) },
+		Example: coverageExample,
+
+		// coverage gaps are reported as errors, so usage is not useful here
+		SilenceUsage: true,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags) error {
+	scope, err := coverage.NewScope(flags.For(input.FlagScope).StringValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagScope, err)
+	}
+
+	// convert our user input into a configuration for the processor
+	config, err := flags.ToProcessorConfig()
+	if err != nil {
+		return fmt.Errorf("unable to convert flags into a processor config - %w", err)
+	}
+
+	// keep standard output clean for the report
+	config.LogWriter = os.Stderr
+
+	markerProcessor, err := common.NewProcessor(config)
+	if err != nil {
+		return err
+	}
+
+	// collect the markers
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	// collect the sdk calls, annotated with their enclosing functions if possible
+	paths, err := config.InputDirectory.ListFilePaths(config.Recursive)
+	if err != nil {
+		return fmt.Errorf("unable to collect input files - %w", err)
+	}
+
+	index, err := markerProcessor.GoIndex()
+	if err != nil {
+		markerProcessor.Log.Warn().Msgf("unable to determine enclosing functions for go source - %s", err)
+	}
+
+	calls, err := coverage.Scan(paths, index)
+	if err != nil {
+		return fmt.Errorf("unable to scan for aws sdk calls - %w", err)
+	}
+
+	// compare and report
+	report, err := coverage.NewReport(calls, policyMarkers, scope)
+	if err != nil {
+		return fmt.Errorf("unable to create coverage report - %w", err)
+	}
+
+	content, err := report.Render(flags.For(input.FlagFormat).StringValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagFormat, err)
+	}
+
+	if err := common.WriteReport(flags.For(input.FlagReportFile).StringValue, content); err != nil {
+		return err
+	}
+
+	if report.HasGaps() {
+		return &common.FindingsError{
+			Err: fmt.Errorf("%w - found [%d] uncovered calls", ErrCoverageGaps, len(report.Uncovered)),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/diff"
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/git"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/processor"
+)
+
+var (
+	ErrInvalidArguments = errors.New("invalid arguments")
+)
+
+const defaultDirectory = "."
+
+const diffExample = `
+# report the permissions which changed between two directories of markers
+policy-gen aws diff ./old ./new --recursive
+
+# report the permissions which changed in the current directory since the main branch
+policy-gen aws diff --base-ref=origin/main --recursive
+
+# write the report for a pull request comment
+policy-gen aws diff ./internal --base-ref=origin/main --recursive --report-file=policy-changes.md
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewDiffFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "diff [<old-dir>] <new-dir>",
+		Short:   "Report the permissions which changed between two sets of markers",
+		Long:    `Report the permissions which changed between two sets of markers, as Markdown, suitable for a pull request comment`,
+		RunE:    func(_ *cobra.Command, args []string) error { return run(flags, args) },
+		Example: diffExample,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags, args []string) error {
+	oldDirectory, newDirectory, cleanup, err := directories(flags.For(input.FlagBaseRef).StringValue, args)
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	oldMarkers, err := markersFor(flags, oldDirectory)
+	if err != nil {
+		return err
+	}
+
+	newMarkers, err := markersFor(flags, newDirectory)
+	if err != nil {
+		return err
+	}
+
+	report, err := diff.NewReport(oldMarkers, newMarkers)
+	if err != nil {
+		return fmt.Errorf("unable to create diff report - %w", err)
+	}
+
+	return common.WriteReport(flags.For(input.FlagReportFile).StringValue, report.Markdown())
+}
+
+// directories returns the old and new directories to compare from the arguments.  When a base
+// revision is given, the old directory is the new directory at that revision.
+func directories(baseRef string, args []string) (oldDirectory, newDirectory string, cleanup func(), err error) {
+	if baseRef == "" {
+		if len(args) != 2 {
+			return "", "", nil, fmt.Errorf("%w - expected <old-dir> and <new-dir> or [--%s]", ErrInvalidArguments, input.FlagBaseRef)
+		}
+
+		return args[0], args[1], func() {}, nil
+	}
+
+	switch len(args) {
+	case 0:
+		newDirectory = defaultDirectory
+	case 1:
+		newDirectory = args[0]
+	default:
+		return "", "", nil, fmt.Errorf("%w - expected at most one directory with [--%s]", ErrInvalidArguments, input.FlagBaseRef)
+	}
+
+	oldDirectory, cleanup, err = git.Export(newDirectory, baseRef)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid flag: [--%s] - %w", input.FlagBaseRef, err)
+	}
+
+	return oldDirectory, newDirectory, cleanup, nil
+}
+
+// markersFor returns the markers found in a directory.
+func markersFor(flags input.Flags, path string) ([]policy.Marker, error) {
+	directory, err := files.NewDirectory(path, files.WithPreExistingDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid directory [%s] - %w", path, err)
+	}
+
+	project, err := flags.Project()
+	if err != nil {
+		return nil, err
+	}
+
+	markerProcessor, err := common.NewProcessor(&processor.Config{
+		InputDirectory: directory,
+		Recursive:      flags.For(input.FlagRecursive).BooleanValue,
+		Debug:          flags.For(input.FlagDebug).BooleanValue,
+		Project:        project,
+
+		// keep standard output clean for the report
+		LogWriter: os.Stderr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to process markers in directory [%s] - %w", path, err)
+	}
+
+	return policyMarkers, nil
+}
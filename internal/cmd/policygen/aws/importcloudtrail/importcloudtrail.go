@@ -0,0 +1,224 @@
+package importcloudtrail
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/cloudtrail"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+	"github.com/scottd018/policy-gen/internal/pkg/processor"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+	ErrMissingPaths  = errors.New("missing cloudtrail log paths")
+)
+
+const (
+	formatMarkers = "markers"
+	formatPolicy  = "policy"
+)
+
+const importCloudTrailExample = `
+# suggest markers for every action observed in a directory of cloudtrail logs
+policy-gen aws import-cloudtrail ./logs
+
+# suggest markers for the actions of a single role within a time window
+policy-gen aws import-cloudtrail ./logs \
+    --principal-arn='arn:aws:iam::123456789012:role/my-app' \
+    --start-time=2024-01-01T00:00:00Z \
+    --end-time=2024-02-01T00:00:00Z \
+    --exclude-errors
+
+# suggest markers only for observed actions which existing markers do not cover
+policy-gen aws import-cloudtrail ./logs --input-path=./internal --recursive
+
+# generate a policy from the observed actions
+policy-gen aws import-cloudtrail ./logs --format=policy --name=my-app --report-file=my-app.json
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewCloudTrailFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "import-cloudtrail [paths...]",
+		Short:   "Generate markers from CloudTrail logs",
+		Long:    `Generate suggested markers, or a policy, from the actions observed in local CloudTrail log files`,
+		RunE:    func(_ *cobra.Command, args []string) error { return run(flags, args) },
+		Example: importCloudTrailExample,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags, paths []string) error {
+	if len(paths) == 0 {
+		return ErrMissingPaths
+	}
+
+	format := flags.For(input.FlagFormat).StringValue
+	if format != formatMarkers && format != formatPolicy {
+		return fmt.Errorf(
+			"invalid flag: [--%s] - %w [%s] - must be one of [%s, %s]",
+			input.FlagFormat, ErrInvalidFormat, format, formatMarkers, formatPolicy,
+		)
+	}
+
+	filter, err := toFilter(flags)
+	if err != nil {
+		return err
+	}
+
+	markerProcessor, err := newProcessor(flags)
+	if err != nil {
+		return err
+	}
+
+	// read the records and convert them into observed actions
+	records, err := cloudtrail.ReadRecords(paths...)
+	if err != nil {
+		return err
+	}
+
+	observations, err := cloudtrail.Observe(records, filter)
+	if err != nil {
+		return fmt.Errorf("unable to observe actions from cloudtrail records - %w", err)
+	}
+
+	markerProcessor.Log.Info().Msgf("observed [%d] actions from [%d] cloudtrail records", len(observations), len(records))
+
+	// compare the observed actions with existing markers if requested
+	uncovered := observations
+
+	if flags.For(input.FlagInputPath).StringValue != "" {
+		if uncovered, err = uncoveredObservations(markerProcessor, observations); err != nil {
+			return err
+		}
+
+		for _, observation := range uncovered {
+			markerProcessor.Log.Warn().Msgf(
+				"action not covered by existing markers: [%s] on resource [%s]",
+				observation.Action,
+				observation.Resource,
+			)
+		}
+	}
+
+	// render the output
+	var content []byte
+
+	name := flags.For(input.FlagName).StringValue
+
+	switch format {
+	case formatPolicy:
+		if content, err = toPolicy(name, observations); err != nil {
+			return err
+		}
+	default:
+		content = toMarkers(name, uncovered)
+	}
+
+	return common.WriteReport(flags.For(input.FlagReportFile).StringValue, content)
+}
+
+// toFilter converts the user input into a filter for cloudtrail records.
+func toFilter(flags input.Flags) (*cloudtrail.Filter, error) {
+	filter := &cloudtrail.Filter{
+		PrincipalARN:  flags.For(input.FlagPrincipalARN).StringValue,
+		SessionName:   flags.For(input.FlagSessionName).StringValue,
+		ExcludeErrors: flags.For(input.FlagExcludeErrors).BooleanValue,
+	}
+
+	for flag, value := range map[string]*time.Time{
+		input.FlagStartTime: &filter.Start,
+		input.FlagEndTime:   &filter.End,
+	} {
+		if flags.For(flag).StringValue == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, flags.For(flag).StringValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", flag, err)
+		}
+
+		*value = parsed
+	}
+
+	return filter, nil
+}
+
+// newProcessor returns the processor which finds the existing markers at the input path, when
+// one is given, and logs the progress of the import.
+func newProcessor(flags input.Flags) (*processor.Processor, error) {
+	config := &processor.Config{Debug: flags.For(input.FlagDebug).BooleanValue}
+
+	if flags.For(input.FlagInputPath).StringValue != "" {
+		var err error
+
+		if config, err = flags.ToProcessorConfig(); err != nil {
+			return nil, fmt.Errorf("unable to convert flags into a processor config - %w", err)
+		}
+	}
+
+	// keep standard output clean for the output
+	config.LogWriter = os.Stderr
+
+	return common.NewProcessor(config)
+}
+
+// uncoveredObservations returns the observations which are not covered by the markers found at
+// the input path.
+func uncoveredObservations(markerProcessor *processor.Processor, observations []*cloudtrail.Observation) ([]*cloudtrail.Observation, error) {
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	uncovered, err := cloudtrail.Uncovered(observations, policyMarkers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compare observed actions with markers - %w", err)
+	}
+
+	return uncovered, nil
+}
+
+// toMarkers renders a set of observations as suggested marker comments.
+func toMarkers(name string, observations []*cloudtrail.Observation) []byte {
+	content := &bytes.Buffer{}
+
+	for _, marker := range cloudtrail.ToMarkers(name, observations) {
+		fmt.Fprintf(content, "// %s\n", marker)
+	}
+
+	return content.Bytes()
+}
+
+// toPolicy renders a set of observations as a policy document.
+func toPolicy(name string, observations []*cloudtrail.Observation) ([]byte, error) {
+	markers := cloudtrail.ToMarkers(name, observations)
+
+	values := make([]aws.Marker, len(markers))
+	for i := range markers {
+		values[i] = *markers[i]
+	}
+
+	data, err := json.MarshalIndent(aws.NewPolicyDocument(values...), "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal policy document - %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
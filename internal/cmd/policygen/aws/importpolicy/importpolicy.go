@@ -0,0 +1,194 @@
+package importpolicy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/config"
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+	"github.com/scottd018/policy-gen/internal/pkg/justification"
+	"github.com/scottd018/policy-gen/internal/pkg/processor"
+)
+
+var (
+	ErrRoundTrip = errors.New("markers do not regenerate an equivalent policy")
+)
+
+const (
+	reasonPlaceholder = "TODO"
+	stdoutFile        = "<stdout>"
+)
+
+var invalidNameCharacters = regexp.MustCompile("[^a-z0-9_-]+")
+
+const importExample = `
+# print markers for an existing policy, named after the policy file
+policy-gen aws import ./policies/installer.json
+
+# write markers for an existing policy to a stub go file in an existing package
+policy-gen aws import ./policies/installer.json --name=installer --output-file=./internal/installer/policy.go --package=installer
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewImportFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "import <policy-file>",
+		Short:   "Generate markers from an existing AWS IAM policy",
+		Long:    `Generate markers from an existing AWS IAM identity policy and confirm that they regenerate an equivalent policy`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    func(_ *cobra.Command, args []string) error { return run(flags, args[0]) },
+		Example: importExample,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags, policyPath string) error {
+	data, err := os.ReadFile(policyPath)
+	if err != nil {
+		return fmt.Errorf("unable to read policy file [%s] - %w", policyPath, err)
+	}
+
+	document, err := aws.ParsePolicyDocument(data)
+	if err != nil {
+		return fmt.Errorf("unable to import policy file [%s] - %w", policyPath, err)
+	}
+
+	// convert the policy into markers
+	name := flags.For(input.FlagName).StringValue
+	if name == "" {
+		name = nameFor(policyPath)
+	}
+
+	markers, err := document.ToMarkers(name, reasonPlaceholder)
+	if err != nil {
+		return fmt.Errorf("unable to import policy file [%s] - %w", policyPath, err)
+	}
+
+	content := &bytes.Buffer{}
+
+	if packageName := flags.For(input.FlagPackage).StringValue; packageName != "" {
+		fmt.Fprintf(content, "package %s\n\n", packageName)
+	}
+
+	for _, marker := range markers {
+		fmt.Fprintf(content, "// %s\n", marker)
+	}
+
+	markerProcessor, err := common.NewProcessor(&processor.Config{
+		Debug:     flags.For(input.FlagDebug).BooleanValue,
+		LogWriter: os.Stderr,
+
+		// the placeholder reasons are reported once below, rather than for each marker
+		Project: &config.File{Justification: &justification.Rules{Placeholders: []string{}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// ensure the markers regenerate an equivalent policy before writing them
+	outputPath := flags.For(input.FlagOutputFile).StringValue
+
+	if err := roundTrip(markerProcessor, document, outputPath, content.String()); err != nil {
+		return err
+	}
+
+	markerProcessor.Log.Info().Msgf("imported [%d] statements as [%d] markers for policy [%s]", len(document.Statements), len(markers), name)
+	markerProcessor.Log.Warn().Msgf(
+		"imported markers have the placeholder reason [%s], which generation warns about until it is replaced, "+
+			"or rejects with a justification severity of [%s] in the config file",
+		reasonPlaceholder, justification.SeverityError,
+	)
+
+	if outputPath == "" {
+		if _, err := os.Stdout.Write(content.Bytes()); err != nil {
+			return fmt.Errorf("unable to write markers to standard output - %w", err)
+		}
+
+		return nil
+	}
+
+	outputFile, err := files.NewFile(outputPath, files.WithPreExistingDirectory)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagOutputFile, err)
+	}
+
+	outputFile.Content = content.Bytes()
+
+	options := []files.Option{}
+	if flags.For(input.FlagForce).BooleanValue {
+		options = append(options, files.WithOverwrite)
+	}
+
+	if err := outputFile.Write(files.ModePolicyFile, options...); err != nil {
+		return fmt.Errorf("unable to write markers to file [%s] - %w", outputPath, err)
+	}
+
+	return nil
+}
+
+// roundTrip parses the generated marker content as it would be parsed from a file, regenerates
+// the policy from the parsed markers and ensures that it is semantically equal to the original.
+func roundTrip(markerProcessor *processor.Processor, document *aws.PolicyDocument, path, content string) error {
+	if path == "" {
+		path = stdoutFile
+	}
+
+	policyMarkers, err := markerProcessor.FindMarkers(markerProcessor.ParseContent(path, content))
+	if err != nil {
+		return fmt.Errorf("unable to parse generated markers - %w", err)
+	}
+
+	for i := range policyMarkers {
+		policyMarkers[i].WithDefault()
+	}
+
+	regenerated, err := markerProcessor.PolicyFileGenerator.ToDocument(policyMarkers)
+	if err != nil {
+		return fmt.Errorf("unable to regenerate policy from generated markers - %w", err)
+	}
+
+	regeneratedDocument, ok := regenerated.(*aws.PolicyDocument)
+	if !ok {
+		return fmt.Errorf("unable to regenerate policy from generated markers - unexpected document type [%T]", regenerated)
+	}
+
+	removed, added := document.DiffGrants(regeneratedDocument)
+	if len(removed) == 0 && len(added) == 0 {
+		return nil
+	}
+
+	differences := []string{}
+
+	for _, grant := range removed {
+		differences = append(differences, "missing: "+grant.String())
+	}
+
+	for _, grant := range added {
+		differences = append(differences, "unexpected: "+grant.String())
+	}
+
+	return fmt.Errorf("%w - [%s]", ErrRoundTrip, strings.Join(differences, ", "))
+}
+
+// nameFor returns a valid policy name derived from the file name of a policy file.
+func nameFor(policyPath string) string {
+	base := filepath.Base(policyPath)
+
+	return strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(strings.TrimSuffix(base, filepath.Ext(base))), "-"), "-")
+}
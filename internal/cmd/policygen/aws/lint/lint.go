@@ -0,0 +1,117 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/lint"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+var (
+	ErrLintFailed = errors.New("found markers which violate lint rules")
+)
+
+const failOnNone = "none"
+
+const lintExample = `
+# lint every marker with the built-in rules
+policy-gen aws lint --input-path=./internal --recursive
+
+# lint the markers of a single policy, only failing on high severity findings
+policy-gen aws lint --input-path=./internal --recursive --name=installer-local --fail-on=high
+
+# write the lint report as json without failing
+policy-gen aws lint --input-path=./internal --recursive --format=json --report-file=lint.json --fail-on=none
+
+# suppress a rule for a single marker
+# +policy-gen:aws:iam:policy:name=installer,action=` + "`s3:*`" + `,resource=` + "`arn:aws:s3:::bucket/*`" + `,ignore=` + "`service-wildcard`" + `
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewLintFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "lint",
+		Short:   "Lint AWS IAM policy markers for insecure permissions",
+		Long:    `Lint AWS IAM policy markers for insecure permissions with a built-in set of rules`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return run(flags) },
+		Example: lintExample,
+
+		// lint findings are reported as errors, so usage is not useful here
+		SilenceUsage: true,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags) error {
+	failOn := flags.For(input.FlagFailOn).StringValue
+
+	var threshold lint.Severity
+
+	if failOn != failOnNone {
+		severity, err := lint.NewSeverity(failOn)
+		if err != nil {
+			return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagFailOn, err)
+		}
+
+		threshold = severity
+	}
+
+	// convert our user input into a configuration for the processor
+	config, err := flags.ToProcessorConfig()
+	if err != nil {
+		return fmt.Errorf("unable to convert flags into a processor config - %w", err)
+	}
+
+	// keep standard output clean for the report
+	config.LogWriter = os.Stderr
+
+	markerProcessor, err := common.NewProcessor(config)
+	if err != nil {
+		return err
+	}
+
+	// collect the markers for the requested policy
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	if name := flags.For(input.FlagName).StringValue; name != "" {
+		policyMarkers = policy.FilterByName(policyMarkers, name)
+	}
+
+	// lint and report
+	report, err := lint.NewReport(policyMarkers)
+	if err != nil {
+		return fmt.Errorf("unable to create lint report - %w", err)
+	}
+
+	content, err := report.Render(flags.For(input.FlagFormat).StringValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagFormat, err)
+	}
+
+	if err := common.WriteReport(flags.For(input.FlagReportFile).StringValue, content); err != nil {
+		return err
+	}
+
+	if threshold != "" && report.HasFindings(threshold) {
+		return &common.FindingsError{
+			Err: fmt.Errorf("%w - found [%d] findings with a severity of at least [%s]", ErrLintFailed, report.Count(threshold), threshold),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/simulator"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+var (
+	ErrRequestDenied     = errors.New("request is denied")
+	ErrMissingPolicy     = errors.New("missing policy to simulate against")
+	ErrInvalidContext    = errors.New("invalid context - must be in the form of key=value")
+	ErrMissingPolicyName = errors.New("no markers found for policy")
+	ErrConflictingTime   = errors.New("time is given by both flags")
+)
+
+const conditionKeyCurrentTime = "aws:CurrentTime"
+
+const simulateExample = `
+# would the installer policy allow writing an object to a bucket tagged with managed=true?
+policy-gen aws simulate --input-path=. --recursive --name=installer \
+    --action=s3:PutObject \
+    --resource=arn:aws:s3:::bucket/keys.json \
+    --context=aws:ResourceTag/managed=true
+
+# would the installer policy, generated with its expiry enforced, still allow the request next year?
+policy-gen aws simulate --input-path=. --recursive --name=installer \
+    --action=s3:PutObject \
+    --resource=arn:aws:s3:::migration/keys.json \
+    --enforce-expiry --time=2027-01-01T00:00:00Z
+
+# simulate a request against an existing policy file, as json
+policy-gen aws simulate --policy=deployed.json --action=ec2:DeleteVpc --context=aws:RequestedRegion=us-east-1 --format=json
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewSimulateFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "simulate",
+		Short:   "Simulate whether a policy allows a request",
+		Long:    `Simulate whether a policy generated from markers, or a policy file, allows a request, as IAM would evaluate it`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return run(flags) },
+		Example: simulateExample,
+
+		// denied requests are reported as errors, so usage is not useful here
+		SilenceUsage: true,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags) error {
+	context, err := toContext(flags.For(input.FlagContext).StringArrayValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagContext, err)
+	}
+
+	if err := withCurrentTime(context, flags.For(input.FlagTime).StringValue, time.Now()); err != nil {
+		return err
+	}
+
+	document, err := documentFor(flags)
+	if err != nil {
+		return err
+	}
+
+	result := simulator.Evaluate(
+		&simulator.Request{
+			Action:   flags.For(input.FlagAction).StringValue,
+			Resource: flags.For(input.FlagResource).StringValue,
+			Context:  context,
+		},
+		simulator.Policy{Name: flags.For(input.FlagName).StringValue, Document: document},
+	)
+
+	content, err := result.Render(flags.For(input.FlagFormat).StringValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagFormat, err)
+	}
+
+	if err := common.WriteReport("", content); err != nil {
+		return err
+	}
+
+	if !result.Allowed() {
+		return &common.FindingsError{Err: fmt.Errorf("%w - %s", ErrRequestDenied, result.Decision)}
+	}
+
+	return nil
+}
+
+// documentFor returns the policy document to simulate against, either from a policy file or
+// generated from the markers for a policy name.
+func documentFor(flags input.Flags) (*aws.PolicyDocument, error) {
+	if policyPath := flags.For(input.FlagPolicy).StringValue; policyPath != "" {
+		data, err := os.ReadFile(policyPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - unable to read policy file [%s] - %w", input.FlagPolicy, policyPath, err)
+		}
+
+		document, err := aws.ParsePolicyDocument(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", input.FlagPolicy, err)
+		}
+
+		return document, nil
+	}
+
+	name := flags.For(input.FlagName).StringValue
+	if name == "" {
+		return nil, fmt.Errorf("%w - one of [--%s] or [--%s] is required", ErrMissingPolicy, input.FlagName, input.FlagPolicy)
+	}
+
+	// convert our user input into a configuration for the processor
+	config, err := flags.ToProcessorConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert flags into a processor config - %w", err)
+	}
+
+	// keep standard output clean for the result
+	config.LogWriter = os.Stderr
+
+	markerProcessor, err := common.NewProcessor(config)
+	if err != nil {
+		return nil, err
+	}
+
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	policyMarkers = policy.FilterByName(policyMarkers, name)
+	if len(policyMarkers) == 0 {
+		return nil, fmt.Errorf("%w [%s]", ErrMissingPolicyName, name)
+	}
+
+	for i := range policyMarkers {
+		policyMarkers[i].WithDefault()
+	}
+
+	// simulate against the policy as it is generated, including the condition which enforces expiry
+	markerProcessor.PolicyFileGenerator = &aws.PolicyDocumentGenerator{
+		Directory:       config.OutputDirectory,
+		ExpiryCondition: flags.For(input.FlagEnforceExpiry).BooleanValue,
+	}
+
+	document, err := markerProcessor.PolicyFileGenerator.ToDocument(policyMarkers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate policy [%s] - %w", name, err)
+	}
+
+	awsDocument, ok := document.(*aws.PolicyDocument)
+	if !ok {
+		return nil, fmt.Errorf("unable to generate policy [%s] - unexpected document type [%T]", name, document)
+	}
+
+	return awsDocument, nil
+}
+
+// toContext converts a set of key=value inputs into the context of a request.
+func toContext(inputs []string) (conditions.Context, error) {
+	context := conditions.Context{}
+
+	for _, keyValue := range inputs {
+		key, value, found := strings.Cut(keyValue, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("%w [%s]", ErrInvalidContext, keyValue)
+		}
+
+		context[key] = append(context[key], value)
+	}
+
+	return context, nil
+}
+
+// withCurrentTime sets the aws:CurrentTime condition key of the context of a request to the
+// given time, or to now if no time is given, so that date conditions such as those which enforce
+// expiry are evaluated.  A time given as a condition key with the context flag is kept.
+func withCurrentTime(context conditions.Context, value string, now time.Time) error {
+	if _, found := context[conditionKeyCurrentTime]; found {
+		if value != "" {
+			return fmt.Errorf(
+				"invalid flag: [--%s] - %w [--%s, --%s %s]",
+				input.FlagTime, ErrConflictingTime, input.FlagTime, input.FlagContext, conditionKeyCurrentTime,
+			)
+		}
+
+		return nil
+	}
+
+	if value != "" {
+		requestTime, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagTime, err)
+		}
+
+		now = requestTime
+	}
+
+	context[conditionKeyCurrentTime] = []string{now.UTC().Format(time.RFC3339)}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package unused
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/scottd018/policy-gen/internal/cmd/policygen/aws/common"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/lastaccessed"
+	"github.com/scottd018/policy-gen/internal/pkg/input"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const unusedExample = `
+# export the last accessed details for the role which uses a policy
+JOB_ID=$(aws iam generate-service-last-accessed-details \
+    --arn arn:aws:iam::123456789012:role/my-app \
+    --granularity ACTION_LEVEL \
+    --query JobId --output text)
+aws iam get-service-last-accessed-details --job-id $JOB_ID > last-accessed.json
+
+# report markers for the policy whose actions have not been used within 90 days
+policy-gen aws unused --input-path=./internal --recursive --name=my-app --last-accessed=last-accessed.json
+
+# report markers whose actions have not been used within 30 days as json
+policy-gen aws unused --input-path=./internal --recursive --name=my-app --last-accessed=last-accessed.json --days=30 --format=json
+`
+
+func NewCommand() *cobra.Command {
+	flags := input.NewUnusedFlags()
+
+	// create the command
+	command := &cobra.Command{
+		Use:     "unused",
+		Short:   "Report markers with unused permissions",
+		Long:    `Report markers which allow actions that have not been used according to IAM last accessed details`,
+		RunE:    func(_ *cobra.Command, _ []string) error { return run(flags) },
+		Example: unusedExample,
+	}
+
+	// initialize the flags
+	flags.Initialize(command)
+
+	return command
+}
+
+func run(flags input.Flags) error {
+	days := flags.For(input.FlagDays).IntValue
+	if days < 0 {
+		return fmt.Errorf("invalid flag: [--%s] - must not be negative", input.FlagDays)
+	}
+
+	// convert our user input into a configuration for the processor
+	config, err := flags.ToProcessorConfig()
+	if err != nil {
+		return fmt.Errorf("unable to convert flags into a processor config - %w", err)
+	}
+
+	// keep standard output clean for the report
+	config.LogWriter = os.Stderr
+
+	details, err := lastaccessed.ReadDetails(flags.For(input.FlagLastAccessed).StringValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagLastAccessed, err)
+	}
+
+	markerProcessor, err := common.NewProcessor(config)
+	if err != nil {
+		return err
+	}
+
+	// collect the markers for the requested policy
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		return fmt.Errorf("unable to process markers - %w", err)
+	}
+
+	if name := flags.For(input.FlagName).StringValue; name != "" {
+		policyMarkers = policy.FilterByName(policyMarkers, name)
+	}
+
+	// compare and report
+	report, err := lastaccessed.NewReport(details, policyMarkers, days, time.Now())
+	if err != nil {
+		return fmt.Errorf("unable to create unused report - %w", err)
+	}
+
+	content, err := report.Render(flags.For(input.FlagFormat).StringValue)
+	if err != nil {
+		return fmt.Errorf("invalid flag: [--%s] - %w", input.FlagFormat, err)
+	}
+
+	return common.WriteReport(flags.For(input.FlagReportFile).StringValue, content)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -14,6 +15,12 @@ import (
 func Execute(command *cobra.Command) {
 	err := command.Execute()
 	if err != nil {
+		// commands which report findings exit with their own code
+		var exitCoder interface{ ExitCode() int }
+		if errors.As(err, &exitCoder) {
+			os.Exit(exitCoder.ExitCode())
+		}
+
 		os.Exit(1)
 	}
 }
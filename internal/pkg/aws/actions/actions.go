@@ -0,0 +1,127 @@
+package actions
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	eventSourceSuffix = ".amazonaws.com"
+)
+
+// actionsJSON is the embedded table which maps AWS API operations to the IAM actions they
+// require.  Services are mapped from their AWS SDK for Go v2 package name, or their CloudTrail
+// event source, to their IAM service prefix only when the two differ.  Operations are listed,
+// by IAM service prefix, only when they do not require the IAM action of the same name.
+//
+//go:embed actions.json
+var actionsJSON []byte
+
+// table represents the embedded mapping of AWS API operations to IAM actions.
+type table struct {
+	Services     map[string]string   `json:"services"`
+	EventSources map[string]string   `json:"eventSources"`
+	Operations   map[string][]string `json:"operations"`
+}
+
+var (
+	actions     *table
+	actionsOnce sync.Once
+	errActions  error
+)
+
+// load loads the embedded action table.
+func load() (*table, error) {
+	actionsOnce.Do(func() {
+		actions = &table{}
+
+		if err := json.Unmarshal(actionsJSON, actions); err != nil {
+			errActions = fmt.Errorf("unable to load embedded action table - %w", err)
+		}
+	})
+
+	return actions, errActions
+}
+
+// ForOperation returns the IAM actions required to call an operation of a service, where the
+// service is the name of the AWS SDK for Go v2 package (e.g. "ec2" for
+// github.com/aws/aws-sdk-go-v2/service/ec2).
+func ForOperation(service, operation string) ([]string, error) {
+	actionTable, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := service
+	if mapped, found := actionTable.Services[service]; found {
+		prefix = mapped
+	}
+
+	return actionTable.forPrefix(prefix, operation), nil
+}
+
+// ForEvent returns the IAM actions required for a CloudTrail event given its event source
+// (e.g. "ec2.amazonaws.com") and event name (e.g. "CreateVpc").
+func ForEvent(eventSource, eventName string) ([]string, error) {
+	actionTable, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.TrimSuffix(eventSource, eventSourceSuffix)
+	if mapped, found := actionTable.EventSources[prefix]; found {
+		prefix = mapped
+	}
+
+	return actionTable.forPrefix(prefix, eventName), nil
+}
+
+// forPrefix returns the IAM actions required to call an operation given the IAM service prefix.
+func (actionTable *table) forPrefix(prefix, operation string) []string {
+	action := fmt.Sprintf("%s:%s", prefix, operation)
+
+	if mapped, found := actionTable.Operations[action]; found {
+		return mapped
+	}
+
+	return []string{action}
+}
+
+// Known returns the IAM actions which are known from the embedded access level catalog and the
+// embedded table of operations, ordered by name.  It is not a complete list of IAM actions, so it
+// may only be used to expand wildcards where possible.
+func Known() ([]string, error) {
+	actionTable, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := loadLevels(); err != nil {
+		return nil, err
+	}
+
+	unique := map[string]bool{}
+
+	for _, action := range catalogued {
+		unique[action] = true
+	}
+
+	for _, mapped := range actionTable.Operations {
+		for _, action := range mapped {
+			unique[action] = true
+		}
+	}
+
+	known := make([]string, 0, len(unique))
+	for action := range unique {
+		known = append(known, action)
+	}
+
+	sort.Strings(known)
+
+	return known, nil
+}
@@ -0,0 +1,108 @@
+package actions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForOperation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		service   string
+		operation string
+		want      []string
+	}{
+		{
+			name:      "ensure operation with a matching action returns appropriately",
+			service:   "ec2",
+			operation: "CreateVpc",
+			want:      []string{"ec2:CreateVpc"},
+		},
+		{
+			name:      "ensure service with a different iam prefix returns appropriately",
+			service:   "cloudwatchlogs",
+			operation: "CreateLogGroup",
+			want:      []string{"logs:CreateLogGroup"},
+		},
+		{
+			name:      "ensure operation with a different action returns appropriately",
+			service:   "s3",
+			operation: "ListObjectsV2",
+			want:      []string{"s3:ListBucket"},
+		},
+		{
+			name:      "ensure operation with multiple actions returns appropriately",
+			service:   "s3",
+			operation: "CopyObject",
+			want:      []string{"s3:GetObject", "s3:PutObject"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ForOperation(tt.service, tt.operation)
+			if err != nil {
+				t.Errorf("ForOperation() error = %v", err)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ForOperation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForEvent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		eventSource string
+		eventName   string
+		want        []string
+	}{
+		{
+			name:        "ensure event with a matching action returns appropriately",
+			eventSource: "ec2.amazonaws.com",
+			eventName:   "CreateVpc",
+			want:        []string{"ec2:CreateVpc"},
+		},
+		{
+			name:        "ensure event source with a different iam prefix returns appropriately",
+			eventSource: "monitoring.amazonaws.com",
+			eventName:   "PutMetricData",
+			want:        []string{"cloudwatch:PutMetricData"},
+		},
+		{
+			name:        "ensure event with a different action returns appropriately",
+			eventSource: "s3.amazonaws.com",
+			eventName:   "HeadObject",
+			want:        []string{"s3:GetObject"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ForEvent(tt.eventSource, tt.eventName)
+			if err != nil {
+				t.Errorf("ForEvent() error = %v", err)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ForEvent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package actions
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/wildcard"
+)
+
+// levelsJSON is the embedded catalog which maps IAM actions to their access level, keyed by IAM
+// service prefix and then by access level.  It is not a complete catalog of IAM actions, so
+// actions which it does not contain are classified by the prefix of their name.
+//
+//go:embed levels.json
+var levelsJSON []byte
+
+// catalog represents the embedded catalog of access levels, keyed by the lowercase service prefix
+// and then by the lowercase action name.
+type catalog map[string]map[string]string
+
+var (
+	levels     catalog
+	levelsOnce sync.Once
+	errLevels  error
+
+	// catalogued are the actions of the catalog, named as they are in IAM.
+	catalogued []string
+)
+
+// levelPrefixes are the prefixes of action names, in the order in which they are checked, which
+// classify actions which are not in the catalog.  Actions which match no prefix are considered
+// to write data.
+var levelPrefixes = []struct {
+	prefix string
+	level  string
+}{
+	{"List", policy.AccessLevelList},
+	{"Describe", policy.AccessLevelList},
+	{"BatchGet", policy.AccessLevelRead},
+	{"Get", policy.AccessLevelRead},
+	{"Head", policy.AccessLevelRead},
+	{"View", policy.AccessLevelRead},
+	{"Search", policy.AccessLevelRead},
+	{"Lookup", policy.AccessLevelRead},
+	{"Scan", policy.AccessLevelRead},
+	{"Query", policy.AccessLevelRead},
+	{"Select", policy.AccessLevelRead},
+	{"Tag", policy.AccessLevelTagging},
+	{"Untag", policy.AccessLevelTagging},
+	{"AddPermission", policy.AccessLevelPermissionsManagement},
+	{"RemovePermission", policy.AccessLevelPermissionsManagement},
+}
+
+// loadLevels loads the embedded catalog of access levels.
+func loadLevels() (catalog, error) {
+	levelsOnce.Do(func() {
+		services := map[string]map[string][]string{}
+
+		if err := json.Unmarshal(levelsJSON, &services); err != nil {
+			errLevels = fmt.Errorf("unable to load embedded access level catalog - %w", err)
+
+			return
+		}
+
+		levels = catalog{}
+
+		for service, byLevel := range services {
+			levels[service] = map[string]string{}
+
+			for level, names := range byLevel {
+				if policy.AccessLevelRank(level) < 0 {
+					errLevels = fmt.Errorf("unable to load embedded access level catalog - %w [%s]", policy.ErrInvalidAccessLevel, level)
+
+					return
+				}
+
+				for _, name := range names {
+					levels[service][strings.ToLower(name)] = level
+					catalogued = append(catalogued, fmt.Sprintf("%s:%s", service, name))
+				}
+			}
+		}
+	})
+
+	return levels, errLevels
+}
+
+// Load loads the embedded catalog of access levels, so that an invalid catalog may be reported
+// before any action is classified.
+func Load() error {
+	_, err := loadLevels()
+
+	return err
+}
+
+// AccessLevel returns the access level of an IAM action, which may contain wildcards.  An action
+// with wildcards resolves to the most sensitive access level of the actions of the catalog which
+// it matches, across every service which it matches when the service contains wildcards.
+// Actions of services which are not in the catalog are classified by the prefix of their name,
+// and wildcards which could match any action, such as "*", are classified as permissions
+// management.
+func AccessLevel(action string) (string, error) {
+	levelCatalog, err := loadLevels()
+	if err != nil {
+		return "", err
+	}
+
+	service, name, found := strings.Cut(strings.ToLower(action), ":")
+	if !found {
+		return policy.AccessLevelPermissionsManagement, nil
+	}
+
+	// a service with wildcards may also match services which are not in the catalog, so the
+	// classification of the name is the least sensitive level that it may resolve to
+	if strings.ContainsAny(service, "*?") {
+		level := levelFor(name)
+
+		for catalogService, names := range levelCatalog {
+			if !wildcard.Match(service, catalogService) {
+				continue
+			}
+
+			if serviceLevel := levelIn(names, name); policy.AccessLevelRank(serviceLevel) > policy.AccessLevelRank(level) {
+				level = serviceLevel
+			}
+		}
+
+		return level, nil
+	}
+
+	if level := levelIn(levelCatalog[service], name); level != "" {
+		return level, nil
+	}
+
+	return levelFor(name), nil
+}
+
+// levelIn returns the access level of the lowercase name of an action, which may contain
+// wildcards, within the actions of a service of the catalog, or an empty string if no action of
+// the service matches.
+func levelIn(names map[string]string, name string) string {
+	if level, known := names[name]; known {
+		return level
+	}
+
+	if strings.ContainsAny(name, "*?") {
+		return highest(names, name)
+	}
+
+	return ""
+}
+
+// highest returns the most sensitive access level of the actions of a service which match a
+// pattern, or an empty string if no action matches.
+func highest(names map[string]string, pattern string) string {
+	var level string
+
+	for name, nameLevel := range names {
+		if wildcard.Match(pattern, name) && policy.AccessLevelRank(nameLevel) > policy.AccessLevelRank(level) {
+			level = nameLevel
+		}
+	}
+
+	return level
+}
+
+// levelFor classifies the lowercase name of an action, which is not in the catalog, by its
+// prefix.  Names which begin with a wildcard could match any action, so they are classified as
+// permissions management.
+func levelFor(name string) string {
+	if name == "" || strings.HasPrefix(name, "*") || strings.HasPrefix(name, "?") {
+		return policy.AccessLevelPermissionsManagement
+	}
+
+	for _, levelPrefix := range levelPrefixes {
+		if strings.HasPrefix(name, strings.ToLower(levelPrefix.prefix)) {
+			return levelPrefix.level
+		}
+	}
+
+	if strings.Contains(name, "policy") || strings.Contains(name, "permission") || strings.Contains(name, "acl") {
+		return policy.AccessLevelPermissionsManagement
+	}
+
+	return policy.AccessLevelWrite
+}
@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func TestAccessLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		action string
+		want   string
+	}{
+		{
+			name:   "ensure list action in the catalog returns appropriately",
+			action: "s3:ListBucket",
+			want:   policy.AccessLevelList,
+		},
+		{
+			name:   "ensure action is matched case-insensitively",
+			action: "S3:getobject",
+			want:   policy.AccessLevelRead,
+		},
+		{
+			name:   "ensure tagging action in the catalog returns appropriately",
+			action: "ec2:CreateTags",
+			want:   policy.AccessLevelTagging,
+		},
+		{
+			name:   "ensure permissions management action in the catalog returns appropriately",
+			action: "iam:AttachRolePolicy",
+			want:   policy.AccessLevelPermissionsManagement,
+		},
+		{
+			name:   "ensure wildcard resolves to the most sensitive matching action",
+			action: "s3:Put*",
+			want:   policy.AccessLevelPermissionsManagement,
+		},
+		{
+			name:   "ensure wildcard of only list actions returns list",
+			action: "ec2:Describe*",
+			want:   policy.AccessLevelList,
+		},
+		{
+			name:   "ensure service wildcard returns the most sensitive action of the service",
+			action: "sts:*",
+			want:   policy.AccessLevelWrite,
+		},
+		{
+			name:   "ensure wildcard which matches no catalog action is classified by its prefix",
+			action: "s3:GetAccessPoint*",
+			want:   policy.AccessLevelRead,
+		},
+		{
+			name:   "ensure action of an unknown service is classified by its prefix",
+			action: "athena:ListWorkGroups",
+			want:   policy.AccessLevelList,
+		},
+		{
+			name:   "ensure describe action which is not in the catalog is list, as in the catalog",
+			action: "ec2:DescribeCapacityBlockOfferings",
+			want:   policy.AccessLevelList,
+		},
+		{
+			name:   "ensure unknown policy action is permissions management",
+			action: "glue:PutResourcePolicy",
+			want:   policy.AccessLevelPermissionsManagement,
+		},
+		{
+			name:   "ensure unknown action without a known prefix is write",
+			action: "athena:StartQueryExecution",
+			want:   policy.AccessLevelWrite,
+		},
+		{
+			name:   "ensure wildcard of an unknown service is permissions management",
+			action: "athena:*",
+			want:   policy.AccessLevelPermissionsManagement,
+		},
+		{
+			name:   "ensure wildcard service resolves to the level of the matching action",
+			action: "s*:GetObject",
+			want:   policy.AccessLevelRead,
+		},
+		{
+			name:   "ensure wildcard service resolves to the most sensitive action of every matching service",
+			action: "s?s:Set*Attributes",
+			want:   policy.AccessLevelPermissionsManagement,
+		},
+		{
+			name:   "ensure wildcard service and action resolve to the most sensitive matching action",
+			action: "*:Put*",
+			want:   policy.AccessLevelPermissionsManagement,
+		},
+		{
+			name:   "ensure wildcard service which matches no catalog service is classified by its prefix",
+			action: "athen?:List*",
+			want:   policy.AccessLevelList,
+		},
+		{
+			name:   "ensure global wildcard is permissions management",
+			action: "*",
+			want:   policy.AccessLevelPermissionsManagement,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := AccessLevel(tt.action)
+			if err != nil {
+				t.Fatalf("AccessLevel() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("AccessLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package assertions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/wildcard"
+)
+
+var (
+	ErrInvalidAssertion = errors.New("invalid assertion")
+)
+
+const (
+	ExpectAbsent  = "absent"
+	ExpectPresent = "present"
+)
+
+// File represents a file of assertions about the policies generated from markers.
+type File struct {
+	Assertions []*Assertion `yaml:"assertions"`
+}
+
+// Assertion represents an expectation that grants matching a set of match expressions are either
+// absent from, or present in, the generated policies.
+type Assertion struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Match       Match  `yaml:"match"`
+	Expect      string `yaml:"expect"`
+}
+
+// Match represents a set of match expressions which select grants from the generated policies.
+// A grant matches when every expression which is set matches it.  Policies, actions and
+// resources are lists of patterns which may contain wildcards, of which any may match.  An
+// action or resource pattern matches a grant when either matches the other, so that a grant of
+// s3:* matches the pattern s3:PutObject and a grant of s3:PutObject matches the pattern s3:*.
+type Match struct {
+	Policies  []string `yaml:"policies,omitempty"`
+	Effect    string   `yaml:"effect,omitempty"`
+	Actions   []string `yaml:"actions,omitempty"`
+	Resources []string `yaml:"resources,omitempty"`
+	Condition *bool    `yaml:"condition,omitempty"`
+}
+
+// MatchedGrant represents a grant of a policy which matches an assertion.
+type MatchedGrant struct {
+	Policy string
+	Grant  aws.Grant
+}
+
+// String returns the string representation of a matched grant.
+func (matched MatchedGrant) String() string {
+	return fmt.Sprintf("%s: %s", matched.Policy, matched.Grant)
+}
+
+// Violation represents an assertion which does not hold for the generated policies.
+type Violation struct {
+	Assertion *Assertion
+	Grants    []MatchedGrant
+}
+
+// Report represents the result of evaluating a file of assertions.
+type Report struct {
+	Assertions int
+	Violations []*Violation
+}
+
+// Read reads and validates a file of assertions from a path.
+func Read(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read assertions file [%s] - %w", path, err)
+	}
+
+	file, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid assertions file [%s] - %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Parse parses and validates a file of assertions.  Unknown fields are rejected so that a
+// misspelled match expression does not silently match every grant.
+func Parse(data []byte) (*File, error) {
+	file := &File{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse assertions - %w", err)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Validate validates that every assertion in a file is valid.
+func (file *File) Validate() error {
+	for i, assertion := range file.Assertions {
+		if assertion.Name == "" {
+			return fmt.Errorf("%w at index [%d] - missing name", ErrInvalidAssertion, i)
+		}
+
+		if assertion.Expect != ExpectAbsent && assertion.Expect != ExpectPresent {
+			return fmt.Errorf(
+				"%w [%s] - expect must be one of [%s, %s], found [%s]",
+				ErrInvalidAssertion, assertion.Name, ExpectAbsent, ExpectPresent, assertion.Expect,
+			)
+		}
+
+		effect := assertion.Match.Effect
+		if effect != "" && effect != aws.ValidEffectAllow && effect != aws.ValidEffectDeny {
+			return fmt.Errorf(
+				"%w [%s] - effect must be one of [%s, %s], found [%s]",
+				ErrInvalidAssertion, assertion.Name, aws.ValidEffectAllow, aws.ValidEffectDeny, effect,
+			)
+		}
+	}
+
+	return nil
+}
+
+// NewReport evaluates a file of assertions against a set of policy documents keyed by the
+// policy name.
+func NewReport(file *File, documents map[string]*aws.PolicyDocument) *Report {
+	report := &Report{
+		Assertions: len(file.Assertions),
+		Violations: []*Violation{},
+	}
+
+	for _, assertion := range file.Assertions {
+		if violation := assertion.Evaluate(documents); violation != nil {
+			report.Violations = append(report.Violations, violation)
+		}
+	}
+
+	return report
+}
+
+// Evaluate evaluates an assertion against a set of policy documents keyed by the policy name,
+// returning a violation if the assertion does not hold.
+func (assertion *Assertion) Evaluate(documents map[string]*aws.PolicyDocument) *Violation {
+	names := make([]string, 0, len(documents))
+	for name := range documents {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	matched := []MatchedGrant{}
+
+	for _, name := range names {
+		for _, grant := range documents[name].Grants() {
+			if assertion.Match.Matches(name, grant) {
+				matched = append(matched, MatchedGrant{Policy: name, Grant: grant})
+			}
+		}
+	}
+
+	switch {
+	case assertion.Expect == ExpectAbsent && len(matched) > 0:
+		return &Violation{Assertion: assertion, Grants: matched}
+	case assertion.Expect == ExpectPresent && len(matched) == 0:
+		return &Violation{Assertion: assertion, Grants: matched}
+	default:
+		return nil
+	}
+}
+
+// Matches determines whether a grant of a given policy matches every match expression.
+func (match *Match) Matches(policy string, grant aws.Grant) bool {
+	if len(match.Policies) > 0 && !aws.MatchesAny(match.Policies, policy, wildcard.Match) {
+		return false
+	}
+
+	if match.Effect != "" && match.Effect != grant.Effect {
+		return false
+	}
+
+	if len(match.Actions) > 0 && !aws.MatchesAny(match.Actions, grant.Action, aws.Overlaps(aws.MatchAction)) {
+		return false
+	}
+
+	if len(match.Resources) > 0 && !aws.MatchesAny(match.Resources, grant.Resource, aws.Overlaps(aws.MatchResource)) {
+		return false
+	}
+
+	if match.Condition != nil && *match.Condition != (grant.Condition != "") {
+		return false
+	}
+
+	return true
+}
+
+// String returns the string representation of the match expressions.
+func (match *Match) String() string {
+	expressions := []string{}
+
+	for _, expression := range []struct {
+		name   string
+		values []string
+	}{
+		{name: "policies", values: match.Policies},
+		{name: "actions", values: match.Actions},
+		{name: "resources", values: match.Resources},
+	} {
+		if len(expression.values) > 0 {
+			expressions = append(expressions, fmt.Sprintf("%s=[%s]", expression.name, strings.Join(expression.values, ", ")))
+		}
+	}
+
+	if match.Effect != "" {
+		expressions = append(expressions, fmt.Sprintf("effect=%s", match.Effect))
+	}
+
+	if match.Condition != nil {
+		expressions = append(expressions, fmt.Sprintf("condition=%t", *match.Condition))
+	}
+
+	if len(expressions) == 0 {
+		return "any grant"
+	}
+
+	return strings.Join(expressions, ", ")
+}
+
+// Failed returns whether or not any assertion was violated.
+func (report *Report) Failed() bool {
+	return len(report.Violations) > 0
+}
+
+// Text renders a report as human-readable text listing each violated assertion along with the
+// grants which violate it.
+func (report *Report) Text() []byte {
+	text := &bytes.Buffer{}
+
+	for _, violation := range report.Violations {
+		assertion := violation.Assertion
+
+		fmt.Fprintf(text, "assertion [%s] failed", assertion.Name)
+
+		if assertion.Description != "" {
+			fmt.Fprintf(text, ": %s", assertion.Description)
+		}
+
+		fmt.Fprintln(text)
+
+		if assertion.Expect == ExpectPresent {
+			fmt.Fprintf(text, "    expected a grant matching [%s] but found none\n", &assertion.Match)
+
+			continue
+		}
+
+		fmt.Fprintf(text, "    expected no grants matching [%s] but found:\n", &assertion.Match)
+
+		for _, grant := range violation.Grants {
+			fmt.Fprintf(text, "        %s\n", grant)
+		}
+	}
+
+	fmt.Fprintf(text, "\nassertions: %d, failed: %d\n", report.Assertions, len(report.Violations))
+
+	return text.Bytes()
+}
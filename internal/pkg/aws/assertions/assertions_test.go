@@ -0,0 +1,205 @@
+package assertions
+
+import (
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "ensure valid assertions are parsed",
+			data: `
+assertions:
+  - name: never-write
+    match:
+      policies: [uninstaller-local]
+      effect: Allow
+      actions: ["s3:PutObject"]
+    expect: absent
+  - name: reads
+    match:
+      actions: ["s3:GetObject"]
+      condition: false
+    expect: present
+`,
+			want: 2,
+		},
+		{
+			name: "ensure empty file is parsed",
+			data: "",
+			want: 0,
+		},
+		{
+			name: "ensure unknown field returns an error",
+			data: `
+assertions:
+  - name: never-write
+    match:
+      action: ["s3:PutObject"]
+    expect: absent
+`,
+			wantErr: true,
+		},
+		{
+			name: "ensure missing name returns an error",
+			data: `
+assertions:
+  - expect: absent
+`,
+			wantErr: true,
+		},
+		{
+			name: "ensure invalid expect returns an error",
+			data: `
+assertions:
+  - name: never-write
+    expect: never
+`,
+			wantErr: true,
+		},
+		{
+			name: "ensure invalid effect returns an error",
+			data: `
+assertions:
+  - name: never-write
+    match:
+      effect: allow
+    expect: absent
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if len(got.Assertions) != tt.want {
+				t.Errorf("Parse() assertions = %v, want %v", len(got.Assertions), tt.want)
+			}
+		})
+	}
+}
+
+func TestAssertion_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	documents := map[string]*aws.PolicyDocument{
+		"uninstaller-local": {
+			Statements: aws.Statements{
+				{Effect: aws.ValidEffectAllow, Action: []string{"s3:*"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+			},
+		},
+		"installer": {
+			Statements: aws.Statements{
+				{Effect: aws.ValidEffectAllow, Action: []string{"iam:PassRole"}, Resources: []string{"*"}},
+				{
+					Effect:    aws.ValidEffectAllow,
+					Action:    []string{"iam:GetRole"},
+					Resources: []string{"*"},
+					Condition: conditions.NewCondition("aws:ResourceTag/managed", "true", conditions.StringEqualsOperator),
+				},
+				{Effect: aws.ValidEffectDeny, Action: []string{"s3:DeleteBucket"}, Resources: []string{"*"}},
+			},
+		},
+	}
+
+	condition := func(value bool) *bool { return &value }
+
+	tests := []struct {
+		name       string
+		assertion  *Assertion
+		wantGrants int
+		wantPass   bool
+	}{
+		{
+			name: "ensure absent assertion matching a wildcard grant fails",
+			assertion: &Assertion{
+				Name:   "never-write",
+				Match:  Match{Policies: []string{"uninstaller-*"}, Effect: aws.ValidEffectAllow, Actions: []string{"s3:PutObject"}},
+				Expect: ExpectAbsent,
+			},
+			wantGrants: 1,
+		},
+		{
+			name: "ensure absent assertion matching only a deny passes",
+			assertion: &Assertion{
+				Name:   "never-delete",
+				Match:  Match{Policies: []string{"installer"}, Effect: aws.ValidEffectAllow, Actions: []string{"s3:DeleteBucket"}},
+				Expect: ExpectAbsent,
+			},
+			wantPass: true,
+		},
+		{
+			name: "ensure absent assertion on unconditioned grants fails",
+			assertion: &Assertion{
+				Name:   "iam-conditioned",
+				Match:  Match{Actions: []string{"iam:*"}, Condition: condition(false)},
+				Expect: ExpectAbsent,
+			},
+			wantGrants: 1,
+		},
+		{
+			name: "ensure absent assertion with a non-matching resource passes",
+			assertion: &Assertion{
+				Name:   "other-bucket",
+				Match:  Match{Actions: []string{"s3:GetObject"}, Resources: []string{"arn:aws:s3:::other/*"}},
+				Expect: ExpectAbsent,
+			},
+			wantPass: true,
+		},
+		{
+			name: "ensure present assertion with a matching grant passes",
+			assertion: &Assertion{
+				Name:   "reads-roles",
+				Match:  Match{Policies: []string{"installer"}, Actions: []string{"iam:GetRole"}, Condition: condition(true)},
+				Expect: ExpectPresent,
+			},
+			wantPass: true,
+		},
+		{
+			name: "ensure present assertion without a matching grant fails",
+			assertion: &Assertion{
+				Name:   "creates-roles",
+				Match:  Match{Actions: []string{"iam:CreateRole"}},
+				Expect: ExpectPresent,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tt.assertion.Evaluate(documents)
+			if (got == nil) != tt.wantPass {
+				t.Fatalf("Assertion.Evaluate() = %v, want pass %v", got, tt.wantPass)
+			}
+
+			if got != nil && len(got.Grants) != tt.wantGrants {
+				t.Errorf("Assertion.Evaluate() grants = %v, want %v", got.Grants, tt.wantGrants)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/actions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const wildcards = "*?"
+
+// Justification represents a marker which justifies a grant.
+type Justification struct {
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Source   *policy.Source    `json:"source,omitempty"`
+}
+
+// Excess represents a grant in the deployed policy which no marker justifies.
+type Excess struct {
+	aws.Grant
+
+	// Unjustified is the set of known actions that a wildcard action expands to which no marker
+	// justifies.  It is empty for actions without wildcards, or when the wildcard could not be
+	// expanded.
+	Unjustified []string `json:"unjustified,omitempty"`
+}
+
+// Missing represents a grant justified by a marker which is not in the deployed policy.
+type Missing struct {
+	aws.Grant
+
+	Justifications []Justification `json:"justifications"`
+}
+
+// Incomparable represents a statement in the deployed policy which cannot be compared with the
+// grants of markers, such as a statement with a condition key with multiple values.
+type Incomparable struct {
+	Index  int    `json:"index"`
+	SID    string `json:"sid,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Report represents the result of comparing a deployed policy with the policy generated from
+// markers.  Allow and Deny grants are compared separately, so that excess and missing grants
+// are allowed grants, while tighter grants are denied grants in the deployed policy which no
+// marker justifies and lost grants are denied grants justified by a marker which are not in the
+// deployed policy.
+type Report struct {
+	Name         string          `json:"name"`
+	Excess       []*Excess       `json:"excess"`
+	Missing      []*Missing      `json:"missing"`
+	Tighter      []aws.Grant     `json:"tighter"`
+	Lost         []*Missing      `json:"lost"`
+	Incomparable []*Incomparable `json:"incomparable"`
+}
+
+// grant represents a grant generated from a marker.
+type grant struct {
+	aws.Grant
+	Justification
+}
+
+// Parse parses a deployed policy for an audit.  Statements which cannot be compared with the
+// grants of markers, because they have elements which markers cannot represent, are returned as
+// incomparable rather than an error.
+func Parse(data []byte) (*aws.PolicyDocument, []*Incomparable, error) {
+	deployed, skipped, err := aws.ParseSupportedPolicyDocument(data, func(statement *aws.Statement) error {
+		if statement.HasNotElements() {
+			return fmt.Errorf("%w [NotAction, NotResource]", aws.ErrUnsupportedPolicy)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	incomparable := make([]*Incomparable, len(skipped))
+	for i := range skipped {
+		incomparable[i] = &Incomparable{Index: skipped[i].Index, SID: skipped[i].SID, Reason: skipped[i].Err.Error()}
+	}
+
+	return deployed, incomparable, nil
+}
+
+// NewReport compares a deployed policy with the policy generated from a set of markers for the
+// policy with the given name.  A grant is covered by another grant with the same effect when
+// the action and resource of the other grant match its action and resource, allowing for
+// wildcards, and the other grant has no condition or the same condition.
+//
+// A deployed grant is excess, or tighter when it is denied, when no generated grant covers it.
+// Wildcard actions of excess grants are expanded, where possible, to the known actions which no
+// generated grant covers.  A generated grant is missing, or lost when it is denied, when no
+// deployed grant covers it.  A broader deployed deny therefore covers the narrower denies of
+// markers and is reported as tighter rather than excess.
+func NewReport(name string, deployed *aws.PolicyDocument, policyMarkers []policy.Marker) (*Report, error) {
+	for i := range deployed.Statements {
+		if deployed.Statements[i].HasNotElements() {
+			return nil, fmt.Errorf(
+				"%w [NotAction, NotResource] - statement [%d] with sid [%s] cannot be audited",
+				aws.ErrUnsupportedPolicy, i, deployed.Statements[i].SID,
+			)
+		}
+	}
+
+	known, err := actions.Known()
+	if err != nil {
+		return nil, err
+	}
+
+	generated := []grant{}
+
+	for i := range policyMarkers {
+		original, ok := policyMarkers[i].(*aws.Marker)
+		if !ok {
+			return nil, aws.ErrMarkerConvert
+		}
+
+		if original.GetName() != name {
+			continue
+		}
+
+		// default a copy of the marker so that the markers of the caller are not modified
+		marker := *original
+		marker.WithDefault()
+
+		for _, markerGrant := range aws.NewPolicyDocument(marker).Grants() {
+			generated = append(generated, grant{
+				Grant: markerGrant,
+				Justification: Justification{
+					Reason:   marker.ReasonColumn(),
+					Metadata: marker.GetMetadata(),
+					Source:   marker.GetSource(),
+				},
+			})
+		}
+
+		// use the actions of markers to expand wildcards as well
+		known = append(known, marker.PermissionColumn())
+	}
+
+	deployedGrants := deployed.Grants()
+
+	report := &Report{
+		Name:         name,
+		Excess:       []*Excess{},
+		Missing:      []*Missing{},
+		Tighter:      []aws.Grant{},
+		Lost:         []*Missing{},
+		Incomparable: []*Incomparable{},
+	}
+
+	// find the deployed grants which are not covered by a generated grant
+	for _, deployedGrant := range deployedGrants {
+		justified := false
+
+		for i := range generated {
+			if covers(generated[i].Grant, deployedGrant) {
+				justified = true
+
+				break
+			}
+		}
+
+		if justified {
+			continue
+		}
+
+		if deployedGrant.Effect == aws.ValidEffectDeny {
+			report.Tighter = append(report.Tighter, deployedGrant)
+
+			continue
+		}
+
+		excess := &Excess{Grant: deployedGrant}
+
+		if strings.ContainsAny(deployedGrant.Action, wildcards) {
+			excess.Unjustified = unjustifiedActions(deployedGrant, generated, known)
+		}
+
+		report.Excess = append(report.Excess, excess)
+	}
+
+	// find the generated grants which are not covered by a deployed grant, collecting each of
+	// the markers which justify them
+	missing := map[string]*Missing{}
+
+	for i := range generated {
+		covered := false
+
+		for _, deployedGrant := range deployedGrants {
+			if covers(deployedGrant, generated[i].Grant) {
+				covered = true
+
+				break
+			}
+		}
+
+		if covered {
+			continue
+		}
+
+		key := strings.ToLower(generated[i].String())
+		if _, found := missing[key]; !found {
+			missing[key] = &Missing{Grant: generated[i].Grant}
+
+			if generated[i].Effect == aws.ValidEffectDeny {
+				report.Lost = append(report.Lost, missing[key])
+			} else {
+				report.Missing = append(report.Missing, missing[key])
+			}
+		}
+
+		missing[key].Justifications = append(missing[key].Justifications, generated[i].Justification)
+	}
+
+	return report, nil
+}
+
+// HasExcess returns whether the deployed policy grants more than is justified, either with
+// excess grants or lost denies.
+func (report *Report) HasExcess() bool {
+	return len(report.Excess) > 0 || len(report.Lost) > 0
+}
+
+// HasMissing returns whether the deployed policy grants less than is justified, either with
+// missing grants or tighter denies.
+func (report *Report) HasMissing() bool {
+	return len(report.Missing) > 0 || len(report.Tighter) > 0
+}
+
+// covers determines whether a grant covers another grant.
+func covers(grant, other aws.Grant) bool {
+	return grant.Effect == other.Effect &&
+		(grant.Condition == "" || grant.Condition == other.Condition) &&
+		aws.MatchAction(grant.Action, other.Action) &&
+		aws.MatchResource(grant.Resource, other.Resource)
+}
+
+// unjustifiedActions expands the wildcard action of a grant to the known actions which no
+// generated grant covers.
+func unjustifiedActions(wildcard aws.Grant, generated []grant, known []string) []string {
+	unique := map[string]bool{}
+	unjustified := []string{}
+
+	for _, action := range known {
+		if unique[strings.ToLower(action)] || strings.ContainsAny(action, wildcards) || !aws.MatchAction(wildcard.Action, action) {
+			continue
+		}
+
+		unique[strings.ToLower(action)] = true
+
+		expanded := wildcard
+		expanded.Action = action
+
+		justified := false
+
+		for i := range generated {
+			if covers(generated[i].Grant, expanded) {
+				justified = true
+
+				break
+			}
+		}
+
+		if !justified {
+			unjustified = append(unjustified, action)
+		}
+	}
+
+	sort.Strings(unjustified)
+
+	return unjustified
+}
@@ -0,0 +1,321 @@
+package audit
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+
+	newMarkers := func() []policy.Marker {
+		return []policy.Marker{
+			&aws.Marker{
+				Name:     pointers.String("test"),
+				Action:   pointers.String("s3:GetObject"),
+				Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				Reason:   pointers.String("read objects"),
+			},
+			&aws.Marker{
+				Name:   pointers.String("test"),
+				Action: pointers.String("ec2:DescribeVpcs"),
+				Reason: pointers.String("list vpcs"),
+			},
+			&aws.Marker{
+				Name:   pointers.String("other"),
+				Action: pointers.String("iam:ListRoles"),
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		deployed    *aws.PolicyDocument
+		wantExcess  []string
+		wantMissing []string
+	}{
+		{
+			name: "ensure equivalent policy has no differences",
+			deployed: &aws.PolicyDocument{
+				Statements: aws.Statements{
+					{Effect: "Allow", Action: []string{"EC2:DescribeVpcs"}, Resources: []string{"*"}},
+					{Effect: "Allow", Action: []string{"s3:GetObject"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+				},
+			},
+			wantExcess:  []string{},
+			wantMissing: []string{},
+		},
+		{
+			name: "ensure broader deployed grants are excess and narrower deployed grants are missing",
+			deployed: &aws.PolicyDocument{
+				Statements: aws.Statements{
+					{Effect: "Allow", Action: []string{"s3:*"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+					{Effect: "Allow", Action: []string{"ec2:DescribeVpcs"}, Resources: []string{"arn:aws:ec2:*:*:vpc/*"}},
+				},
+			},
+			wantExcess: []string{
+				"Allow s3:* on arn:aws:s3:::bucket/*",
+			},
+			wantMissing: []string{
+				"Allow ec2:DescribeVpcs on *",
+			},
+		},
+		{
+			name: "ensure deployed grants with a condition do not cover grants without a condition",
+			deployed: &aws.PolicyDocument{
+				Statements: aws.Statements{
+					{
+						Effect:    "Allow",
+						Action:    []string{"ec2:DescribeVpcs"},
+						Resources: []string{"*"},
+						Condition: conditions.NewCondition("aws:RequestedRegion", "us-east-1", "StringEquals"),
+					},
+					{Effect: "Allow", Action: []string{"s3:GetObject"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+				},
+			},
+			wantExcess:  []string{},
+			wantMissing: []string{"Allow ec2:DescribeVpcs on *"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := NewReport("test", tt.deployed, newMarkers())
+			if err != nil {
+				t.Fatalf("NewReport() error = %v", err)
+			}
+
+			gotExcess := []string{}
+			for _, excess := range report.Excess {
+				gotExcess = append(gotExcess, excess.String())
+			}
+
+			gotMissing := []string{}
+			for _, missing := range report.Missing {
+				gotMissing = append(gotMissing, missing.String())
+			}
+
+			if !reflect.DeepEqual(gotExcess, tt.wantExcess) {
+				t.Errorf("NewReport() excess = %v, want %v", gotExcess, tt.wantExcess)
+			}
+
+			if !reflect.DeepEqual(gotMissing, tt.wantMissing) {
+				t.Errorf("NewReport() missing = %v, want %v", gotMissing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestNewReport_Deny(t *testing.T) {
+	t.Parallel()
+
+	newMarkers := func() []policy.Marker {
+		return []policy.Marker{
+			&aws.Marker{
+				Name:     pointers.String("test"),
+				Action:   pointers.String("s3:GetObject"),
+				Resource: pointers.String("arn:aws:s3:::bucket/*"),
+			},
+			&aws.Marker{
+				Name:     pointers.String("test"),
+				Effect:   pointers.String("Deny"),
+				Action:   pointers.String("s3:DeleteObject"),
+				Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				Reason:   pointers.String("protect objects"),
+			},
+		}
+	}
+
+	allow := aws.Statement{Effect: "Allow", Action: []string{"s3:GetObject"}, Resources: []string{"arn:aws:s3:::bucket/*"}}
+
+	tests := []struct {
+		name        string
+		deployed    *aws.PolicyDocument
+		wantExcess  []string
+		wantMissing []string
+		wantTighter []string
+		wantLost    []string
+	}{
+		{
+			name: "ensure equivalent deny has no differences",
+			deployed: &aws.PolicyDocument{
+				Statements: aws.Statements{
+					allow,
+					{Effect: "Deny", Action: []string{"s3:DeleteObject"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+				},
+			},
+			wantExcess:  []string{},
+			wantMissing: []string{},
+			wantTighter: []string{},
+			wantLost:    []string{},
+		},
+		{
+			name: "ensure broader deployed deny is tighter rather than excess",
+			deployed: &aws.PolicyDocument{
+				Statements: aws.Statements{
+					allow,
+					{Effect: "Deny", Action: []string{"s3:Delete*"}, Resources: []string{"*"}},
+				},
+			},
+			wantExcess:  []string{},
+			wantMissing: []string{},
+			wantTighter: []string{"Deny s3:Delete* on *"},
+			wantLost:    []string{},
+		},
+		{
+			name: "ensure deny missing from the deployed policy is lost",
+			deployed: &aws.PolicyDocument{
+				Statements: aws.Statements{allow},
+			},
+			wantExcess:  []string{},
+			wantMissing: []string{},
+			wantTighter: []string{},
+			wantLost:    []string{"Deny s3:DeleteObject on arn:aws:s3:::bucket/*"},
+		},
+		{
+			name: "ensure deployed deny with a condition does not cover deny without a condition",
+			deployed: &aws.PolicyDocument{
+				Statements: aws.Statements{
+					allow,
+					{
+						Effect:    "Deny",
+						Action:    []string{"s3:DeleteObject"},
+						Resources: []string{"arn:aws:s3:::bucket/*"},
+						Condition: conditions.NewCondition("aws:RequestedRegion", "us-east-1", "StringEquals"),
+					},
+				},
+			},
+			wantExcess:  []string{},
+			wantMissing: []string{},
+			wantTighter: []string{},
+			wantLost:    []string{"Deny s3:DeleteObject on arn:aws:s3:::bucket/*"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := NewReport("test", tt.deployed, newMarkers())
+			if err != nil {
+				t.Fatalf("NewReport() error = %v", err)
+			}
+
+			gotExcess := []string{}
+			for _, excess := range report.Excess {
+				gotExcess = append(gotExcess, excess.String())
+			}
+
+			gotMissing := []string{}
+			for _, missing := range report.Missing {
+				gotMissing = append(gotMissing, missing.String())
+			}
+
+			gotTighter := []string{}
+			for _, tighter := range report.Tighter {
+				gotTighter = append(gotTighter, tighter.String())
+			}
+
+			gotLost := []string{}
+			for _, lost := range report.Lost {
+				gotLost = append(gotLost, lost.String())
+			}
+
+			if !reflect.DeepEqual(gotExcess, tt.wantExcess) {
+				t.Errorf("NewReport() excess = %v, want %v", gotExcess, tt.wantExcess)
+			}
+
+			if !reflect.DeepEqual(gotMissing, tt.wantMissing) {
+				t.Errorf("NewReport() missing = %v, want %v", gotMissing, tt.wantMissing)
+			}
+
+			if !reflect.DeepEqual(gotTighter, tt.wantTighter) {
+				t.Errorf("NewReport() tighter = %v, want %v", gotTighter, tt.wantTighter)
+			}
+
+			if !reflect.DeepEqual(gotLost, tt.wantLost) {
+				t.Errorf("NewReport() lost = %v, want %v", gotLost, tt.wantLost)
+			}
+
+			if report.HasExcess() != (len(tt.wantExcess)+len(tt.wantLost) > 0) {
+				t.Errorf("NewReport() has excess = %v", report.HasExcess())
+			}
+
+			if report.HasMissing() != (len(tt.wantMissing)+len(tt.wantTighter) > 0) {
+				t.Errorf("NewReport() has missing = %v", report.HasMissing())
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	data := `{"Statement": [
+		{"Sid": "Teams", "Effect": "Allow", "Action": "s3:GetObject", "Condition": {"StringEquals": {"aws:PrincipalTag/team": ["a", "b"]}}},
+		{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket/*"},
+		{"Sid": "Protect", "Effect": "Deny", "NotAction": "s3:Get*"}
+	]}`
+
+	deployed, incomparable, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(deployed.Statements) != 1 {
+		t.Errorf("Parse() statement count = %v, want %v", len(deployed.Statements), 1)
+	}
+
+	got := []string{}
+	for _, statement := range incomparable {
+		got = append(got, fmt.Sprintf("%d %s", statement.Index, statement.SID))
+	}
+
+	if want := []string{"0 Teams", "2 Protect"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() incomparable = %v, want %v", got, want)
+	}
+}
+
+func TestNewReport_Unjustified(t *testing.T) {
+	t.Parallel()
+
+	deployed := &aws.PolicyDocument{
+		Statements: aws.Statements{
+			{Effect: "Allow", Action: []string{"s3:*Object"}, Resources: []string{"*"}},
+		},
+	}
+
+	policyMarkers := []policy.Marker{
+		&aws.Marker{Name: pointers.String("test"), Action: pointers.String("s3:GetObject")},
+	}
+
+	report, err := NewReport("test", deployed, policyMarkers)
+	if err != nil {
+		t.Fatalf("NewReport() error = %v", err)
+	}
+
+	if len(report.Excess) != 1 {
+		t.Fatalf("NewReport() excess count = %v, want %v", len(report.Excess), 1)
+	}
+
+	want := []string{"s3:DeleteObject", "s3:PutObject", "s3:RestoreObject"}
+	if got := report.Excess[0].Unjustified; !reflect.DeepEqual(got, want) {
+		t.Errorf("NewReport() unjustified = %v, want %v", got, want)
+	}
+
+	// the markers of the caller must not be defaulted
+	if marker := policyMarkers[0].(*aws.Marker); marker.Effect != nil || marker.Resource != nil {
+		t.Errorf("NewReport() modified marker = %+v", marker)
+	}
+}
@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Render renders a report in the given format.
+func (report *Report) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatText:
+		return report.Text(), nil
+	case FormatJSON:
+		return report.JSON()
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s]", ErrInvalidFormat, format, FormatText, FormatJSON)
+	}
+}
+
+// Text renders a report as human-readable text.
+func (report *Report) Text() []byte {
+	text := &bytes.Buffer{}
+
+	fmt.Fprintf(text, "audit of policy [%s]\n", report.Name)
+
+	fmt.Fprintf(text, "\nexcess grants (deployed but not justified by a marker): %d\n", len(report.Excess))
+
+	for _, excess := range report.Excess {
+		fmt.Fprintf(text, "  - %s\n", excess.Grant)
+
+		if len(excess.Unjustified) > 0 {
+			fmt.Fprintf(text, "      unjustified actions: %s\n", strings.Join(excess.Unjustified, ", "))
+		}
+	}
+
+	fmt.Fprintf(text, "\nmissing grants (justified by a marker but not deployed): %d\n", len(report.Missing))
+
+	for _, missing := range report.Missing {
+		fmt.Fprintf(text, "  - %s\n", missing.Grant)
+
+		for _, justification := range missing.Justifications {
+			fmt.Fprintf(text, "      marker at [%s]: %s\n", justification.Source, justification.Reason)
+		}
+	}
+
+	fmt.Fprintf(text, "\ntighter denies (deployed but not justified by a marker): %d\n", len(report.Tighter))
+
+	for _, tighter := range report.Tighter {
+		fmt.Fprintf(text, "  - %s\n", tighter)
+	}
+
+	fmt.Fprintf(text, "\nlost denies (justified by a marker but not deployed): %d\n", len(report.Lost))
+
+	for _, lost := range report.Lost {
+		fmt.Fprintf(text, "  - %s\n", lost.Grant)
+
+		for _, justification := range lost.Justifications {
+			fmt.Fprintf(text, "      marker at [%s]: %s\n", justification.Source, justification.Reason)
+		}
+	}
+
+	if len(report.Incomparable) > 0 {
+		fmt.Fprintf(text, "\nstatements not comparable with markers: %d\n", len(report.Incomparable))
+
+		for _, incomparable := range report.Incomparable {
+			fmt.Fprintf(text, "  - statement [%d] with sid [%s]: %s\n", incomparable.Index, incomparable.SID, incomparable.Reason)
+		}
+	}
+
+	return text.Bytes()
+}
+
+// JSON renders a report as JSON.
+func (report *Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal audit report - %w", err)
+	}
+
+	return data, nil
+}
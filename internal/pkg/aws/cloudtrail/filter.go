@@ -0,0 +1,71 @@
+package cloudtrail
+
+import (
+	"strings"
+	"time"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+)
+
+// Filter represents the criteria used to select CloudTrail records.  Empty criteria match all
+// records.
+type Filter struct {
+	// PrincipalARN matches the ARN of the principal, or the ARN of the issuer of its session
+	// such as an IAM role, and may contain wildcards.
+	PrincipalARN string
+
+	// SessionName matches the session name of an assumed role and may contain wildcards.
+	SessionName string
+
+	// Start and End select records within a time window.
+	Start time.Time
+	End   time.Time
+
+	// ExcludeErrors excludes records for requests which failed, such as those which were denied.
+	ExcludeErrors bool
+}
+
+// Matches determines whether a record matches the filter.
+func (filter *Filter) Matches(record *Record) bool {
+	if filter.ExcludeErrors && record.ErrorCode != "" {
+		return false
+	}
+
+	if !filter.Start.IsZero() && record.EventTime.Before(filter.Start) {
+		return false
+	}
+
+	if !filter.End.IsZero() && record.EventTime.After(filter.End) {
+		return false
+	}
+
+	if filter.PrincipalARN != "" {
+		matched := aws.MatchResource(filter.PrincipalARN, record.UserIdentity.ARN)
+
+		if context := record.UserIdentity.SessionContext; !matched && context != nil && context.SessionIssuer != nil {
+			matched = aws.MatchResource(filter.PrincipalARN, context.SessionIssuer.ARN)
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if filter.SessionName != "" && !aws.MatchResource(filter.SessionName, record.SessionName()) {
+		return false
+	}
+
+	return true
+}
+
+// SessionName returns the session name of a record made by an assumed role, which is the last
+// segment of an ARN such as arn:aws:sts::123456789012:assumed-role/role-name/session-name.  It
+// returns an empty string for other principals.
+func (record *Record) SessionName() string {
+	arn := record.UserIdentity.ARN
+	if !strings.Contains(arn, ":assumed-role/") {
+		return ""
+	}
+
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
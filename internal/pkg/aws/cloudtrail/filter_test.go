@@ -0,0 +1,79 @@
+package cloudtrail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	record := &Record{
+		EventTime: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		ErrorCode: "AccessDenied",
+		UserIdentity: UserIdentity{
+			ARN: "arn:aws:sts::123456789012:assumed-role/my-app/worker",
+			SessionContext: &SessionContext{
+				SessionIssuer: &SessionIssuer{ARN: "arn:aws:iam::123456789012:role/my-app"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   bool
+	}{
+		{
+			name:   "ensure empty filter matches",
+			filter: &Filter{},
+			want:   true,
+		},
+		{
+			name:   "ensure principal arn matches the session issuer",
+			filter: &Filter{PrincipalARN: "arn:aws:iam::*:role/my-app"},
+			want:   true,
+		},
+		{
+			name:   "ensure mismatched principal arn does not match",
+			filter: &Filter{PrincipalARN: "arn:aws:iam::*:role/other"},
+			want:   false,
+		},
+		{
+			name:   "ensure session name matches",
+			filter: &Filter{SessionName: "work*"},
+			want:   true,
+		},
+		{
+			name:   "ensure mismatched session name does not match",
+			filter: &Filter{SessionName: "scheduler"},
+			want:   false,
+		},
+		{
+			name:   "ensure record within the time window matches",
+			filter: &Filter{Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			want:   true,
+		},
+		{
+			name:   "ensure record outside the time window does not match",
+			filter: &Filter{Start: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+			want:   false,
+		},
+		{
+			name:   "ensure failed record does not match when errors are excluded",
+			filter: &Filter{ExcludeErrors: true},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.filter.Matches(record); got != tt.want {
+				t.Errorf("Filter.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
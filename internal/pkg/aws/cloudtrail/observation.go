@@ -0,0 +1,172 @@
+package cloudtrail
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/actions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	eventSourceS3       = "s3.amazonaws.com"
+	parameterBucketName = "bucketName"
+	parameterKey        = "key"
+
+	resourceAll = "*"
+)
+
+// Observation represents an IAM action, on a resource, that was observed in CloudTrail.
+type Observation struct {
+	Action    string    `json:"action"`
+	Resource  string    `json:"resource"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// Observe converts the records which match a filter into a set of observed IAM actions and
+// resources, ordered by action and resource.
+func Observe(records []Record, filter *Filter) ([]*Observation, error) {
+	observed := map[string]*Observation{}
+
+	for i := range records {
+		record := &records[i]
+		if !filter.Matches(record) {
+			continue
+		}
+
+		recordActions, err := actions.ForEvent(record.EventSource, record.EventName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine actions for event [%s:%s] - %w", record.EventSource, record.EventName, err)
+		}
+
+		for _, action := range recordActions {
+			for _, resource := range record.ResourceARNs() {
+				key := action + "|" + resource
+
+				observation, found := observed[key]
+				if !found {
+					observation = &Observation{Action: action, Resource: resource, FirstSeen: record.EventTime, LastSeen: record.EventTime}
+					observed[key] = observation
+				}
+
+				observation.Count++
+
+				if record.EventTime.Before(observation.FirstSeen) {
+					observation.FirstSeen = record.EventTime
+				}
+
+				if record.EventTime.After(observation.LastSeen) {
+					observation.LastSeen = record.EventTime
+				}
+			}
+		}
+	}
+
+	observations := make([]*Observation, 0, len(observed))
+	for _, observation := range observed {
+		observations = append(observations, observation)
+	}
+
+	sort.Slice(observations, func(i, j int) bool {
+		if observations[i].Action != observations[j].Action {
+			return observations[i].Action < observations[j].Action
+		}
+
+		return observations[i].Resource < observations[j].Resource
+	})
+
+	return observations, nil
+}
+
+// ResourceARNs returns the ARNs of the resources that a record acted upon.  S3 data events do
+// not list their resources, so the ARN is derived from the request parameters.  If no
+// resources can be determined, all resources are returned.
+func (record *Record) ResourceARNs() []string {
+	arns := []string{}
+
+	for _, resource := range record.Resources {
+		if resource.ARN != "" {
+			arns = append(arns, resource.ARN)
+		}
+	}
+
+	if len(arns) > 0 {
+		return arns
+	}
+
+	if record.EventSource == eventSourceS3 {
+		if bucket, ok := record.RequestParameters[parameterBucketName].(string); ok && bucket != "" {
+			if key, ok := record.RequestParameters[parameterKey].(string); ok && key != "" {
+				return []string{fmt.Sprintf("arn:aws:s3:::%s/%s", bucket, key)}
+			}
+
+			return []string{fmt.Sprintf("arn:aws:s3:::%s", bucket)}
+		}
+	}
+
+	return []string{resourceAll}
+}
+
+// ToMarkers converts a set of observations into a set of suggested markers for a policy with
+// the given name.  The reason of each marker is a placeholder to be replaced by its owner.
+func ToMarkers(name string, observations []*Observation) []*aws.Marker {
+	markers := make([]*aws.Marker, len(observations))
+
+	for i, observation := range observations {
+		markers[i] = &aws.Marker{
+			Name:     pointers.String(name),
+			Action:   pointers.String(observation.Action),
+			Resource: pointers.String(observation.Resource),
+			Reason: pointers.String(fmt.Sprintf(
+				"TODO - observed in CloudTrail [%d] times between [%s] and [%s]",
+				observation.Count,
+				observation.FirstSeen.Format(time.RFC3339),
+				observation.LastSeen.Format(time.RFC3339),
+			)),
+		}
+
+		markers[i].WithDefault()
+	}
+
+	return markers
+}
+
+// Uncovered returns the observations which are not allowed by any of a set of existing markers.
+// An observation is allowed when a marker allows a matching action on a matching resource.  If
+// the resource of an observation is unknown, only the action must match.
+func Uncovered(observations []*Observation, policyMarkers []policy.Marker) ([]*Observation, error) {
+	uncovered := []*Observation{}
+
+	for _, observation := range observations {
+		covered := false
+
+		for i := range policyMarkers {
+			marker, ok := policyMarkers[i].(*aws.Marker)
+			if !ok {
+				return nil, aws.ErrMarkerConvert
+			}
+
+			if marker.EffectColumn() != aws.ValidEffectAllow || !aws.MatchAction(marker.PermissionColumn(), observation.Action) {
+				continue
+			}
+
+			if observation.Resource == resourceAll || aws.MatchResource(marker.ResourceColumn(), observation.Resource) {
+				covered = true
+
+				break
+			}
+		}
+
+		if !covered {
+			uncovered = append(uncovered, observation)
+		}
+	}
+
+	return uncovered, nil
+}
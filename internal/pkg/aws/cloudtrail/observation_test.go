@@ -0,0 +1,131 @@
+package cloudtrail
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func TestObserve(t *testing.T) {
+	t.Parallel()
+
+	records, err := ReadRecords(testLogPath)
+	if err != nil {
+		t.Fatalf("ReadRecords() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   []string
+	}{
+		{
+			name:   "ensure all records are observed with an empty filter",
+			filter: &Filter{},
+			want: []string{
+				"ec2:DescribeVpcs|*",
+				"iam:ListRoles|*",
+				"lambda:InvokeFunction|arn:aws:lambda:us-east-1:123456789012:function:my-function",
+				"s3:GetObject|arn:aws:s3:::my-bucket/data.json",
+			},
+		},
+		{
+			name:   "ensure only matching records are observed",
+			filter: &Filter{PrincipalARN: "arn:aws:iam::123456789012:role/my-app", ExcludeErrors: true},
+			want: []string{
+				"lambda:InvokeFunction|arn:aws:lambda:us-east-1:123456789012:function:my-function",
+				"s3:GetObject|arn:aws:s3:::my-bucket/data.json",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			observations, err := Observe(records, tt.filter)
+			if err != nil {
+				t.Fatalf("Observe() error = %v", err)
+			}
+
+			got := make([]string, len(observations))
+			for i := range observations {
+				got[i] = observations[i].Action + "|" + observations[i].Resource
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Observe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUncovered(t *testing.T) {
+	t.Parallel()
+
+	observations := []*Observation{
+		{Action: "ec2:DescribeVpcs", Resource: "*"},
+		{Action: "s3:GetObject", Resource: "arn:aws:s3:::my-bucket/data.json"},
+		{Action: "s3:GetObject", Resource: "arn:aws:s3:::other-bucket/data.json"},
+	}
+
+	tests := []struct {
+		name    string
+		markers []policy.Marker
+		want    []*Observation
+	}{
+		{
+			name:    "ensure all observations are uncovered without markers",
+			markers: []policy.Marker{},
+			want:    observations,
+		},
+		{
+			name: "ensure observations matching a marker action and resource are covered",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Action:   pointers.String("ec2:Describe*"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:ec2:*:*:vpc/*"),
+				},
+				&aws.Marker{
+					Action:   pointers.String("s3:GetObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::my-bucket/*"),
+				},
+			},
+			want: []*Observation{observations[2]},
+		},
+		{
+			name: "ensure observations matching a deny marker are uncovered",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Action:   pointers.String("ec2:DescribeVpcs"),
+					Effect:   pointers.String(aws.ValidEffectDeny),
+					Resource: pointers.String("*"),
+				},
+			},
+			want: observations,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Uncovered(observations, tt.markers)
+			if err != nil {
+				t.Fatalf("Uncovered() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Uncovered() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package cloudtrail
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	extensionJSON = ".json"
+	extensionGzip = ".gz"
+)
+
+// gzipMagic is the header which identifies gzip compressed content.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Record represents a single CloudTrail event record.  Only the fields needed to determine the
+// principal, the action and the resources of an event are represented.
+type Record struct {
+	EventTime         time.Time              `json:"eventTime"`
+	EventSource       string                 `json:"eventSource"`
+	EventName         string                 `json:"eventName"`
+	ErrorCode         string                 `json:"errorCode,omitempty"`
+	UserIdentity      UserIdentity           `json:"userIdentity"`
+	Resources         []Resource             `json:"resources,omitempty"`
+	RequestParameters map[string]interface{} `json:"requestParameters,omitempty"`
+}
+
+// UserIdentity represents the identity of the principal which made a request.
+type UserIdentity struct {
+	Type           string          `json:"type"`
+	ARN            string          `json:"arn"`
+	SessionContext *SessionContext `json:"sessionContext,omitempty"`
+}
+
+// SessionContext represents the session of a principal using temporary credentials.
+type SessionContext struct {
+	SessionIssuer *SessionIssuer `json:"sessionIssuer,omitempty"`
+}
+
+// SessionIssuer represents the principal which issued a session, such as an IAM role.
+type SessionIssuer struct {
+	ARN string `json:"arn"`
+}
+
+// Resource represents a resource that an event acted upon.
+type Resource struct {
+	ARN string `json:"ARN"`
+}
+
+// logFile represents the content of a CloudTrail log file, as delivered to S3, or the output
+// of the CloudTrail LookupEvents API, where each event is an embedded JSON string.
+type logFile struct {
+	Records []Record `json:"Records"`
+	Events  []struct {
+		CloudTrailEvent string `json:"CloudTrailEvent"`
+	} `json:"Events"`
+}
+
+// ReadRecords reads the CloudTrail records from a set of paths.  A path may be a JSON or gzip
+// compressed JSON log file or a directory, which is read recursively.  Only the files of a
+// directory with a .json or .json.gz extension are read, so that other files, such as notes
+// stored alongside the logs, are ignored.
+func ReadRecords(paths ...string) ([]Record, error) {
+	records := []Record{}
+
+	for _, path := range paths {
+		err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+
+			if filePath != path && !isLogFile(filePath) {
+				return nil
+			}
+
+			fileRecords, err := readFile(filePath)
+			if err != nil {
+				return err
+			}
+
+			records = append(records, fileRecords...)
+
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to read cloudtrail records from path [%s] - %w", path, err)
+		}
+	}
+
+	return records, nil
+}
+
+// isLogFile determines whether a file found within a directory is a CloudTrail log file by its
+// extension.
+func isLogFile(path string) bool {
+	lower := strings.ToLower(path)
+
+	return strings.HasSuffix(lower, extensionJSON) || strings.HasSuffix(lower, extensionJSON+extensionGzip)
+}
+
+// readFile reads the CloudTrail records from a single JSON or gzip compressed JSON file.
+func readFile(path string) ([]Record, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file [%s] - %w", path, err)
+	}
+
+	if bytes.HasPrefix(content, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress file [%s] - %w", path, err)
+		}
+
+		defer reader.Close()
+
+		if content, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("unable to decompress file [%s] - %w", path, err)
+		}
+	}
+
+	log := &logFile{}
+	if err := json.Unmarshal(content, log); err != nil {
+		return nil, fmt.Errorf("unable to parse cloudtrail log file [%s] - %w", path, err)
+	}
+
+	records := log.Records
+
+	for i := range log.Events {
+		record := Record{}
+		if err := json.Unmarshal([]byte(log.Events[i].CloudTrailEvent), &record); err != nil {
+			return nil, fmt.Errorf("unable to parse cloudtrail event in file [%s] - %w", path, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
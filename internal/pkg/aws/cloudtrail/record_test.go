@@ -0,0 +1,61 @@
+package cloudtrail
+
+import (
+	"testing"
+)
+
+const testLogPath = "test/input/logs"
+
+func TestReadRecords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		paths     []string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "ensure records are read from json and gzip files in a directory, ignoring other files",
+			paths:     []string{testLogPath},
+			wantCount: 5,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure records are read from a single json file",
+			paths:     []string{testLogPath + "/records.json"},
+			wantCount: 4,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure records are read from a single gzip file of lookup events",
+			paths:     []string{testLogPath + "/events.json.gz"},
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure missing path returns an error",
+			paths:     []string{"test/input/missing"},
+			wantCount: 0,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ReadRecords(tt.paths...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadRecords() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if len(got) != tt.wantCount {
+				t.Errorf("ReadRecords() count = %v, want %v", len(got), tt.wantCount)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package conditions
 
 import (
 	"encoding/json"
+	"sort"
 )
 
 // Condition represent a condition statement.
@@ -104,3 +105,46 @@ func (condition *Condition) String() string {
 
 	return string(jsonData)
 }
+
+// Clause represents a single key and value of a condition operator, such as the key
+// aws:RequestedRegion of the StringEquals operator.
+type Clause struct {
+	Operator string `json:"operator" yaml:"operator"`
+	Key      string `json:"key" yaml:"key"`
+	Value    string `json:"value" yaml:"value"`
+}
+
+// Clauses returns the clauses of a condition, ordered by operator and then by key.
+func (condition *Condition) Clauses() []Clause {
+	clauses := []Clause{}
+
+	if condition == nil {
+		return clauses
+	}
+
+	jsonData, err := json.Marshal(condition)
+	if err != nil {
+		return clauses
+	}
+
+	operators := map[string]Operator{}
+	if err := json.Unmarshal(jsonData, &operators); err != nil {
+		return clauses
+	}
+
+	for operator, keys := range operators {
+		for key, value := range keys {
+			clauses = append(clauses, Clause{Operator: operator, Key: key, Value: value})
+		}
+	}
+
+	sort.Slice(clauses, func(i, j int) bool {
+		if clauses[i].Operator != clauses[j].Operator {
+			return clauses[i].Operator < clauses[j].Operator
+		}
+
+		return clauses[i].Key < clauses[j].Key
+	})
+
+	return clauses
+}
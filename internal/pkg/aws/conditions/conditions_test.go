@@ -0,0 +1,45 @@
+package conditions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCondition_Clauses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition *Condition
+		want      []Clause
+	}{
+		{
+			name:      "ensure nil condition has no clauses",
+			condition: nil,
+			want:      []Clause{},
+		},
+		{
+			name: "ensure clauses are ordered by operator and key",
+			condition: &Condition{
+				StringEquals: Operator{"aws:RequestedRegion": "us-east-1", "aws:PrincipalTag/team": "platform"},
+				Bool:         Operator{"aws:SecureTransport": "true"},
+			},
+			want: []Clause{
+				{Operator: BoolOperator, Key: "aws:SecureTransport", Value: "true"},
+				{Operator: StringEqualsOperator, Key: "aws:PrincipalTag/team", Value: "platform"},
+				{Operator: StringEqualsOperator, Key: "aws:RequestedRegion", Value: "us-east-1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.condition.Clauses(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Condition.Clauses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
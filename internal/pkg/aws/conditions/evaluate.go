@@ -0,0 +1,259 @@
+package conditions
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scottd018/policy-gen/internal/pkg/wildcard"
+)
+
+// Context represents the condition keys of a request, such as aws:RequestedRegion, and their
+// values.  Condition keys are case-insensitive.
+type Context map[string][]string
+
+// policyVariable matches a policy variable, such as ${aws:username}, within a value.
+var policyVariable = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// special policy variables which represent the characters that otherwise have a special meaning.
+var specialVariables = map[string]string{
+	"*": "*",
+	"?": "?",
+	"$": "$",
+}
+
+// Values returns the values of a condition key and whether the key is present.
+func (context Context) Values(key string) ([]string, bool) {
+	for contextKey, values := range context {
+		if strings.EqualFold(contextKey, key) {
+			return values, true
+		}
+	}
+
+	return nil, false
+}
+
+// Substitute replaces the policy variables within a value, such as ${aws:username}, with the
+// first value of the matching condition key.  It returns false if a variable has no value, in
+// which case the value may not match anything.
+func (context Context) Substitute(value string) (string, bool) {
+	resolved := true
+
+	substituted := policyVariable.ReplaceAllStringFunc(value, func(variable string) string {
+		key := variable[2 : len(variable)-1]
+
+		if special, found := specialVariables[key]; found {
+			return special
+		}
+
+		values, found := context.Values(key)
+		if !found || len(values) == 0 {
+			resolved = false
+
+			return variable
+		}
+
+		return values[0]
+	})
+
+	return substituted, resolved
+}
+
+// negatedOperators are the operators which match when a condition key is not present in the
+// request.
+var negatedOperators = map[string]bool{
+	StringNotEqualsOperator:           true,
+	StringNotEqualsIgnoreCaseOperator: true,
+	StringNotLikeOperator:             true,
+	NumericNotEqualsOperator:          true,
+	DateNotEqualsOperator:             true,
+	NotIpAddressOperator:              true,
+	ArnNotEqualsOperator:              true,
+	ArnNotLikeOperator:                true,
+}
+
+// comparisons maps each operator to the function which compares a value from a request with a
+// value from a condition.  Negated operators are represented by their positive comparison.
+var comparisons = map[string]func(requestValue, conditionValue string) bool{
+	StringEqualsOperator:              func(r, c string) bool { return r == c },
+	StringNotEqualsOperator:           func(r, c string) bool { return r == c },
+	StringEqualsIgnoreCaseOperator:    strings.EqualFold,
+	StringNotEqualsIgnoreCaseOperator: strings.EqualFold,
+	StringLikeOperator:                func(r, c string) bool { return wildcard.Match(c, r) },
+	StringNotLikeOperator:             func(r, c string) bool { return wildcard.Match(c, r) },
+
+	NumericEqualsOperator:            compareNumbers(func(r, c float64) bool { return r == c }),
+	NumericNotEqualsOperator:         compareNumbers(func(r, c float64) bool { return r == c }),
+	NumericLessThanOperator:          compareNumbers(func(r, c float64) bool { return r < c }),
+	NumericLessThanEqualsOperator:    compareNumbers(func(r, c float64) bool { return r <= c }),
+	NumericGreaterThanOperator:       compareNumbers(func(r, c float64) bool { return r > c }),
+	NumericGreaterThanEqualsOperator: compareNumbers(func(r, c float64) bool { return r >= c }),
+
+	DateEqualsOperator:            compareDates(func(r, c time.Time) bool { return r.Equal(c) }),
+	DateNotEqualsOperator:         compareDates(func(r, c time.Time) bool { return r.Equal(c) }),
+	DateLessThanOperator:          compareDates(func(r, c time.Time) bool { return r.Before(c) }),
+	DateLessThanEqualsOperator:    compareDates(func(r, c time.Time) bool { return !r.After(c) }),
+	DateGreaterThanOperator:       compareDates(func(r, c time.Time) bool { return r.After(c) }),
+	DateGreaterThanEqualsOperator: compareDates(func(r, c time.Time) bool { return !r.Before(c) }),
+
+	BoolOperator: strings.EqualFold,
+
+	BinaryEqualsOperator: compareBinary,
+
+	IpAddressOperator:    compareIPAddress,
+	NotIpAddressOperator: compareIPAddress,
+
+	ArnEqualsOperator:    func(r, c string) bool { return wildcard.Match(c, r) },
+	ArnNotEqualsOperator: func(r, c string) bool { return wildcard.Match(c, r) },
+	ArnLikeOperator:      func(r, c string) bool { return wildcard.Match(c, r) },
+	ArnNotLikeOperator:   func(r, c string) bool { return wildcard.Match(c, r) },
+}
+
+// Evaluate determines whether a condition is satisfied by the condition keys of a request.  Every
+// key of every operator must be satisfied.  A key is satisfied by a positive operator when any
+// value of the key in the request matches, and by a negated operator, such as StringNotEquals,
+// when no value matches, including when the key is not present in the request.  Policy
+// variables in condition values are substituted from the request.
+func (condition *Condition) Evaluate(context Context) bool {
+	if condition == nil {
+		return true
+	}
+
+	for operator, keys := range condition.operators() {
+		compare, found := comparisons[operator]
+		if !found {
+			return false
+		}
+
+		for key, conditionValue := range keys {
+			conditionValue, resolved := context.Substitute(conditionValue)
+			requestValues, present := context.Values(key)
+
+			matched := false
+
+			for _, requestValue := range requestValues {
+				if resolved && compare(requestValue, conditionValue) {
+					matched = true
+
+					break
+				}
+			}
+
+			if negatedOperators[operator] {
+				matched = !matched
+			} else if !present {
+				matched = false
+			}
+
+			if !matched {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// operators returns the keys and values of each operator of a condition.
+func (condition *Condition) operators() map[string]Operator {
+	operators := map[string]Operator{}
+
+	data, err := json.Marshal(condition)
+	if err != nil {
+		return operators
+	}
+
+	_ = json.Unmarshal(data, &operators)
+
+	return operators
+}
+
+// compareNumbers returns a comparison of values parsed as numbers.  Values which are not numbers
+// do not match.
+func compareNumbers(compare func(requestValue, conditionValue float64) bool) func(string, string) bool {
+	return func(requestValue, conditionValue string) bool {
+		r, err := strconv.ParseFloat(requestValue, 64)
+		if err != nil {
+			return false
+		}
+
+		c, err := strconv.ParseFloat(conditionValue, 64)
+		if err != nil {
+			return false
+		}
+
+		return compare(r, c)
+	}
+}
+
+// compareDates returns a comparison of values parsed as dates, either in ISO 8601 format or as
+// seconds since the epoch.  Values which are not dates do not match.
+func compareDates(compare func(requestValue, conditionValue time.Time) bool) func(string, string) bool {
+	return func(requestValue, conditionValue string) bool {
+		r, ok := ParseDate(requestValue)
+		if !ok {
+			return false
+		}
+
+		c, ok := ParseDate(conditionValue)
+		if !ok {
+			return false
+		}
+
+		return compare(r, c)
+	}
+}
+
+// parseDate parses a date in ISO 8601 format or as seconds since the epoch.
+func ParseDate(value string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00", time.DateOnly} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), true
+	}
+
+	return time.Time{}, false
+}
+
+// compareBinary compares base64 encoded values by their decoded bytes.
+func compareBinary(requestValue, conditionValue string) bool {
+	r, err := base64.StdEncoding.DecodeString(requestValue)
+	if err != nil {
+		return false
+	}
+
+	c, err := base64.StdEncoding.DecodeString(conditionValue)
+	if err != nil {
+		return false
+	}
+
+	return string(r) == string(c)
+}
+
+// compareIPAddress determines whether an ip address is within a cidr block, or equal to an ip
+// address without a prefix length.
+func compareIPAddress(requestValue, conditionValue string) bool {
+	ip := net.ParseIP(requestValue)
+	if ip == nil {
+		return false
+	}
+
+	if !strings.Contains(conditionValue, "/") {
+		return ip.Equal(net.ParseIP(conditionValue))
+	}
+
+	_, network, err := net.ParseCIDR(conditionValue)
+	if err != nil {
+		return false
+	}
+
+	return network.Contains(ip)
+}
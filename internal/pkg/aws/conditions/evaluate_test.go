@@ -0,0 +1,137 @@
+package conditions
+
+import "testing"
+
+func TestCondition_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition *Condition
+		context   Context
+		want      bool
+	}{
+		{
+			name:      "ensure nil condition is satisfied",
+			condition: nil,
+			context:   Context{},
+			want:      true,
+		},
+		{
+			name:      "ensure string equals with matching value is satisfied",
+			condition: NewCondition("aws:ResourceTag/managed", "true", StringEqualsOperator),
+			context:   Context{"aws:resourcetag/managed": {"true"}},
+			want:      true,
+		},
+		{
+			name:      "ensure string equals with missing key is not satisfied",
+			condition: NewCondition("aws:ResourceTag/managed", "true", StringEqualsOperator),
+			context:   Context{},
+			want:      false,
+		},
+		{
+			name:      "ensure string not equals with missing key is satisfied",
+			condition: NewCondition("aws:ResourceTag/managed", "true", StringNotEqualsOperator),
+			context:   Context{},
+			want:      true,
+		},
+		{
+			name:      "ensure string not equals with any matching value is not satisfied",
+			condition: NewCondition("aws:TagKeys", "owner", StringNotEqualsOperator),
+			context:   Context{"aws:TagKeys": {"team", "owner"}},
+			want:      false,
+		},
+		{
+			name:      "ensure string equals ignore case is satisfied",
+			condition: NewCondition("aws:PrincipalTag/team", "Platform", StringEqualsIgnoreCaseOperator),
+			context:   Context{"aws:PrincipalTag/team": {"platform"}},
+			want:      true,
+		},
+		{
+			name:      "ensure string like with wildcard is satisfied",
+			condition: NewCondition("s3:prefix", "home/*", StringLikeOperator),
+			context:   Context{"s3:prefix": {"home/user/"}},
+			want:      true,
+		},
+		{
+			name:      "ensure string equals with policy variable is satisfied",
+			condition: NewCondition("aws:ResourceTag/owner", "${aws:username}", StringEqualsOperator),
+			context:   Context{"aws:ResourceTag/owner": {"alice"}, "aws:username": {"alice"}},
+			want:      true,
+		},
+		{
+			name:      "ensure numeric less than is satisfied",
+			condition: NewCondition("s3:max-keys", "10", NumericLessThanOperator),
+			context:   Context{"s3:max-keys": {"5"}},
+			want:      true,
+		},
+		{
+			name:      "ensure numeric comparison with an invalid number is not satisfied",
+			condition: NewCondition("s3:max-keys", "10", NumericLessThanOperator),
+			context:   Context{"s3:max-keys": {"five"}},
+			want:      false,
+		},
+		{
+			name:      "ensure date less than is satisfied",
+			condition: NewCondition("aws:CurrentTime", "2025-01-01T00:00:00Z", DateLessThanOperator),
+			context:   Context{"aws:CurrentTime": {"2024-06-01T00:00:00Z"}},
+			want:      true,
+		},
+		{
+			name:      "ensure date greater than with epoch seconds is not satisfied",
+			condition: NewCondition("aws:EpochTime", "2025-01-01T00:00:00Z", DateGreaterThanOperator),
+			context:   Context{"aws:EpochTime": {"1717200000"}},
+			want:      false,
+		},
+		{
+			name:      "ensure bool is satisfied",
+			condition: NewCondition("aws:SecureTransport", "true", BoolOperator),
+			context:   Context{"aws:SecureTransport": {"True"}},
+			want:      true,
+		},
+		{
+			name:      "ensure binary equals is satisfied",
+			condition: NewCondition("key", "QmluYXJ5", BinaryEqualsOperator),
+			context:   Context{"key": {"QmluYXJ5"}},
+			want:      true,
+		},
+		{
+			name:      "ensure ip address within cidr is satisfied",
+			condition: NewCondition("aws:SourceIp", "10.0.0.0/8", IpAddressOperator),
+			context:   Context{"aws:SourceIp": {"10.1.2.3"}},
+			want:      true,
+		},
+		{
+			name:      "ensure not ip address within cidr is not satisfied",
+			condition: NewCondition("aws:SourceIp", "10.0.0.0/8", NotIpAddressOperator),
+			context:   Context{"aws:SourceIp": {"10.1.2.3"}},
+			want:      false,
+		},
+		{
+			name:      "ensure arn like is satisfied",
+			condition: NewCondition("aws:SourceArn", "arn:aws:sns:*:123456789012:*", ArnLikeOperator),
+			context:   Context{"aws:SourceArn": {"arn:aws:sns:us-east-1:123456789012:topic"}},
+			want:      true,
+		},
+		{
+			name: "ensure every key of every operator must be satisfied",
+			condition: &Condition{
+				StringEquals: Operator{"aws:RequestedRegion": "us-east-1", "aws:ResourceTag/managed": "true"},
+				Bool:         Operator{"aws:SecureTransport": "true"},
+			},
+			context: Context{"aws:RequestedRegion": {"us-east-1"}, "aws:ResourceTag/managed": {"true"}},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.condition.Evaluate(tt.context); got != tt.want {
+				t.Errorf("Condition.Evaluate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// clauseSeparator separates the clauses of a condition expression.  Every clause of a
+	// condition must be satisfied, so they are joined as a conjunction.
+	clauseSeparator = " and "
+)
+
+// clauseFormat returns the format of a clause of an operator, which is given the key and value
+// of the clause.  String-like values are quoted so that whitespace and wildcards are visible,
+// while numbers, dates, booleans and addresses are not.  An empty format is returned for an
+// unknown operator.
+func clauseFormat(operator string) string {
+	return map[string]string{
+		// string condition operators
+		StringEqualsOperator:              "%s == %q",
+		StringNotEqualsOperator:           "%s != %q",
+		StringEqualsIgnoreCaseOperator:    "%s == %q (ignoring case)",
+		StringNotEqualsIgnoreCaseOperator: "%s != %q (ignoring case)",
+		StringLikeOperator:                "%s like %q",
+		StringNotLikeOperator:             "%s not like %q",
+
+		// numeric condition operators
+		NumericEqualsOperator:            "%s == %s",
+		NumericNotEqualsOperator:         "%s != %s",
+		NumericLessThanOperator:          "%s < %s",
+		NumericLessThanEqualsOperator:    "%s <= %s",
+		NumericGreaterThanOperator:       "%s > %s",
+		NumericGreaterThanEqualsOperator: "%s >= %s",
+
+		// date condition operators
+		DateEqualsOperator:            "%s is %s",
+		DateNotEqualsOperator:         "%s is not %s",
+		DateLessThanOperator:          "%s before %s",
+		DateLessThanEqualsOperator:    "%s at or before %s",
+		DateGreaterThanOperator:       "%s after %s",
+		DateGreaterThanEqualsOperator: "%s at or after %s",
+
+		// boolean condition operators
+		BoolOperator: "%s is %s",
+
+		// binary condition operators
+		BinaryEqualsOperator: "%s == binary %q",
+
+		// ip condition operators
+		IpAddressOperator:    "%s in %s",
+		NotIpAddressOperator: "%s not in %s",
+
+		// arn condition operators
+		ArnEqualsOperator:    "%s == %q",
+		ArnNotEqualsOperator: "%s != %q",
+		ArnLikeOperator:      "%s like %q",
+		ArnNotLikeOperator:   "%s not like %q",
+	}[operator]
+}
+
+// Expression returns a compact, human-readable expression of a clause, such as
+// aws:SourceIp in 10.0.0.0/8.  Clauses of an unknown operator are written as the operator
+// applied to the key and value.
+func (clause Clause) Expression() string {
+	format := clauseFormat(clause.Operator)
+	if format == "" {
+		return fmt.Sprintf("%s(%s, %q)", clause.Operator, clause.Key, clause.Value)
+	}
+
+	return fmt.Sprintf(format, clause.Key, clause.Value)
+}
+
+// Expression returns a compact, human-readable expression of a condition, such as
+// aws:RequestTag/managed == "true", with each clause joined by "and".  It returns an empty
+// string for a nil condition.
+func (condition *Condition) Expression() string {
+	clauses := condition.Clauses()
+
+	expressions := make([]string, len(clauses))
+	for i := range clauses {
+		expressions[i] = clauses[i].Expression()
+	}
+
+	return strings.Join(expressions, clauseSeparator)
+}
@@ -0,0 +1,99 @@
+package conditions
+
+import (
+	"testing"
+)
+
+func TestClause_Expression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		operator string
+		key      string
+		value    string
+		want     string
+	}{
+		{StringEqualsOperator, "aws:RequestTag/managed", "true", `aws:RequestTag/managed == "true"`},
+		{StringNotEqualsOperator, "aws:RequestTag/managed", "true", `aws:RequestTag/managed != "true"`},
+		{StringEqualsIgnoreCaseOperator, "aws:PrincipalTag/team", "Platform", `aws:PrincipalTag/team == "Platform" (ignoring case)`},
+		{StringNotEqualsIgnoreCaseOperator, "aws:PrincipalTag/team", "Platform", `aws:PrincipalTag/team != "Platform" (ignoring case)`},
+		{StringLikeOperator, "s3:prefix", "home/*", `s3:prefix like "home/*"`},
+		{StringNotLikeOperator, "s3:prefix", "home/*", `s3:prefix not like "home/*"`},
+		{NumericEqualsOperator, "s3:max-keys", "10", "s3:max-keys == 10"},
+		{NumericNotEqualsOperator, "s3:max-keys", "10", "s3:max-keys != 10"},
+		{NumericLessThanOperator, "s3:max-keys", "10", "s3:max-keys < 10"},
+		{NumericLessThanEqualsOperator, "s3:max-keys", "10", "s3:max-keys <= 10"},
+		{NumericGreaterThanOperator, "s3:max-keys", "10", "s3:max-keys > 10"},
+		{NumericGreaterThanEqualsOperator, "s3:max-keys", "10", "s3:max-keys >= 10"},
+		{DateEqualsOperator, "aws:CurrentTime", "2030-01-01T00:00:00Z", "aws:CurrentTime is 2030-01-01T00:00:00Z"},
+		{DateNotEqualsOperator, "aws:CurrentTime", "2030-01-01T00:00:00Z", "aws:CurrentTime is not 2030-01-01T00:00:00Z"},
+		{DateLessThanOperator, "aws:CurrentTime", "2030-01-01T00:00:00Z", "aws:CurrentTime before 2030-01-01T00:00:00Z"},
+		{DateLessThanEqualsOperator, "aws:CurrentTime", "2030-01-01T00:00:00Z", "aws:CurrentTime at or before 2030-01-01T00:00:00Z"},
+		{DateGreaterThanOperator, "aws:CurrentTime", "2030-01-01T00:00:00Z", "aws:CurrentTime after 2030-01-01T00:00:00Z"},
+		{DateGreaterThanEqualsOperator, "aws:CurrentTime", "2030-01-01T00:00:00Z", "aws:CurrentTime at or after 2030-01-01T00:00:00Z"},
+		{BoolOperator, "aws:SecureTransport", "true", "aws:SecureTransport is true"},
+		{BinaryEqualsOperator, "key", "QmluYXJ5VmFsdWU=", `key == binary "QmluYXJ5VmFsdWU="`},
+		{IpAddressOperator, "aws:SourceIp", "10.0.0.0/8", "aws:SourceIp in 10.0.0.0/8"},
+		{NotIpAddressOperator, "aws:SourceIp", "10.0.0.0/8", "aws:SourceIp not in 10.0.0.0/8"},
+		{ArnEqualsOperator, "aws:SourceArn", "arn:aws:sns:*:*:topic", `aws:SourceArn == "arn:aws:sns:*:*:topic"`},
+		{ArnNotEqualsOperator, "aws:SourceArn", "arn:aws:sns:*:*:topic", `aws:SourceArn != "arn:aws:sns:*:*:topic"`},
+		{ArnLikeOperator, "aws:SourceArn", "arn:aws:sns:*:*:topic", `aws:SourceArn like "arn:aws:sns:*:*:topic"`},
+		{ArnNotLikeOperator, "aws:SourceArn", "arn:aws:sns:*:*:topic", `aws:SourceArn not like "arn:aws:sns:*:*:topic"`},
+		{"Unknown", "key", "value", `Unknown(key, "value")`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.operator, func(t *testing.T) {
+			t.Parallel()
+
+			// every known operator must also produce a condition with the clause
+			if tt.operator != "Unknown" {
+				clauses := NewCondition(tt.key, tt.value, tt.operator).Clauses()
+				if len(clauses) != 1 || clauses[0].Operator != tt.operator {
+					t.Fatalf("NewCondition() clauses = %v, want a single clause of operator [%s]", clauses, tt.operator)
+				}
+			}
+
+			clause := Clause{Operator: tt.operator, Key: tt.key, Value: tt.value}
+			if got := clause.Expression(); got != tt.want {
+				t.Errorf("Clause.Expression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCondition_Expression(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition *Condition
+		want      string
+	}{
+		{
+			name:      "ensure nil condition is empty",
+			condition: nil,
+			want:      "",
+		},
+		{
+			name: "ensure clauses are joined in order",
+			condition: &Condition{
+				StringEquals: Operator{"aws:RequestTag/managed": "true"},
+				IpAddress:    Operator{"aws:SourceIp": "10.0.0.0/8"},
+			},
+			want: `aws:SourceIp in 10.0.0.0/8 and aws:RequestTag/managed == "true"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.condition.Expression(); got != tt.want {
+				t.Errorf("Condition.Expression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
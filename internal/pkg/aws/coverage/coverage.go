@@ -0,0 +1,156 @@
+package coverage
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+var (
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+// Scope represents the scope in which a marker is considered to cover a call.
+type Scope string
+
+const (
+	ScopeFunction Scope = "function"
+	ScopePackage  Scope = "package"
+	ScopeModule   Scope = "module"
+)
+
+// NewScope returns a scope from its string representation.
+func NewScope(scope string) (Scope, error) {
+	switch Scope(scope) {
+	case ScopeFunction, ScopePackage, ScopeModule:
+		return Scope(scope), nil
+	default:
+		return "", fmt.Errorf("%w [%s] - must be one of [%s, %s, %s]", ErrInvalidScope, scope, ScopeFunction, ScopePackage, ScopeModule)
+	}
+}
+
+// Contains determines whether a marker at a given source location is in scope of a call at a
+// given source location.
+func (scope Scope) Contains(marker, call *policy.Source) bool {
+	if marker == nil || call == nil {
+		return false
+	}
+
+	switch scope {
+	case ScopeModule:
+		return true
+	case ScopePackage:
+		if marker.Package != "" && call.Package != "" {
+			return marker.Package == call.Package
+		}
+
+		return filepath.Dir(marker.File) == filepath.Dir(call.File)
+	default:
+		return marker.Symbol() != "" && marker.Symbol() == call.Symbol()
+	}
+}
+
+// UncoveredCall represents a call which requires actions that no marker in scope allows.
+type UncoveredCall struct {
+	*Call
+
+	MissingActions []string `json:"missingActions"`
+}
+
+// UnmatchedMarker represents a marker which allows an action that no call in scope requires.
+type UnmatchedMarker struct {
+	Name     string            `json:"name"`
+	Action   string            `json:"action"`
+	Resource string            `json:"resource"`
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Source   *policy.Source    `json:"source"`
+}
+
+// Report represents the result of comparing the calls made to AWS SDK operations with the
+// markers which allow them.
+type Report struct {
+	Scope     Scope              `json:"scope"`
+	Calls     int                `json:"calls"`
+	Markers   int                `json:"markers"`
+	Uncovered []*UncoveredCall   `json:"uncoveredCalls"`
+	Unmatched []*UnmatchedMarker `json:"unmatchedMarkers"`
+}
+
+// NewReport compares a set of calls with a set of markers.  A call is covered when every action
+// it requires is allowed by a marker in scope.  A marker is matched when its action matches an
+// action required by a call in scope.  Only markers which allow actions are considered.
+func NewReport(calls []*Call, policyMarkers []policy.Marker, scope Scope) (*Report, error) {
+	allowMarkers := []*aws.Marker{}
+
+	for i := range policyMarkers {
+		marker, ok := policyMarkers[i].(*aws.Marker)
+		if !ok {
+			return nil, aws.ErrMarkerConvert
+		}
+
+		if marker.EffectColumn() == aws.ValidEffectAllow {
+			allowMarkers = append(allowMarkers, marker)
+		}
+	}
+
+	report := &Report{
+		Scope:     scope,
+		Calls:     len(calls),
+		Markers:   len(allowMarkers),
+		Uncovered: []*UncoveredCall{},
+		Unmatched: []*UnmatchedMarker{},
+	}
+
+	matched := make([]bool, len(allowMarkers))
+
+	for _, call := range calls {
+		missing := []string{}
+
+		for _, action := range call.Actions {
+			covered := false
+
+			for i, marker := range allowMarkers {
+				if !scope.Contains(marker.GetSource(), call.Source) || !aws.MatchAction(marker.PermissionColumn(), action) {
+					continue
+				}
+
+				covered = true
+				matched[i] = true
+			}
+
+			if !covered {
+				missing = append(missing, action)
+			}
+		}
+
+		if len(missing) > 0 {
+			report.Uncovered = append(report.Uncovered, &UncoveredCall{Call: call, MissingActions: missing})
+		}
+	}
+
+	for i, marker := range allowMarkers {
+		if matched[i] {
+			continue
+		}
+
+		report.Unmatched = append(report.Unmatched, &UnmatchedMarker{
+			Name:     marker.GetName(),
+			Action:   marker.PermissionColumn(),
+			Resource: marker.ResourceColumn(),
+			Reason:   marker.ReasonColumn(),
+			Metadata: marker.GetMetadata(),
+			Source:   marker.GetSource(),
+		})
+	}
+
+	return report, nil
+}
+
+// HasGaps returns whether or not the report contains calls which are not covered by a marker.
+func (report *Report) HasGaps() bool {
+	return len(report.Uncovered) > 0
+}
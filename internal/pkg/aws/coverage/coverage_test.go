@@ -0,0 +1,162 @@
+package coverage
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func thisFilePathFor(file string) string {
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		return file
+	}
+
+	return fmt.Sprintf("%s/%s", filepath.Dir(thisFile), file)
+}
+
+func testMarker(action string, source *policy.Source) policy.Marker {
+	marker := &aws.Marker{
+		Name:   pointers.String("test"),
+		Action: pointers.String(action),
+	}
+
+	marker.SetSource(source)
+
+	return marker
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		file string
+		want []string
+	}{
+		{
+			name: "ensure calls are located at their method call or input reference",
+			file: "test/input/module/vpc.go",
+			want: []string{
+				"ec2.CreateVpc [ec2:CreateVpc] 18",
+				"ec2.DeleteVpc [ec2:DeleteVpc] 25",
+				"s3.CopyObject [s3:GetObject s3:PutObject] 35",
+			},
+		},
+		{
+			name: "ensure calls on the same line are ordered by name",
+			file: "test/input/module/tags.go",
+			want: []string{
+				"ec2.CreateTags [ec2:CreateTags] 11",
+				"ec2.DeleteTags [ec2:DeleteTags] 11",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// scan repeatedly, as calls are collected in no particular order
+			for i := 0; i < 10; i++ {
+				calls, err := Scan([]string{thisFilePathFor(tt.file)}, nil)
+				if err != nil {
+					t.Fatalf("Scan() error = %v", err)
+				}
+
+				got := []string{}
+				for _, call := range calls {
+					got = append(got, fmt.Sprintf("%s %v %d", call, call.Actions, call.Source.Line))
+				}
+
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("Scan() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+
+	createVpc := &Call{
+		Service:   "ec2",
+		Operation: "CreateVpc",
+		Actions:   []string{"ec2:CreateVpc"},
+		Source:    &policy.Source{File: "vpc.go", Line: 10, Package: "vpc", Function: "Create"},
+	}
+
+	tests := []struct {
+		name          string
+		markers       []policy.Marker
+		scope         Scope
+		wantUncovered int
+		wantUnmatched int
+	}{
+		{
+			name: "ensure marker in the same function covers a call",
+			markers: []policy.Marker{
+				testMarker("ec2:Create*", &policy.Source{File: "vpc.go", Line: 8, Package: "vpc", Function: "Create"}),
+			},
+			scope:         ScopeFunction,
+			wantUncovered: 0,
+			wantUnmatched: 0,
+		},
+		{
+			name: "ensure marker in another function does not cover a call in function scope",
+			markers: []policy.Marker{
+				testMarker("ec2:CreateVpc", &policy.Source{File: "vpc.go", Line: 20, Package: "vpc", Function: "Delete"}),
+			},
+			scope:         ScopeFunction,
+			wantUncovered: 1,
+			wantUnmatched: 1,
+		},
+		{
+			name: "ensure marker in another function covers a call in package scope",
+			markers: []policy.Marker{
+				testMarker("ec2:CreateVpc", &policy.Source{File: "vpc.go", Line: 20, Package: "vpc", Function: "Delete"}),
+			},
+			scope:         ScopePackage,
+			wantUncovered: 0,
+			wantUnmatched: 0,
+		},
+		{
+			name: "ensure marker in a non-go file covers a call in module scope",
+			markers: []policy.Marker{
+				testMarker("ec2:CreateVpc", &policy.Source{File: "README.md", Line: 1}),
+			},
+			scope:         ScopeModule,
+			wantUncovered: 0,
+			wantUnmatched: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := NewReport([]*Call{createVpc}, tt.markers, tt.scope)
+			if err != nil {
+				t.Fatalf("NewReport() error = %v", err)
+			}
+
+			if len(report.Uncovered) != tt.wantUncovered {
+				t.Errorf("NewReport() uncovered = %v, want %v", len(report.Uncovered), tt.wantUncovered)
+			}
+
+			if len(report.Unmatched) != tt.wantUnmatched {
+				t.Errorf("NewReport() unmatched = %v, want %v", len(report.Unmatched), tt.wantUnmatched)
+			}
+		})
+	}
+}
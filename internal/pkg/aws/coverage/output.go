@@ -0,0 +1,118 @@
+package coverage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/scottd018/policy-gen/internal/pkg/sarif"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+
+	RuleUncoveredCall   = "aws-coverage-uncovered-call"
+	RuleUnmatchedMarker = "aws-coverage-unmatched-marker"
+
+	findingUncovered = "uncovered call"
+	findingUnmatched = "unmatched marker"
+)
+
+// Render renders a report in the given format.
+func (report *Report) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatTable:
+		return report.Table(), nil
+	case FormatJSON:
+		return report.JSON()
+	case FormatSARIF:
+		return report.SARIF()
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s, %s]", ErrInvalidFormat, format, FormatTable, FormatJSON, FormatSARIF)
+	}
+}
+
+// Table renders a report as a human-readable table.
+func (report *Report) Table() []byte {
+	tableBytes := &bytes.Buffer{}
+
+	table := tablewriter.NewWriter(tableBytes)
+	table.SetHeader([]string{"finding", "call", "action", "source"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+
+	for _, call := range report.Uncovered {
+		table.Append([]string{findingUncovered, call.String(), strings.Join(call.MissingActions, ", "), call.Source.String()})
+	}
+
+	for _, marker := range report.Unmatched {
+		table.Append([]string{findingUnmatched, "", marker.Action, marker.Source.String()})
+	}
+
+	table.Render()
+
+	fmt.Fprintf(
+		tableBytes,
+		"\nscope: %s, calls: %d, markers: %d, uncovered calls: %d, unmatched markers: %d\n",
+		report.Scope,
+		report.Calls,
+		report.Markers,
+		len(report.Uncovered),
+		len(report.Unmatched),
+	)
+
+	return tableBytes.Bytes()
+}
+
+// JSON renders a report as JSON.
+func (report *Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal coverage report - %w", err)
+	}
+
+	return data, nil
+}
+
+// SARIF renders a report as a SARIF 2.1.0 log.
+func (report *Report) SARIF() ([]byte, error) {
+	results := make([]sarif.Result, 0, len(report.Uncovered)+len(report.Unmatched))
+
+	for _, call := range report.Uncovered {
+		results = append(results, sarif.NewResult(
+			RuleUncoveredCall,
+			sarif.LevelError,
+			fmt.Sprintf("call to [%s] requires actions with no marker in scope: [%s]", call, strings.Join(call.MissingActions, ", ")),
+			call.Source.File,
+			call.Source.Line,
+		))
+	}
+
+	for _, marker := range report.Unmatched {
+		results = append(results, sarif.NewResult(
+			RuleUnmatchedMarker,
+			sarif.LevelWarning,
+			fmt.Sprintf("marker allows action [%s] with no matching sdk call in scope", marker.Action),
+			marker.Source.File,
+			marker.Source.Line,
+		))
+	}
+
+	rules := []sarif.Rule{
+		sarif.NewRule(RuleUncoveredCall, "AWS SDK call without a marker allowing its IAM actions", sarif.LevelError),
+		sarif.NewRule(RuleUnmatchedMarker, "Marker allowing an IAM action without a matching AWS SDK call", sarif.LevelWarning),
+	}
+
+	return sarif.NewLog(rules, results).Marshal()
+}
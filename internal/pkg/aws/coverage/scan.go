@@ -0,0 +1,191 @@
+package coverage
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws/actions"
+	"github.com/scottd018/policy-gen/internal/pkg/golang"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	sdkServicePrefix = "github.com/aws/aws-sdk-go-v2/service/"
+	sdkInputSuffix   = "Input"
+
+	extensionGo = ".go"
+	suffixTest  = "_test.go"
+)
+
+// Call represents a call to an AWS SDK for Go v2 client operation.
+type Call struct {
+	Service   string         `json:"service"`
+	Operation string         `json:"operation"`
+	Actions   []string       `json:"actions"`
+	Source    *policy.Source `json:"source"`
+}
+
+// String returns the string representation of a call.
+func (call *Call) String() string {
+	return fmt.Sprintf("%s.%s", call.Service, call.Operation)
+}
+
+// Scan statically scans a set of Go source files for calls to AWS SDK for Go v2 client
+// operations.  Calls are detected by references to the input type of an operation, such as
+// ec2.CreateVpcInput, which every operation, paginator and waiter requires.  The location of
+// the call is the method call of the same name as the operation when it is found within the
+// same function, otherwise the location of the input reference.  If an index is given, each
+// call is annotated with the function which encloses it.
+func Scan(paths []string, index *golang.Index) ([]*Call, error) {
+	fileSet := token.NewFileSet()
+	calls := []*Call{}
+
+	for _, filePath := range paths {
+		if !strings.HasSuffix(filePath, extensionGo) || strings.HasSuffix(filePath, suffixTest) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fileSet, filePath, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse go file [%s] - %w", filePath, err)
+		}
+
+		services := sdkImports(file)
+		if len(services) == 0 {
+			continue
+		}
+
+		for _, declaration := range file.Decls {
+			function, ok := declaration.(*ast.FuncDecl)
+			if !ok || function.Body == nil {
+				continue
+			}
+
+			found, err := scanFunction(fileSet, filePath, function, services)
+			if err != nil {
+				return nil, err
+			}
+
+			calls = append(calls, found...)
+		}
+	}
+
+	// annotate each call with the function which encloses it
+	if index != nil {
+		for _, call := range calls {
+			if function := index.Enclosing(call.Source.File, call.Source.Line); function != nil {
+				call.Source.Package = function.Package
+				call.Source.Receiver = function.Receiver
+				call.Source.Function = function.Name
+			}
+		}
+	}
+
+	return calls, nil
+}
+
+// sdkImports returns a map of the import names to the service names of the AWS SDK for Go v2
+// service packages imported by a file.
+func sdkImports(file *ast.File) map[string]string {
+	services := map[string]string{}
+
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || !strings.HasPrefix(importPath, sdkServicePrefix) {
+			continue
+		}
+
+		service := strings.TrimPrefix(importPath, sdkServicePrefix)
+		if strings.Contains(service, "/") {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		services[name] = service
+	}
+
+	return services
+}
+
+// scanFunction scans a single function for calls to AWS SDK for Go v2 client operations.
+func scanFunction(fileSet *token.FileSet, filePath string, function *ast.FuncDecl, services map[string]string) ([]*Call, error) {
+	type operation struct {
+		service string
+		name    string
+	}
+
+	inputs := map[operation]token.Pos{}
+	methods := map[string]token.Pos{}
+
+	ast.Inspect(function.Body, func(node ast.Node) bool {
+		switch expression := node.(type) {
+		case *ast.CallExpr:
+			if selector, ok := expression.Fun.(*ast.SelectorExpr); ok {
+				if _, found := methods[selector.Sel.Name]; !found {
+					methods[selector.Sel.Name] = expression.Pos()
+				}
+			}
+		case *ast.SelectorExpr:
+			ident, ok := expression.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			service, found := services[ident.Name]
+			if !found || !strings.HasSuffix(expression.Sel.Name, sdkInputSuffix) || expression.Sel.Name == sdkInputSuffix {
+				return true
+			}
+
+			key := operation{service: service, name: strings.TrimSuffix(expression.Sel.Name, sdkInputSuffix)}
+			if _, found := inputs[key]; !found {
+				inputs[key] = expression.Pos()
+			}
+		}
+
+		return true
+	})
+
+	calls := make([]*Call, 0, len(inputs))
+
+	for key, position := range inputs {
+		if methodPosition, found := methods[key.name]; found {
+			position = methodPosition
+		}
+
+		requiredActions, err := actions.ForOperation(key.service, key.name)
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, &Call{
+			Service:   key.service,
+			Operation: key.name,
+			Actions:   requiredActions,
+			Source: &policy.Source{
+				File: filePath,
+				Line: fileSet.Position(position).Line,
+			},
+		})
+	}
+
+	// calls on the same line are ordered by name so that the order is consistent
+	sort.Slice(calls, func(i, j int) bool {
+		if calls[i].Source.Line != calls[j].Source.Line {
+			return calls[i].Source.Line < calls[j].Source.Line
+		}
+
+		return calls[i].String() < calls[j].String()
+	})
+
+	return calls, nil
+}
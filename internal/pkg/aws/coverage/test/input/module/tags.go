@@ -0,0 +1,14 @@
+package vpc
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// Retag replaces the tags of a VPC.
+func (v *vpc) Retag(ctx context.Context, client *ec2.Client) error {
+	inputs := []interface{}{&ec2.DeleteTagsInput{}, &ec2.CreateTagsInput{}}
+
+	return retag(ctx, client, inputs)
+}
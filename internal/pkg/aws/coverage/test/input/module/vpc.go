@@ -0,0 +1,38 @@
+package vpc
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type vpc struct{}
+
+// Create creates a VPC.
+//
+// +policy-gen:aws:iam:policy:name=installer,action=`ec2:CreateVpc`,reason=`create the vpc`
+func (v *vpc) Create(ctx context.Context, client *ec2.Client) error {
+	input := &ec2.CreateVpcInput{}
+
+	_, err := client.CreateVpc(ctx, input)
+
+	return err
+}
+
+// Delete deletes a VPC.
+func (v *vpc) Delete(ctx context.Context, client *ec2.Client) error {
+	_, err := client.DeleteVpc(ctx, &ec2.DeleteVpcInput{})
+
+	return err
+}
+
+// Upload copies an object.
+//
+// +policy-gen:aws:iam:policy:name=installer,action=`s3:PutObject`,reason=`copy the object`
+// +policy-gen:aws:iam:policy:name=installer,action=`s3:DeleteBucket`,reason=`not needed`
+func Upload(ctx context.Context, client *awss3.Client) error {
+	_, err := client.CopyObject(ctx, &awss3.CopyObjectInput{})
+
+	return err
+}
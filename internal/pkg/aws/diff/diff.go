@@ -0,0 +1,284 @@
+package diff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+// Kind represents the kind of a change to a policy.
+type Kind string
+
+const (
+	KindAddedAction      Kind = "added action"
+	KindRemovedAction    Kind = "removed action"
+	KindAddedResource    Kind = "added resource"
+	KindRemovedResource  Kind = "removed resource"
+	KindEffectChanged    Kind = "effect changed"
+	KindConditionChanged Kind = "condition changed"
+)
+
+// Status represents the status of a policy between two sets of markers.
+type Status string
+
+const (
+	StatusAdded   Status = "added"
+	StatusRemoved Status = "removed"
+	StatusChanged Status = "changed"
+)
+
+// Change represents a single change to the permissions of a policy.
+type Change struct {
+	Kind     Kind   `json:"kind"`
+	Action   string `json:"action"`
+	Resource string `json:"resource"`
+	Before   string `json:"before,omitempty"`
+	After    string `json:"after,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// PolicyChanges represents the changes to the permissions of a single policy.
+type PolicyChanges struct {
+	Name    string    `json:"name"`
+	Status  Status    `json:"status"`
+	Changes []*Change `json:"changes"`
+}
+
+// Report represents the changes to the permissions of a set of policies.
+type Report struct {
+	Policies []*PolicyChanges `json:"policies"`
+}
+
+// entry represents the permission that a marker grants for an action on a resource.  Conditions
+// are compared as they appear in the generated policy, but shown as readable expressions.
+type entry struct {
+	action     string
+	effect     string
+	condition  string
+	expression string
+	reason     string
+}
+
+// permissions represents the permissions of a policy keyed by action and resource, where
+// actions are normalized to lowercase as they are case-insensitive.
+type permissions map[key]*entry
+
+type key struct {
+	action   string
+	resource string
+}
+
+// NewReport compares the policies generated from an old and a new set of markers.  Policies are
+// compared by their permissions rather than their statements, so only changes to the actions,
+// resources, effects and conditions that they grant are reported, each with the reason of the
+// marker responsible for it.
+func NewReport(oldMarkers, newMarkers []policy.Marker) (*Report, error) {
+	oldPolicies, err := toPolicies(oldMarkers)
+	if err != nil {
+		return nil, err
+	}
+
+	newPolicies, err := toPolicies(newMarkers)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for name := range oldPolicies {
+		names[name] = true
+	}
+
+	for name := range newPolicies {
+		names[name] = true
+	}
+
+	report := &Report{Policies: []*PolicyChanges{}}
+
+	for _, name := range sortedKeys(names) {
+		changes := compare(oldPolicies[name], newPolicies[name])
+		if len(changes) == 0 {
+			continue
+		}
+
+		policyChanges := &PolicyChanges{Name: name, Status: StatusChanged, Changes: changes}
+
+		switch {
+		case oldPolicies[name] == nil:
+			policyChanges.Status = StatusAdded
+		case newPolicies[name] == nil:
+			policyChanges.Status = StatusRemoved
+		}
+
+		report.Policies = append(report.Policies, policyChanges)
+	}
+
+	return report, nil
+}
+
+// HasChanges returns whether any policy has changed.
+func (report *Report) HasChanges() bool {
+	return len(report.Policies) > 0
+}
+
+// toPolicies converts a set of markers into the permissions of each policy, keyed by name.
+func toPolicies(policyMarkers []policy.Marker) (map[string]permissions, error) {
+	policies := map[string]permissions{}
+
+	for i := range policyMarkers {
+		marker, ok := policyMarkers[i].(*aws.Marker)
+		if !ok {
+			return nil, aws.ErrMarkerConvert
+		}
+
+		if policies[marker.GetName()] == nil {
+			policies[marker.GetName()] = permissions{}
+		}
+
+		permissionKey := key{action: strings.ToLower(marker.PermissionColumn()), resource: marker.ResourceColumn()}
+
+		// multiple markers may grant the same action on the same resource, in which case their
+		// effects and conditions are combined
+		existing, found := policies[marker.GetName()][permissionKey]
+		if !found {
+			policies[marker.GetName()][permissionKey] = &entry{
+				action:     marker.PermissionColumn(),
+				effect:     marker.EffectColumn(),
+				condition:  marker.RawConditionColumn(),
+				expression: marker.ConditionColumn(),
+				reason:     marker.ReasonColumn(),
+			}
+
+			continue
+		}
+
+		existing.effect = combine(existing.effect, marker.EffectColumn())
+		existing.condition = combine(existing.condition, marker.RawConditionColumn())
+		existing.expression = combine(existing.expression, marker.ConditionColumn())
+		existing.reason = combine(existing.reason, marker.ReasonColumn())
+	}
+
+	return policies, nil
+}
+
+// compare compares the permissions of an old and a new policy.  Either may be nil if the policy
+// was added or removed.
+func compare(oldPermissions, newPermissions permissions) []*Change {
+	oldActions := actionsOf(oldPermissions)
+	newActions := actionsOf(newPermissions)
+
+	changes := []*Change{}
+
+	for _, permissionKey := range sortedPermissionKeys(oldPermissions, newPermissions) {
+		oldEntry, newEntry := oldPermissions[permissionKey], newPermissions[permissionKey]
+
+		switch {
+		case oldEntry == nil:
+			kind := KindAddedAction
+			if oldActions[permissionKey.action] {
+				kind = KindAddedResource
+			}
+
+			changes = append(changes, newEntry.change(kind, permissionKey.resource, "", newEntry.effect))
+		case newEntry == nil:
+			kind := KindRemovedAction
+			if newActions[permissionKey.action] {
+				kind = KindRemovedResource
+			}
+
+			changes = append(changes, oldEntry.change(kind, permissionKey.resource, oldEntry.effect, ""))
+		default:
+			if oldEntry.effect != newEntry.effect {
+				changes = append(changes, newEntry.change(KindEffectChanged, permissionKey.resource, oldEntry.effect, newEntry.effect))
+			}
+
+			if oldEntry.condition != newEntry.condition {
+				// conditions which differ may have the same expression, such as with the StringLike
+				// and ArnLike operators, in which case the raw conditions are shown instead
+				before, after := oldEntry.expression, newEntry.expression
+				if before == after {
+					before, after = oldEntry.condition, newEntry.condition
+				}
+
+				changes = append(changes, newEntry.change(KindConditionChanged, permissionKey.resource, before, after))
+			}
+		}
+	}
+
+	return changes
+}
+
+// change returns a change for an entry.
+func (permission *entry) change(kind Kind, resource, before, after string) *Change {
+	return &Change{
+		Kind:     kind,
+		Action:   permission.action,
+		Resource: resource,
+		Before:   before,
+		After:    after,
+		Reason:   permission.reason,
+	}
+}
+
+// actionsOf returns the set of actions in a set of permissions.
+func actionsOf(policyPermissions permissions) map[string]bool {
+	actions := map[string]bool{}
+
+	for permissionKey := range policyPermissions {
+		actions[permissionKey.action] = true
+	}
+
+	return actions
+}
+
+// sortedPermissionKeys returns the keys of a set of permissions, ordered by action and resource.
+func sortedPermissionKeys(permissionSets ...permissions) []key {
+	unique := map[key]bool{}
+
+	for _, permissionSet := range permissionSets {
+		for permissionKey := range permissionSet {
+			unique[permissionKey] = true
+		}
+	}
+
+	keys := make([]key, 0, len(unique))
+	for permissionKey := range unique {
+		keys = append(keys, permissionKey)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].action != keys[j].action {
+			return keys[i].action < keys[j].action
+		}
+
+		return keys[i].resource < keys[j].resource
+	})
+
+	return keys
+}
+
+// sortedKeys returns the keys of a set of strings in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for value := range set {
+		keys = append(keys, value)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// combine combines two values which should be the same, separating them if they differ.
+func combine(value, other string) string {
+	if value == other || other == "" {
+		return value
+	}
+
+	if value == "" {
+		return other
+	}
+
+	return value + "; " + other
+}
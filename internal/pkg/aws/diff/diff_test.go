@@ -0,0 +1,134 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+
+	newMarker := func(name, action, resource, effect, reason string) *aws.Marker {
+		return &aws.Marker{
+			Name:     pointers.String(name),
+			Action:   pointers.String(action),
+			Resource: pointers.String(resource),
+			Effect:   pointers.String(effect),
+			Reason:   pointers.String(reason),
+		}
+	}
+
+	conditional := newMarker("test", "ec2:DescribeVpcs", "*", "Allow", "list vpcs in region")
+	conditional.ConditionOperator = pointers.String("StringEquals")
+	conditional.ConditionKey = pointers.String("aws:RequestedRegion")
+	conditional.ConditionValue = pointers.String("us-east-1")
+
+	oldMarkers := []policy.Marker{
+		newMarker("test", "s3:GetObject", "arn:aws:s3:::a/*", "Allow", "read a"),
+		newMarker("test", "ec2:DescribeVpcs", "*", "Allow", "list vpcs"),
+		newMarker("test", "ec2:DeleteVpc", "*", "Allow", "delete vpcs"),
+		newMarker("test", "iam:PassRole", "*", "Allow", "pass roles"),
+		newMarker("removed", "iam:ListRoles", "*", "Allow", "list roles"),
+	}
+
+	newMarkers := []policy.Marker{
+		newMarker("test", "S3:GetObject", "arn:aws:s3:::a/*", "Allow", "read a"),
+		newMarker("test", "s3:GetObject", "arn:aws:s3:::b/*", "Allow", "read b"),
+		conditional,
+		newMarker("test", "ec2:DeleteVpc", "*", "Deny", "never delete vpcs"),
+		newMarker("test", "ec2:CreateVpc", "*", "Allow", "create vpcs"),
+		newMarker("added", "iam:ListRoles", "*", "Allow", "list roles"),
+	}
+
+	want := &Report{
+		Policies: []*PolicyChanges{
+			{
+				Name:   "added",
+				Status: StatusAdded,
+				Changes: []*Change{
+					{Kind: KindAddedAction, Action: "iam:ListRoles", Resource: "*", After: "Allow", Reason: "list roles"},
+				},
+			},
+			{
+				Name:   "removed",
+				Status: StatusRemoved,
+				Changes: []*Change{
+					{Kind: KindRemovedAction, Action: "iam:ListRoles", Resource: "*", Before: "Allow", Reason: "list roles"},
+				},
+			},
+			{
+				Name:   "test",
+				Status: StatusChanged,
+				Changes: []*Change{
+					{Kind: KindAddedAction, Action: "ec2:CreateVpc", Resource: "*", After: "Allow", Reason: "create vpcs"},
+					{Kind: KindEffectChanged, Action: "ec2:DeleteVpc", Resource: "*", Before: "Allow", After: "Deny", Reason: "never delete vpcs"},
+					{
+						Kind:     KindConditionChanged,
+						Action:   "ec2:DescribeVpcs",
+						Resource: "*",
+						After:    `aws:RequestedRegion == "us-east-1"`,
+						Reason:   "list vpcs in region",
+					},
+					{Kind: KindRemovedAction, Action: "iam:PassRole", Resource: "*", Before: "Allow", Reason: "pass roles"},
+					{Kind: KindAddedResource, Action: "s3:GetObject", Resource: "arn:aws:s3:::b/*", After: "Allow", Reason: "read b"},
+				},
+			},
+		},
+	}
+
+	got, err := NewReport(oldMarkers, newMarkers)
+	if err != nil {
+		t.Fatalf("NewReport() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		for _, policyChanges := range got.Policies {
+			for _, change := range policyChanges.Changes {
+				t.Logf("%s: %+v", policyChanges.Name, change)
+			}
+		}
+
+		t.Errorf("NewReport() did not return the expected changes")
+	}
+
+	if got, err := NewReport(oldMarkers, oldMarkers); err != nil || got.HasChanges() {
+		t.Errorf("NewReport() with identical markers has changes = %v, error = %v", got.HasChanges(), err)
+	}
+}
+
+func TestNewReport_RawCondition(t *testing.T) {
+	t.Parallel()
+
+	newMarker := func(operator string) *aws.Marker {
+		return &aws.Marker{
+			Name:              pointers.String("test"),
+			Action:            pointers.String("iam:PassRole"),
+			Resource:          pointers.String("*"),
+			ConditionOperator: pointers.String(operator),
+			ConditionKey:      pointers.String("iam:PassedToService"),
+			ConditionValue:    pointers.String("ec2.*"),
+		}
+	}
+
+	got, err := NewReport([]policy.Marker{newMarker("StringLike")}, []policy.Marker{newMarker("ArnLike")})
+	if err != nil {
+		t.Fatalf("NewReport() error = %v", err)
+	}
+
+	want := &Change{
+		Kind:     KindConditionChanged,
+		Action:   "iam:PassRole",
+		Resource: "*",
+		Before:   `{"StringLike":{"iam:PassedToService":"ec2.*"}}`,
+		After:    `{"ArnLike":{"iam:PassedToService":"ec2.*"}}`,
+	}
+
+	if len(got.Policies) != 1 || len(got.Policies[0].Changes) != 1 || !reflect.DeepEqual(got.Policies[0].Changes[0], want) {
+		t.Errorf("NewReport() did not report the change of operator, got %+v", got.Policies)
+	}
+}
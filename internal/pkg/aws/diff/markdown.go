@@ -0,0 +1,69 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	markdownHeader    = "## Policy Changes\n\n"
+	markdownNoChanges = "No permissions changed.\n"
+)
+
+// Markdown renders a report as Markdown, suitable for a pull request comment, with a table of
+// changes for each changed policy.
+func (report *Report) Markdown() []byte {
+	markdown := &bytes.Buffer{}
+	markdown.WriteString(markdownHeader)
+
+	if !report.HasChanges() {
+		markdown.WriteString(markdownNoChanges)
+
+		return markdown.Bytes()
+	}
+
+	for _, policyChanges := range report.Policies {
+		fmt.Fprintf(markdown, "### `%s` (%s)\n\n", policyChanges.Name, policyChanges.Status)
+
+		table := tablewriter.NewWriter(markdown)
+		table.SetHeader([]string{"change", "action", "resource", "before", "after", "reason"})
+		table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+		table.SetCenterSeparator("|")
+		table.SetAutoWrapText(false)
+		table.SetAutoFormatHeaders(false)
+
+		for _, change := range policyChanges.Changes {
+			table.Append([]string{
+				string(change.Kind),
+				code(change.Action),
+				code(change.Resource),
+				code(change.Before),
+				code(change.After),
+				escape(change.Reason),
+			})
+		}
+
+		table.Render()
+
+		markdown.WriteString("\n")
+	}
+
+	return markdown.Bytes()
+}
+
+// code formats a value as inline code, unless it is empty.
+func code(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return "`" + escape(value) + "`"
+}
+
+// escape escapes the characters of a value which would otherwise break a Markdown table.
+func escape(value string) string {
+	return strings.ReplaceAll(value, "|", `\|`)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/scottd018/policy-gen/internal/pkg/docs"
 	"github.com/scottd018/policy-gen/internal/pkg/files"
 )
 
@@ -78,3 +79,46 @@ func (document *PolicyDocument) ToFile(path string) (*files.File, error) {
 
 	return file, nil
 }
+
+// DocumentStatements returns the statements of a policy document for documentation.  It is used
+// to satisfy the docs.StatementDocument interface.
+func (document *PolicyDocument) DocumentStatements() []docs.Statement {
+	statements := make([]docs.Statement, len(document.Statements))
+
+	for i, statement := range document.Statements {
+		statements[i] = docs.Statement{
+			Sid:       statement.SID,
+			Effect:    statement.Effect,
+			Actions:   statement.Action,
+			Resources: statement.Resources,
+		}
+
+		if statement.Condition != nil {
+			statements[i].Condition = statement.Condition.Expression()
+			statements[i].RawCondition = statement.Condition.String()
+		}
+	}
+
+	return statements
+}
+
+// StatementFor returns the statement of a policy document which contains the permission of a
+// marker, or nil if no statement contains it.
+func (document *PolicyDocument) StatementFor(marker Marker) *Statement {
+	if document == nil {
+		return nil
+	}
+
+	for i := range document.Statements {
+		statement := &document.Statements[i]
+
+		if statement.HasAction(*marker.Action) &&
+			statement.HasResource(*marker.Resource) &&
+			statement.HasEffect(*marker.Effect) &&
+			statement.HasCondition(marker.Condition()) {
+			return statement
+		}
+	}
+
+	return nil
+}
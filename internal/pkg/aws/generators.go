@@ -15,6 +15,10 @@ var (
 
 type PolicyDocumentGenerator struct {
 	Directory *files.Directory
+
+	// ExpiryCondition determines whether markers which expire are generated with a condition
+	// which enforces their expiry.
+	ExpiryCondition bool
 }
 
 // ToPolicyMarkerMap generates a map of filenames with their given set of markers.
@@ -81,7 +85,41 @@ func (generator *PolicyDocumentGenerator) ToDocument(markers []policy.Marker) (p
 		}
 
 		awsMarkers[i] = *marker
+
+		if generator.ExpiryCondition {
+			awsMarkers[i].WithExpiryCondition()
+		}
 	}
 
 	return NewPolicyDocument(awsMarkers...), nil
 }
+
+// ToPolicyDocuments generates a policy document for each policy name in a given set of markers,
+// keyed by the policy name.
+func (generator *PolicyDocumentGenerator) ToPolicyDocuments(markers []policy.Marker) (map[string]*PolicyDocument, error) {
+	policyMarkers := map[string][]Marker{}
+
+	for i := range markers {
+		marker, ok := markers[i].(*Marker)
+		if !ok {
+			return nil, ErrMarkerConvert
+		}
+
+		// ensure default values for the marker
+		marker.WithDefault()
+
+		generated := *marker
+		if generator.ExpiryCondition {
+			generated.WithExpiryCondition()
+		}
+
+		policyMarkers[marker.GetName()] = append(policyMarkers[marker.GetName()], generated)
+	}
+
+	documents := make(map[string]*PolicyDocument, len(policyMarkers))
+	for name := range policyMarkers {
+		documents[name] = NewPolicyDocument(policyMarkers[name]...)
+	}
+
+	return documents, nil
+}
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"sort"
+	"strings"
+)
+
+// Grant represents a single action on a single resource, under an optional condition, that a
+// policy statement allows or denies.  Policy documents which produce the same set of grants are
+// semantically equal, regardless of how their statements are arranged.
+type Grant struct {
+	Effect    string `json:"effect"`
+	Action    string `json:"action"`
+	Resource  string `json:"resource"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// String returns the string representation of a grant.
+func (grant Grant) String() string {
+	value := grant.Effect + " " + grant.Action + " on " + grant.Resource
+	if grant.Condition != "" {
+		value += " when " + grant.Condition
+	}
+
+	return value
+}
+
+// Grants returns the unique grants of a policy document, ordered by effect, action, resource
+// and condition.  Actions are compared case-insensitively, so only the first of a set of actions
+// which differ only by case is returned.  Statements with NotAction or NotResource elements
+// cannot be represented as grants, so they must be checked for with HasNotElements.
+func (document *PolicyDocument) Grants() []Grant {
+	unique := map[Grant]Grant{}
+
+	for _, statement := range document.Statements {
+		condition := ""
+		if statement.Condition != nil {
+			condition = statement.Condition.String()
+		}
+
+		for _, action := range statement.Action {
+			for _, resource := range statement.Resources {
+				grant := Grant{
+					Effect:    statement.Effect,
+					Action:    action,
+					Resource:  resource,
+					Condition: condition,
+				}
+
+				if _, found := unique[grant.key()]; !found {
+					unique[grant.key()] = grant
+				}
+			}
+		}
+	}
+
+	grants := make([]Grant, 0, len(unique))
+	for _, grant := range unique {
+		grants = append(grants, grant)
+	}
+
+	sort.Slice(grants, func(i, j int) bool { return grants[i].key().String() < grants[j].key().String() })
+
+	return grants
+}
+
+// DiffGrants returns the grants of a policy document which are not in another policy document
+// and the grants of the other policy document which are not in the policy document.
+func (document *PolicyDocument) DiffGrants(other *PolicyDocument) (removed, added []Grant) {
+	return subtractGrants(document.Grants(), other.Grants()), subtractGrants(other.Grants(), document.Grants())
+}
+
+// Equivalent determines whether a policy document is semantically equal to another.
+func (document *PolicyDocument) Equivalent(other *PolicyDocument) bool {
+	removed, added := document.DiffGrants(other)
+
+	return len(removed) == 0 && len(added) == 0
+}
+
+// subtractGrants returns the grants in one set of grants which are not in another.
+func subtractGrants(grants, other []Grant) []Grant {
+	exists := map[Grant]bool{}
+	for _, grant := range other {
+		exists[grant.key()] = true
+	}
+
+	difference := []Grant{}
+
+	for _, grant := range grants {
+		if !exists[grant.key()] {
+			difference = append(difference, grant)
+		}
+	}
+
+	return difference
+}
+
+// key returns the representation of a grant used to compare it with other grants, where the
+// action is normalized to lowercase as actions are case-insensitive.
+func (grant Grant) key() Grant {
+	grant.Action = strings.ToLower(grant.Action)
+
+	return grant
+}
@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+)
+
+func TestPolicyDocument_DiffGrants(t *testing.T) {
+	t.Parallel()
+
+	document := &PolicyDocument{
+		Statements: Statements{
+			{SID: "A", Effect: "Allow", Action: []string{"s3:GetObject", "s3:ListBucket"}, Resources: []string{"*"}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		other       *PolicyDocument
+		wantRemoved []Grant
+		wantAdded   []Grant
+	}{
+		{
+			name: "ensure differently arranged statements are equivalent",
+			other: &PolicyDocument{
+				Statements: Statements{
+					{SID: "B", Effect: "Allow", Action: []string{"S3:listbucket"}, Resources: []string{"*"}},
+					{SID: "C", Effect: "Allow", Action: []string{"s3:GetObject"}, Resources: []string{"*"}},
+				},
+			},
+			wantRemoved: []Grant{},
+			wantAdded:   []Grant{},
+		},
+		{
+			name: "ensure differing grants are returned",
+			other: &PolicyDocument{
+				Statements: Statements{
+					{
+						SID:       "B",
+						Effect:    "Allow",
+						Action:    []string{"s3:GetObject"},
+						Resources: []string{"*"},
+						Condition: conditions.NewCondition("aws:RequestedRegion", "us-east-1", "StringEquals"),
+					},
+					{SID: "C", Effect: "Allow", Action: []string{"s3:ListBucket"}, Resources: []string{"*"}},
+				},
+			},
+			wantRemoved: []Grant{
+				{Effect: "Allow", Action: "s3:GetObject", Resource: "*"},
+			},
+			wantAdded: []Grant{
+				{Effect: "Allow", Action: "s3:GetObject", Resource: "*", Condition: `{"StringEquals":{"aws:RequestedRegion":"us-east-1"}}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			removed, added := document.DiffGrants(tt.other)
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("PolicyDocument.DiffGrants() removed = %v, want %v", removed, tt.wantRemoved)
+			}
+
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("PolicyDocument.DiffGrants() added = %v, want %v", added, tt.wantAdded)
+			}
+		})
+	}
+}
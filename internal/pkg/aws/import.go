@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+)
+
+const (
+	statementIDMaxLength = 64
+	statementIDPrefix    = "Statement"
+)
+
+var statementIDInvalidCharacters = regexp.MustCompile("[^a-zA-Z0-9]")
+
+// ToMarkers converts a policy document into an equivalent set of markers for a policy with the
+// given name, one for each action and resource of each statement, with the given reason.  Each
+// marker may only have a single condition, so statements with multiple condition operators or
+// keys, or with NotAction or NotResource elements, return an error.  Statement ids which are not valid for markers are sanitized.
+func (document *PolicyDocument) ToMarkers(name, reason string) ([]*Marker, error) {
+	markers := []*Marker{}
+
+	for i, statement := range document.Statements {
+		id := statementIDInvalidCharacters.ReplaceAllString(statement.SID, "")
+		if len(id) > statementIDMaxLength {
+			id = id[:statementIDMaxLength]
+		}
+
+		if id == "" {
+			id = fmt.Sprintf("%s%d", statementIDPrefix, i+1)
+		}
+
+		if statement.HasNotElements() {
+			return nil, fmt.Errorf(
+				"invalid statement [%d] with sid [%s] - %w [NotAction, NotResource] - markers only support Action and Resource",
+				i, statement.SID, ErrUnsupportedPolicy,
+			)
+		}
+
+		operator, key, value, err := singleCondition(&statement)
+		if err != nil {
+			return nil, fmt.Errorf("invalid statement [%d] with sid [%s] - %w", i, statement.SID, err)
+		}
+
+		for _, action := range statement.Action {
+			for _, resource := range statement.Resources {
+				marker := &Marker{
+					Name:     pointers.String(name),
+					Id:       pointers.String(id),
+					Action:   pointers.String(action),
+					Effect:   pointers.String(statement.Effect),
+					Resource: pointers.String(resource),
+					Reason:   pointers.String(reason),
+				}
+
+				if operator != "" {
+					marker.ConditionOperator = pointers.String(operator)
+					marker.ConditionKey = pointers.String(key)
+					marker.ConditionValue = pointers.String(value)
+				}
+
+				markers = append(markers, marker)
+			}
+		}
+	}
+
+	return markers, nil
+}
+
+// singleCondition returns the operator, key and value of the condition of a statement.  Empty
+// values are returned if the statement has no condition.
+func singleCondition(statement *Statement) (operator, key, value string, err error) {
+	if statement.Condition == nil {
+		return "", "", "", nil
+	}
+
+	data, err := json.Marshal(statement.Condition)
+	if err != nil {
+		return "", "", "", fmt.Errorf("unable to convert condition - %w", err)
+	}
+
+	operators := map[string]map[string]string{}
+	if err := json.Unmarshal(data, &operators); err != nil {
+		return "", "", "", fmt.Errorf("unable to convert condition - %w", err)
+	}
+
+	for operator, keys := range operators {
+		if len(operators) > 1 || len(keys) > 1 {
+			return "", "", "", fmt.Errorf("%w [Condition] - markers only support a single condition operator and key", ErrUnsupportedPolicy)
+		}
+
+		for key, value := range keys {
+			return operator, key, value, nil
+		}
+	}
+
+	return "", "", "", nil
+}
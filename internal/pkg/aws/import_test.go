@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+)
+
+func TestPolicyDocument_ToMarkers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		document  *PolicyDocument
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name: "ensure statements with multiple actions and resources regenerate an equivalent policy",
+			document: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{SID: "read-buckets", Effect: "Allow", Action: []string{"s3:GetObject", "s3:ListBucket"}, Resources: []string{"arn:aws:s3:::a", "arn:aws:s3:::a/*"}},
+					{Effect: "Allow", Action: []string{"ec2:DescribeVpcs"}, Resources: []string{"*"}},
+					{Effect: "Deny", Action: []string{"iam:*"}, Resources: []string{"*"}},
+				},
+			},
+			wantCount: 6,
+		},
+		{
+			name: "ensure statement with a single condition regenerates an equivalent policy",
+			document: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{
+						SID:       "Tagged",
+						Effect:    "Allow",
+						Action:    []string{"ec2:DeleteVpc", "ec2:ModifyVpcAttribute"},
+						Resources: []string{"*"},
+						Condition: conditions.NewCondition("aws:ResourceTag/owner", "me", "StringEquals"),
+					},
+					{SID: "Tagged", Effect: "Allow", Action: []string{"ec2:DeleteVpc"}, Resources: []string{"arn:aws:ec2:*:*:vpc/*"}},
+				},
+			},
+			wantCount: 3,
+		},
+		{
+			name: "ensure statement with multiple condition keys returns an error",
+			document: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{
+						Effect:    "Allow",
+						Action:    []string{"ec2:DeleteVpc"},
+						Resources: []string{"*"},
+						Condition: &conditions.Condition{StringEquals: conditions.Operator{"aws:ResourceTag/owner": "me", "aws:RequestedRegion": "us-east-1"}},
+					},
+				},
+			},
+			wantErr: ErrUnsupportedPolicy,
+		},
+		{
+			name: "ensure statement with not action returns an error",
+			document: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{Effect: "Deny", NotAction: []string{"iam:*"}, Resources: []string{"*"}},
+				},
+			},
+			wantErr: ErrUnsupportedPolicy,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			markers, err := tt.document.ToMarkers("test", "TODO")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PolicyDocument.ToMarkers() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if len(markers) != tt.wantCount {
+				t.Errorf("PolicyDocument.ToMarkers() count = %v, want %v", len(markers), tt.wantCount)
+			}
+
+			values := make([]Marker, len(markers))
+
+			for i := range markers {
+				if err := markers[i].Validate(); err != nil {
+					t.Errorf("PolicyDocument.ToMarkers() invalid marker [%s] - %v", markers[i], err)
+				}
+
+				values[i] = *markers[i]
+			}
+
+			if tt.wantErr == nil && !tt.document.Equivalent(NewPolicyDocument(values...)) {
+				t.Errorf("PolicyDocument.ToMarkers() did not regenerate an equivalent policy")
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package inventory
+
+import (
+	"sort"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	// SchemaVersion is the version of the inventory format.  It is incremented whenever a field
+	// is removed or changed so that consumers may detect incompatible inventories.
+	SchemaVersion = "v1"
+
+	// SchemaURL is the location of the JSON Schema of the current version of the inventory.
+	SchemaURL = "https://raw.githubusercontent.com/scottd018/policy-gen/main/internal/pkg/aws/inventory/schema/" +
+		SchemaVersion + ".json"
+)
+
+// Inventory represents the permissions of every generated policy, with a record for each marker.
+type Inventory struct {
+	Schema  string    `json:"$schema" yaml:"$schema"`
+	Version string    `json:"version" yaml:"version"`
+	Records []*Record `json:"records" yaml:"records"`
+}
+
+// Record represents the permission which a single marker grants or denies.
+type Record struct {
+	Policy      string              `json:"policy" yaml:"policy"`
+	Sid         string              `json:"sid" yaml:"sid"`
+	Effect      string              `json:"effect" yaml:"effect"`
+	Action      string              `json:"action" yaml:"action"`
+	Resource    string              `json:"resource" yaml:"resource"`
+	AccessLevel string              `json:"accessLevel" yaml:"accessLevel"`
+	Conditions  []conditions.Clause `json:"conditions" yaml:"conditions"`
+	Reason      string              `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Expires     string              `json:"expires,omitempty" yaml:"expires,omitempty"`
+	Source      *Source             `json:"source,omitempty" yaml:"source,omitempty"`
+	Metadata    map[string]string   `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// Source represents the location a marker was found at.
+type Source struct {
+	File string `json:"file" yaml:"file"`
+	Line int    `json:"line" yaml:"line"`
+}
+
+// New returns the inventory of a set of markers, in the order in which they were found.  The
+// statement id of each record is the id of the statement of the generated policy which contains
+// the permission, which may differ from the id of the marker when statements conflict.
+func New(generator *aws.PolicyDocumentGenerator, markers []policy.Marker) (*Inventory, error) {
+	documents, err := generator.ToPolicyDocuments(markers)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &Inventory{
+		Schema:  SchemaURL,
+		Version: SchemaVersion,
+		Records: make([]*Record, len(markers)),
+	}
+
+	for i := range markers {
+		marker, ok := markers[i].(*aws.Marker)
+		if !ok {
+			return nil, aws.ErrMarkerConvert
+		}
+
+		generated := *marker
+		if generator.ExpiryCondition {
+			generated.WithExpiryCondition()
+		}
+
+		record := &Record{
+			Policy:      marker.GetName(),
+			Sid:         *marker.Id,
+			Effect:      marker.EffectColumn(),
+			Action:      marker.PermissionColumn(),
+			Resource:    marker.ResourceColumn(),
+			AccessLevel: marker.AccessLevelColumn(),
+			Conditions:  generated.Condition().Clauses(),
+			Reason:      marker.ReasonColumn(),
+			Expires:     marker.ExpiresColumn(),
+		}
+
+		if statement := documents[record.Policy].StatementFor(generated); statement != nil {
+			record.Sid = statement.SID
+		}
+
+		if source := marker.GetSource(); source != nil {
+			record.Source = &Source{File: source.File, Line: source.Line}
+		}
+
+		if metadata := marker.GetMetadata(); len(metadata) > 0 {
+			record.Metadata = metadata
+		}
+
+		inventory.Records[i] = record
+	}
+
+	return inventory, nil
+}
+
+// MetadataKeys returns the metadata keys of every record, in order.
+func (inventory *Inventory) MetadataKeys() []string {
+	seen := map[string]bool{}
+	keys := []string{}
+
+	for _, record := range inventory.Records {
+		for key := range record.Metadata {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
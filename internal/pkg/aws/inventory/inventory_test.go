@@ -0,0 +1,249 @@
+package inventory
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func newInventory(t *testing.T) *Inventory {
+	t.Helper()
+
+	conditioned := &aws.Marker{
+		Name:              pointers.String("test"),
+		Action:            pointers.String("s3:PutObject"),
+		Effect:            pointers.String(aws.ValidEffectAllow),
+		Resource:          pointers.String("*"),
+		Reason:            pointers.String("test reason"),
+		ConditionKey:      pointers.String("aws:RequestedRegion"),
+		ConditionValue:    pointers.String("us-east-1"),
+		ConditionOperator: pointers.String(conditions.StringEqualsOperator),
+		Metadata:          pointers.String("owner=platform,ticket=SEC-1"),
+	}
+	conditioned.SetSource(&policy.Source{File: "main.go", Line: 7})
+
+	permissions, err := New(&aws.PolicyDocumentGenerator{}, []policy.Marker{
+		&aws.Marker{
+			Name:     pointers.String("test"),
+			Action:   pointers.String("s3:GetObject"),
+			Effect:   pointers.String(aws.ValidEffectAllow),
+			Resource: pointers.String("*"),
+			Reason:   pointers.String("test reason"),
+		},
+		&aws.Marker{
+			Name:     pointers.String("test"),
+			Action:   pointers.String("s3:DeleteObject"),
+			Effect:   pointers.String(aws.ValidEffectDeny),
+			Resource: pointers.String("*"),
+			Reason:   pointers.String("test reason"),
+		},
+		conditioned,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return permissions
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	permissions := newInventory(t)
+
+	want := []*Record{
+		{
+			Policy:      "test",
+			Sid:         "Default",
+			Effect:      aws.ValidEffectAllow,
+			Action:      "s3:GetObject",
+			Resource:    "*",
+			AccessLevel: policy.AccessLevelRead,
+			Conditions:  []conditions.Clause{},
+			Reason:      "test reason",
+		},
+		{
+			Policy:      "test",
+			Sid:         "Default1",
+			Effect:      aws.ValidEffectDeny,
+			Action:      "s3:DeleteObject",
+			Resource:    "*",
+			AccessLevel: policy.AccessLevelWrite,
+			Conditions:  []conditions.Clause{},
+			Reason:      "test reason",
+		},
+		{
+			Policy:      "test",
+			Sid:         "Default2",
+			Effect:      aws.ValidEffectAllow,
+			Action:      "s3:PutObject",
+			Resource:    "*",
+			AccessLevel: policy.AccessLevelWrite,
+			Conditions: []conditions.Clause{
+				{Operator: conditions.StringEqualsOperator, Key: "aws:RequestedRegion", Value: "us-east-1"},
+			},
+			Reason:   "test reason",
+			Source:   &Source{File: "main.go", Line: 7},
+			Metadata: map[string]string{"owner": "platform", "ticket": "SEC-1"},
+		},
+	}
+
+	if permissions.Version != SchemaVersion || permissions.Schema != SchemaURL {
+		t.Errorf("New() version = %v, schema = %v", permissions.Version, permissions.Schema)
+	}
+
+	if !reflect.DeepEqual(permissions.Records, want) {
+		got, _ := json.Marshal(permissions.Records)
+		t.Errorf("New() records = %s", got)
+	}
+}
+
+func TestInventory_Render(t *testing.T) {
+	t.Parallel()
+
+	permissions := newInventory(t)
+
+	tests := []struct {
+		name     string
+		format   string
+		contains []string
+		wantErr  bool
+	}{
+		{
+			name:   "ensure json renders structured conditions",
+			format: FormatJSON,
+			contains: []string{
+				`"version": "v1"`,
+				`"operator": "StringEquals"`,
+				`"key": "aws:RequestedRegion"`,
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ensure yaml renders structured conditions",
+			format: FormatYAML,
+			contains: []string{
+				"version: v1",
+				"- operator: StringEquals",
+			},
+			wantErr: false,
+		},
+		{
+			name:   "ensure csv renders a row for each record with metadata columns",
+			format: FormatCSV,
+			contains: []string{
+				"policy,sid,effect,action,resource,access_level,conditions,reason,expires,source_file,source_line,metadata.owner,metadata.ticket\n",
+				"test,Default,Allow,s3:GetObject,*,Read,,test reason,,,,,\n",
+				"test,Default2,Allow,s3:PutObject,*,Write,StringEquals aws:RequestedRegion=us-east-1,test reason,,main.go,7,platform,SEC-1\n",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ensure unknown format returns an error",
+			format:  "xml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := permissions.Render(tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Inventory.Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for _, want := range tt.contains {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("Inventory.Render() = %s, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "ensure json extension is json",
+			path:    "inventory.json",
+			want:    FormatJSON,
+			wantErr: false,
+		},
+		{
+			name:    "ensure yml extension is yaml",
+			path:    "out/inventory.YML",
+			want:    FormatYAML,
+			wantErr: false,
+		},
+		{
+			name:    "ensure unknown extension returns an error",
+			path:    "inventory.txt",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FormatFor(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("FormatFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	jsonSchema := struct {
+		ID         string                     `json:"$id"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       struct {
+			Record struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"record"`
+		} `json:"$defs"`
+	}{}
+
+	if err := json.Unmarshal(Schema(), &jsonSchema); err != nil {
+		t.Fatalf("Schema() is not valid json - %v", err)
+	}
+
+	if jsonSchema.ID != SchemaURL {
+		t.Errorf("Schema() id = %v, want %v", jsonSchema.ID, SchemaURL)
+	}
+
+	// every field of a record must be described by the schema
+	recordType := reflect.TypeOf(Record{})
+	for i := 0; i < recordType.NumField(); i++ {
+		name, _, _ := strings.Cut(recordType.Field(i).Tag.Get("json"), ",")
+
+		if _, ok := jsonSchema.Defs.Record.Properties[name]; !ok {
+			t.Errorf("Schema() is missing record property [%s]", name)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package inventory
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatYAML = "yaml"
+
+	// csvMetadataPrefix is the prefix of the columns of metadata keys, which distinguishes them
+	// from the built-in columns.
+	csvMetadataPrefix = "metadata."
+)
+
+// schema is the JSON Schema of the current version of the inventory.
+//
+//go:embed schema/v1.json
+var schema []byte
+
+// Schema returns the JSON Schema of the current version of the inventory.
+func Schema() []byte {
+	return schema
+}
+
+// FormatFor returns the format of an inventory file from the extension of its path.
+func FormatFor(path string) (string, error) {
+	switch extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")); extension {
+	case FormatJSON, FormatCSV, FormatYAML:
+		return extension, nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf(
+			"%w [%s] - file extension must be one of [.%s, .%s, .%s]",
+			ErrInvalidFormat, path, FormatJSON, FormatCSV, FormatYAML,
+		)
+	}
+}
+
+// Render renders an inventory in the given format.
+func (inventory *Inventory) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return inventory.JSON()
+	case FormatCSV:
+		return inventory.CSV()
+	case FormatYAML:
+		return inventory.YAML()
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s, %s]", ErrInvalidFormat, format, FormatJSON, FormatCSV, FormatYAML)
+	}
+}
+
+// JSON renders an inventory as JSON.
+func (inventory *Inventory) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(inventory, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal inventory - %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// YAML renders an inventory as YAML.
+func (inventory *Inventory) YAML() ([]byte, error) {
+	data, err := yaml.Marshal(inventory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal inventory - %w", err)
+	}
+
+	return data, nil
+}
+
+// CSV renders an inventory as CSV, with a row for each record.  Conditions are flattened into a
+// single column as "Operator key=value" clauses separated by semicolons, and each metadata key
+// has its own column.
+func (inventory *Inventory) CSV() ([]byte, error) {
+	metadataKeys := inventory.MetadataKeys()
+
+	header := []string{"policy", "sid", "effect", "action", "resource", "access_level", "conditions", "reason", "expires", "source_file", "source_line"}
+	for _, key := range metadataKeys {
+		header = append(header, csvMetadataPrefix+key)
+	}
+
+	content := &bytes.Buffer{}
+	writer := csv.NewWriter(content)
+
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("unable to write inventory header - %w", err)
+	}
+
+	for _, record := range inventory.Records {
+		clauses := make([]string, len(record.Conditions))
+		for i, clause := range record.Conditions {
+			clauses[i] = fmt.Sprintf("%s %s=%s", clause.Operator, clause.Key, clause.Value)
+		}
+
+		var file, line string
+		if record.Source != nil {
+			file, line = record.Source.File, strconv.Itoa(record.Source.Line)
+		}
+
+		row := []string{
+			record.Policy,
+			record.Sid,
+			record.Effect,
+			record.Action,
+			record.Resource,
+			record.AccessLevel,
+			strings.Join(clauses, "; "),
+			record.Reason,
+			record.Expires,
+			file,
+			line,
+		}
+
+		for _, key := range metadataKeys {
+			row = append(row, record.Metadata[key])
+		}
+
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("unable to write inventory record - %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("unable to write inventory - %w", err)
+	}
+
+	return content.Bytes(), nil
+}
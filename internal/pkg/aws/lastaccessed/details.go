@@ -0,0 +1,107 @@
+package lastaccessed
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+)
+
+// Details represents the output of the IAM GetServiceLastAccessedDetails API, which returns the
+// result of a job started by the GenerateServiceLastAccessedDetails API.
+type Details struct {
+	JobStatus            string                `json:"JobStatus"`
+	JobType              string                `json:"JobType"`
+	JobCompletionDate    *time.Time            `json:"JobCompletionDate,omitempty"`
+	ServicesLastAccessed []ServiceLastAccessed `json:"ServicesLastAccessed"`
+}
+
+// ServiceLastAccessed represents when a service was last accessed.  Actions are only tracked
+// for jobs with action level granularity and for services which support it.
+type ServiceLastAccessed struct {
+	ServiceName                string               `json:"ServiceName"`
+	ServiceNamespace           string               `json:"ServiceNamespace"`
+	LastAuthenticated          *time.Time           `json:"LastAuthenticated,omitempty"`
+	TrackedActionsLastAccessed []ActionLastAccessed `json:"TrackedActionsLastAccessed,omitempty"`
+}
+
+// ActionLastAccessed represents when an action was last accessed.
+type ActionLastAccessed struct {
+	ActionName       string     `json:"ActionName"`
+	LastAccessedTime *time.Time `json:"LastAccessedTime,omitempty"`
+}
+
+// ReadDetails reads the last accessed details from a file.
+func ReadDetails(path string) (*Details, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file [%s] - %w", path, err)
+	}
+
+	details := &Details{}
+	if err := json.Unmarshal(content, details); err != nil {
+		return nil, fmt.Errorf("unable to parse last accessed details file [%s] - %w", path, err)
+	}
+
+	return details, nil
+}
+
+// LastUsed returns when an action, which may contain wildcards, was last used.  The most recent
+// use of any action matching the pattern is returned.  If the actions of a service are not
+// tracked, the last use of the service is used instead.  It returns a nil time if the action was
+// never used and false if none of the services matching the action are present in the details.
+func (details *Details) LastUsed(action string) (*time.Time, bool) {
+	var lastUsed *time.Time
+
+	known := false
+
+	// an action without a service, such as "*", matches every service
+	servicePattern := aws.ServiceFor(action)
+	if servicePattern == "" {
+		servicePattern = action
+	}
+
+	for _, service := range details.ServicesLastAccessed {
+		if !aws.MatchAction(servicePattern, service.ServiceNamespace) {
+			continue
+		}
+
+		known = true
+
+		// prefer the tracked actions of the service, falling back to the service itself
+		used, tracked := service.actionLastUsed(action)
+		if !tracked {
+			used = service.LastAuthenticated
+		}
+
+		if used != nil && (lastUsed == nil || used.After(*lastUsed)) {
+			lastUsed = used
+		}
+	}
+
+	return lastUsed, known
+}
+
+// actionLastUsed returns when the tracked actions of a service matching an action pattern were
+// last used and whether any tracked action matched.
+func (service *ServiceLastAccessed) actionLastUsed(action string) (*time.Time, bool) {
+	var lastUsed *time.Time
+
+	tracked := false
+
+	for _, trackedAction := range service.TrackedActionsLastAccessed {
+		if !aws.MatchAction(action, service.ServiceNamespace+":"+trackedAction.ActionName) {
+			continue
+		}
+
+		tracked = true
+
+		if used := trackedAction.LastAccessedTime; used != nil && (lastUsed == nil || used.After(*lastUsed)) {
+			lastUsed = used
+		}
+	}
+
+	return lastUsed, tracked
+}
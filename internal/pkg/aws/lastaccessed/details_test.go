@@ -0,0 +1,93 @@
+package lastaccessed
+
+import (
+	"testing"
+	"time"
+)
+
+const testDetailsPath = "test/input/last-accessed.json"
+
+func TestDetails_LastUsed(t *testing.T) {
+	t.Parallel()
+
+	details, err := ReadDetails(testDetailsPath)
+	if err != nil {
+		t.Fatalf("ReadDetails() error = %v", err)
+	}
+
+	recent := time.Date(2024, 5, 30, 0, 0, 0, 0, time.UTC)
+	service := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		action    string
+		want      *time.Time
+		wantKnown bool
+	}{
+		{
+			name:      "ensure tracked action returns its last use",
+			action:    "ec2:DescribeVpcs",
+			want:      &old,
+			wantKnown: true,
+		},
+		{
+			name:      "ensure tracked action is matched case-insensitively",
+			action:    "EC2:createvpc",
+			want:      &recent,
+			wantKnown: true,
+		},
+		{
+			name:      "ensure tracked action which was never used returns nil",
+			action:    "ec2:DeleteVpc",
+			want:      nil,
+			wantKnown: true,
+		},
+		{
+			name:      "ensure wildcard action returns the most recent use of matching actions",
+			action:    "ec2:*Vpc*",
+			want:      &recent,
+			wantKnown: true,
+		},
+		{
+			name:      "ensure untracked action returns the last use of its service",
+			action:    "lambda:InvokeFunction",
+			want:      &service,
+			wantKnown: true,
+		},
+		{
+			name:      "ensure service which was never used returns nil",
+			action:    "iam:ListRoles",
+			want:      nil,
+			wantKnown: true,
+		},
+		{
+			name:      "ensure full wildcard returns the most recent use of any service",
+			action:    "*",
+			want:      &recent,
+			wantKnown: true,
+		},
+		{
+			name:      "ensure missing service is unknown",
+			action:    "s3:GetObject",
+			want:      nil,
+			wantKnown: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, known := details.LastUsed(tt.action)
+			if known != tt.wantKnown {
+				t.Errorf("Details.LastUsed() known = %v, want %v", known, tt.wantKnown)
+			}
+
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("Details.LastUsed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
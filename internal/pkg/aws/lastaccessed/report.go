@@ -0,0 +1,172 @@
+package lastaccessed
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+// Status represents why a marker is reported as unused.
+type Status string
+
+const (
+	StatusNeverUsed   Status = "never used"
+	StatusNotUsed     Status = "not recently used"
+	StatusNotReported Status = "not reported"
+
+	FormatTable = "table"
+	FormatJSON  = "json"
+
+	hoursPerDay = 24
+)
+
+// Finding represents a marker which allows an action that has not been used.
+type Finding struct {
+	Name     string            `json:"name"`
+	Action   string            `json:"action"`
+	Resource string            `json:"resource"`
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Source   *policy.Source    `json:"source,omitempty"`
+	Status   Status            `json:"status"`
+	LastUsed *time.Time        `json:"lastUsed,omitempty"`
+}
+
+// Report represents the result of comparing the actions allowed by markers with the actions
+// that were last accessed.
+type Report struct {
+	Days       int        `json:"days"`
+	Markers    int        `json:"markers"`
+	Unused     []*Finding `json:"unused"`
+	Unreported []*Finding `json:"unreported"`
+}
+
+// NewReport compares the actions allowed by a set of markers, which make up the policy documents
+// generated from them, with last accessed details.  A marker is unused when its action was never
+// used, or was not used within the given number of days before now.  Markers whose services are
+// not present in the details are reported separately as their use is unknown.  Only markers
+// which allow actions are considered.
+func NewReport(details *Details, policyMarkers []policy.Marker, days int, now time.Time) (*Report, error) {
+	report := &Report{
+		Days:       days,
+		Unused:     []*Finding{},
+		Unreported: []*Finding{},
+	}
+
+	cutoff := now.Add(-time.Duration(days) * hoursPerDay * time.Hour)
+
+	for i := range policyMarkers {
+		marker, ok := policyMarkers[i].(*aws.Marker)
+		if !ok {
+			return nil, aws.ErrMarkerConvert
+		}
+
+		if marker.EffectColumn() != aws.ValidEffectAllow {
+			continue
+		}
+
+		report.Markers++
+
+		finding := &Finding{
+			Name:     marker.GetName(),
+			Action:   marker.PermissionColumn(),
+			Resource: marker.ResourceColumn(),
+			Reason:   marker.ReasonColumn(),
+			Metadata: marker.GetMetadata(),
+			Source:   marker.GetSource(),
+		}
+
+		lastUsed, known := details.LastUsed(finding.Action)
+
+		switch {
+		case !known:
+			finding.Status = StatusNotReported
+			report.Unreported = append(report.Unreported, finding)
+		case lastUsed == nil:
+			finding.Status = StatusNeverUsed
+			report.Unused = append(report.Unused, finding)
+		case lastUsed.Before(cutoff):
+			finding.Status = StatusNotUsed
+			finding.LastUsed = lastUsed
+			report.Unused = append(report.Unused, finding)
+		}
+	}
+
+	return report, nil
+}
+
+// Render renders a report in the given format.
+func (report *Report) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatTable:
+		return report.Table(), nil
+	case FormatJSON:
+		return report.JSON()
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s]", ErrInvalidFormat, format, FormatTable, FormatJSON)
+	}
+}
+
+// Table renders a report as a human-readable table.
+func (report *Report) Table() []byte {
+	tableBytes := &bytes.Buffer{}
+
+	table := tablewriter.NewWriter(tableBytes)
+	table.SetHeader([]string{"policy", "action", "resource", "status", "last used", "source", "reason"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+
+	for _, findings := range [][]*Finding{report.Unused, report.Unreported} {
+		for _, finding := range findings {
+			lastUsed := ""
+			if finding.LastUsed != nil {
+				lastUsed = finding.LastUsed.Format(time.DateOnly)
+			}
+
+			table.Append([]string{
+				finding.Name,
+				finding.Action,
+				finding.Resource,
+				string(finding.Status),
+				lastUsed,
+				finding.Source.String(),
+				finding.Reason,
+			})
+		}
+	}
+
+	table.Render()
+
+	fmt.Fprintf(
+		tableBytes,
+		"\ndays: %d, markers: %d, unused markers: %d, unreported markers: %d\n",
+		report.Days,
+		report.Markers,
+		len(report.Unused),
+		len(report.Unreported),
+	)
+
+	return tableBytes.Bytes()
+}
+
+// JSON renders a report as JSON.
+func (report *Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal unused report - %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,98 @@
+package lastaccessed
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+
+	details, err := ReadDetails(testDetailsPath)
+	if err != nil {
+		t.Fatalf("ReadDetails() error = %v", err)
+	}
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	policyMarkers := []policy.Marker{}
+	for _, action := range []string{"ec2:CreateVpc", "ec2:DeleteVpc", "ec2:DescribeVpcs", "lambda:InvokeFunction", "s3:GetObject"} {
+		marker := &aws.Marker{Name: pointers.String("test"), Action: pointers.String(action)}
+		marker.WithDefault()
+
+		policyMarkers = append(policyMarkers, marker)
+	}
+
+	policyMarkers = append(policyMarkers, &aws.Marker{
+		Name:   pointers.String("test"),
+		Action: pointers.String("iam:ListRoles"),
+		Effect: pointers.String(aws.ValidEffectDeny),
+	})
+
+	tests := []struct {
+		name           string
+		days           int
+		wantUnused     map[string]Status
+		wantUnreported []string
+	}{
+		{
+			name: "ensure actions not used within 90 days are reported",
+			days: 90,
+			wantUnused: map[string]Status{
+				"ec2:DeleteVpc":    StatusNeverUsed,
+				"ec2:DescribeVpcs": StatusNotUsed,
+			},
+			wantUnreported: []string{"s3:GetObject"},
+		},
+		{
+			name: "ensure actions not used within 7 days are reported",
+			days: 7,
+			wantUnused: map[string]Status{
+				"ec2:DeleteVpc":         StatusNeverUsed,
+				"ec2:DescribeVpcs":      StatusNotUsed,
+				"lambda:InvokeFunction": StatusNotUsed,
+			},
+			wantUnreported: []string{"s3:GetObject"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report, err := NewReport(details, policyMarkers, tt.days, now)
+			if err != nil {
+				t.Fatalf("NewReport() error = %v", err)
+			}
+
+			if report.Markers != 5 {
+				t.Errorf("NewReport() markers = %v, want %v", report.Markers, 5)
+			}
+
+			gotUnused := map[string]Status{}
+			for _, finding := range report.Unused {
+				gotUnused[finding.Action] = finding.Status
+			}
+
+			if !reflect.DeepEqual(gotUnused, tt.wantUnused) {
+				t.Errorf("NewReport() unused = %v, want %v", gotUnused, tt.wantUnused)
+			}
+
+			gotUnreported := []string{}
+			for _, finding := range report.Unreported {
+				gotUnreported = append(gotUnreported, finding.Action)
+			}
+
+			if !reflect.DeepEqual(gotUnreported, tt.wantUnreported) {
+				t.Errorf("NewReport() unreported = %v, want %v", gotUnreported, tt.wantUnreported)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+)
+
+var (
+	ErrInvalidEntry = errors.New("invalid ledger entry")
+)
+
+const (
+	// Version is the version of the ledger file format.
+	Version = 1
+
+	hashPrefix = "sha256:"
+)
+
+// Ledger represents a file, committed to a repository, of the grants which have been approved
+// for each policy.  Requiring review of changes to the ledger, for example with CODEOWNERS,
+// makes that review the approval gate for new permissions.
+type Ledger struct {
+	Version   int      `json:"version"`
+	Approvals []*Entry `json:"approvals"`
+}
+
+// Entry represents an approved grant of a policy.  The hash identifies the grant, while the
+// remaining fields allow a reviewer to see what is being approved.
+type Entry struct {
+	Hash      string `json:"hash"`
+	Policy    string `json:"policy"`
+	Effect    string `json:"effect"`
+	Action    string `json:"action"`
+	Resource  string `json:"resource"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// NewEntry creates a new ledger entry for a grant of a policy.
+func NewEntry(policy string, grant aws.Grant) *Entry {
+	return &Entry{
+		Hash:      Hash(policy, grant),
+		Policy:    policy,
+		Effect:    grant.Effect,
+		Action:    grant.Action,
+		Resource:  grant.Resource,
+		Condition: grant.Condition,
+	}
+}
+
+// String returns the string representation of a ledger entry.
+func (entry *Entry) String() string {
+	return fmt.Sprintf("%s: %s", entry.Policy, entry.grant())
+}
+
+// Validate validates that the hash of a ledger entry identifies the grant that it describes, so
+// that the grant shown to a reviewer is the grant which is approved.
+func (entry *Entry) Validate() error {
+	if hash := Hash(entry.Policy, entry.grant()); hash != entry.Hash {
+		return fmt.Errorf("%w [%s] - hash [%s] does not match the hash of the grant [%s]", ErrInvalidEntry, entry, entry.Hash, hash)
+	}
+
+	return nil
+}
+
+// grant returns the grant that a ledger entry describes.
+func (entry *Entry) grant() aws.Grant {
+	return aws.Grant{Effect: entry.Effect, Action: entry.Action, Resource: entry.Resource, Condition: entry.Condition}
+}
+
+// Hash returns the hash which identifies a grant of a policy.  Actions are case-insensitive, so
+// the action is normalized to lowercase.
+func Hash(policy string, grant aws.Grant) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		policy,
+		grant.Effect,
+		strings.ToLower(grant.Action),
+		grant.Resource,
+		grant.Condition,
+	}, "\x00")))
+
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// Read reads a ledger from a path.  A ledger which does not exist is returned as an empty ledger
+// so that every grant is pending approval.  An error is returned if the hash of any entry does
+// not match the grant that it describes, such as when an entry has been edited by hand.
+func Read(path string) (*Ledger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &Ledger{Version: Version, Approvals: []*Entry{}}, nil
+		}
+
+		return nil, fmt.Errorf("unable to read ledger [%s] - %w", path, err)
+	}
+
+	ledger := &Ledger{}
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("unable to parse ledger [%s] - %w", path, err)
+	}
+
+	if ledger.Version != Version {
+		return nil, fmt.Errorf("unsupported ledger version [%d] in ledger [%s] - expected [%d]", ledger.Version, path, Version)
+	}
+
+	for _, entry := range ledger.Approvals {
+		if err := entry.Validate(); err != nil {
+			return nil, fmt.Errorf("unable to verify ledger [%s] - %w", path, err)
+		}
+	}
+
+	return ledger, nil
+}
+
+// Entries returns a ledger entry for every grant of a set of policy documents keyed by the policy
+// name, ordered by policy name and grant.
+func Entries(documents map[string]*aws.PolicyDocument) []*Entry {
+	names := make([]string, 0, len(documents))
+	for name := range documents {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	entries := []*Entry{}
+
+	for _, name := range names {
+		for _, grant := range documents[name].Grants() {
+			entries = append(entries, NewEntry(name, grant))
+		}
+	}
+
+	return entries
+}
+
+// Pending returns the entries for the grants of a set of policy documents keyed by the policy
+// name which have not been approved.
+func (ledger *Ledger) Pending(documents map[string]*aws.PolicyDocument) []*Entry {
+	approved := ledger.hashes()
+	pending := []*Entry{}
+
+	for _, entry := range Entries(documents) {
+		if !approved[entry.Hash] {
+			pending = append(pending, entry)
+		}
+	}
+
+	return pending
+}
+
+// Stale returns the approved entries which are no longer granted by a set of policy documents
+// keyed by the policy name.
+func (ledger *Ledger) Stale(documents map[string]*aws.PolicyDocument) []*Entry {
+	granted := map[string]bool{}
+	for _, entry := range Entries(documents) {
+		granted[entry.Hash] = true
+	}
+
+	stale := []*Entry{}
+
+	for _, entry := range ledger.Approvals {
+		if !granted[entry.Hash] {
+			stale = append(stale, entry)
+		}
+	}
+
+	return stale
+}
+
+// Approve adds a set of entries to the ledger.  Entries which are already approved are ignored.
+func (ledger *Ledger) Approve(entries ...*Entry) {
+	approved := ledger.hashes()
+
+	for _, entry := range entries {
+		if !approved[entry.Hash] {
+			ledger.Approvals = append(ledger.Approvals, entry)
+			approved[entry.Hash] = true
+		}
+	}
+
+	ledger.sort()
+}
+
+// Remove removes a set of entries from the ledger.
+func (ledger *Ledger) Remove(entries ...*Entry) {
+	removed := map[string]bool{}
+	for _, entry := range entries {
+		removed[entry.Hash] = true
+	}
+
+	approvals := []*Entry{}
+
+	for _, entry := range ledger.Approvals {
+		if !removed[entry.Hash] {
+			approvals = append(approvals, entry)
+		}
+	}
+
+	ledger.Approvals = approvals
+}
+
+// Marshal returns the representation of a ledger as it is written to a file.  Entries are
+// ordered so that changes to the ledger are easily reviewed.
+func (ledger *Ledger) Marshal() ([]byte, error) {
+	ledger.sort()
+
+	data, err := json.MarshalIndent(ledger, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal ledger - %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// hashes returns the set of hashes of the approved entries.
+func (ledger *Ledger) hashes() map[string]bool {
+	hashes := make(map[string]bool, len(ledger.Approvals))
+	for _, entry := range ledger.Approvals {
+		hashes[entry.Hash] = true
+	}
+
+	return hashes
+}
+
+// sort orders the entries of the ledger by policy name and grant.
+func (ledger *Ledger) sort() {
+	sort.SliceStable(ledger.Approvals, func(i, j int) bool {
+		if ledger.Approvals[i].Policy != ledger.Approvals[j].Policy {
+			return ledger.Approvals[i].Policy < ledger.Approvals[j].Policy
+		}
+
+		return ledger.Approvals[i].String() < ledger.Approvals[j].String()
+	})
+}
@@ -0,0 +1,299 @@
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func testDocuments() map[string]*aws.PolicyDocument {
+	return map[string]*aws.PolicyDocument{
+		"installer": {
+			Statements: aws.Statements{
+				{Effect: aws.ValidEffectAllow, Action: []string{"s3:GetObject", "s3:PutObject"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+			},
+		},
+		"uninstaller": {
+			Statements: aws.Statements{
+				{Effect: aws.ValidEffectAllow, Action: []string{"s3:DeleteObject"}, Resources: []string{"arn:aws:s3:::bucket/*"}},
+			},
+		},
+	}
+}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	grant := aws.Grant{Effect: aws.ValidEffectAllow, Action: "s3:GetObject", Resource: "*"}
+
+	tests := []struct {
+		name      string
+		policy    string
+		grant     aws.Grant
+		wantEqual bool
+	}{
+		{
+			name:      "ensure identical grants have the same hash",
+			policy:    "test",
+			grant:     grant,
+			wantEqual: true,
+		},
+		{
+			name:      "ensure actions which differ by case have the same hash",
+			policy:    "test",
+			grant:     aws.Grant{Effect: aws.ValidEffectAllow, Action: "S3:getobject", Resource: "*"},
+			wantEqual: true,
+		},
+		{
+			name:      "ensure grants of different policies have different hashes",
+			policy:    "other",
+			grant:     grant,
+			wantEqual: false,
+		},
+		{
+			name:      "ensure grants with different conditions have different hashes",
+			policy:    "test",
+			grant:     aws.Grant{Effect: aws.ValidEffectAllow, Action: "s3:GetObject", Resource: "*", Condition: "Bool aws:SecureTransport true"},
+			wantEqual: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Hash(tt.policy, tt.grant) == Hash("test", grant); got != tt.wantEqual {
+				t.Errorf("Hash() equal = %v, want %v", got, tt.wantEqual)
+			}
+		})
+	}
+}
+
+func TestLedger_Pending(t *testing.T) {
+	t.Parallel()
+
+	documents := testDocuments()
+	entries := Entries(documents)
+
+	tests := []struct {
+		name      string
+		approvals []*Entry
+		want      []string
+	}{
+		{
+			name:      "ensure every grant is pending for an empty ledger",
+			approvals: []*Entry{},
+			want: []string{
+				"installer: Allow s3:GetObject on arn:aws:s3:::bucket/*",
+				"installer: Allow s3:PutObject on arn:aws:s3:::bucket/*",
+				"uninstaller: Allow s3:DeleteObject on arn:aws:s3:::bucket/*",
+			},
+		},
+		{
+			name:      "ensure only unapproved grants are pending",
+			approvals: entries[:2],
+			want:      []string{"uninstaller: Allow s3:DeleteObject on arn:aws:s3:::bucket/*"},
+		},
+		{
+			name:      "ensure no grants are pending for a complete ledger",
+			approvals: entries,
+			want:      []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ledger := &Ledger{Version: Version, Approvals: tt.approvals}
+
+			got := []string{}
+			for _, entry := range ledger.Pending(documents) {
+				got = append(got, entry.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Ledger.Pending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLedger_PendingExpiry(t *testing.T) {
+	t.Parallel()
+
+	// documents returns the policy documents generated from a marker which expires, with or
+	// without the condition which enforces its expiry.
+	documents := func(expiryCondition bool) map[string]*aws.PolicyDocument {
+		generator := &aws.PolicyDocumentGenerator{ExpiryCondition: expiryCondition}
+
+		generated, err := generator.ToPolicyDocuments([]policy.Marker{
+			&aws.Marker{
+				Name:     pointers.String("installer"),
+				Action:   pointers.String("s3:GetObject"),
+				Effect:   pointers.String(aws.ValidEffectAllow),
+				Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				Expires:  pointers.String("2030-01-01"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("PolicyDocumentGenerator.ToPolicyDocuments() error = %v", err)
+		}
+
+		return generated
+	}
+
+	tests := []struct {
+		name           string
+		approveExpiry  bool
+		generateExpiry bool
+		wantPending    int
+	}{
+		{
+			name:           "ensure grants approved without enforced expiry are approved without enforced expiry",
+			approveExpiry:  false,
+			generateExpiry: false,
+			wantPending:    0,
+		},
+		{
+			name:           "ensure grants approved with enforced expiry are approved with enforced expiry",
+			approveExpiry:  true,
+			generateExpiry: true,
+			wantPending:    0,
+		},
+		{
+			name:           "ensure grants approved without enforced expiry are pending with enforced expiry",
+			approveExpiry:  false,
+			generateExpiry: true,
+			wantPending:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ledger := &Ledger{Version: Version, Approvals: []*Entry{}}
+			ledger.Approve(ledger.Pending(documents(tt.approveExpiry))...)
+
+			if got := len(ledger.Pending(documents(tt.generateExpiry))); got != tt.wantPending {
+				t.Errorf("Ledger.Pending() = %v, want %v", got, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestLedger_ApproveAndPrune(t *testing.T) {
+	t.Parallel()
+
+	documents := testDocuments()
+	removed := NewEntry("removed", aws.Grant{Effect: aws.ValidEffectAllow, Action: "ec2:CreateVpc", Resource: "*"})
+
+	ledger := &Ledger{Version: Version, Approvals: []*Entry{removed}}
+	ledger.Approve(ledger.Pending(documents)...)
+	ledger.Approve(ledger.Pending(documents)...)
+
+	if got := len(ledger.Approvals); got != 4 {
+		t.Fatalf("Ledger.Approve() approvals = %v, want %v", got, 4)
+	}
+
+	stale := ledger.Stale(documents)
+	if len(stale) != 1 || stale[0].Hash != removed.Hash {
+		t.Fatalf("Ledger.Stale() = %v, want [%v]", stale, removed)
+	}
+
+	ledger.Remove(stale...)
+
+	if got := len(ledger.Approvals); got != 3 {
+		t.Errorf("Ledger.Remove() approvals = %v, want %v", got, 3)
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	directory := t.TempDir()
+
+	ledger := &Ledger{Version: Version, Approvals: Entries(testDocuments())}
+
+	content, err := ledger.Marshal()
+	if err != nil {
+		t.Fatalf("Ledger.Marshal() error = %v", err)
+	}
+
+	valid := filepath.Join(directory, "valid.lock")
+	if err := os.WriteFile(valid, content, 0o600); err != nil {
+		t.Fatalf("unable to write ledger - %v", err)
+	}
+
+	unsupported := filepath.Join(directory, "unsupported.lock")
+	if err := os.WriteFile(unsupported, []byte(`{"version": 2, "approvals": []}`), 0o600); err != nil {
+		t.Fatalf("unable to write ledger - %v", err)
+	}
+
+	tampered := &Ledger{Version: Version, Approvals: Entries(testDocuments())}
+	tampered.Approvals[0].Resource = "*"
+
+	content, err = tampered.Marshal()
+	if err != nil {
+		t.Fatalf("Ledger.Marshal() error = %v", err)
+	}
+
+	modified := filepath.Join(directory, "tampered.lock")
+	if err := os.WriteFile(modified, content, 0o600); err != nil {
+		t.Fatalf("unable to write ledger - %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:      "ensure written ledger is read",
+			path:      valid,
+			wantCount: 3,
+		},
+		{
+			name:      "ensure missing ledger is read as empty",
+			path:      filepath.Join(directory, "missing.lock"),
+			wantCount: 0,
+		},
+		{
+			name:    "ensure unsupported version returns an error",
+			path:    unsupported,
+			wantErr: true,
+		},
+		{
+			name:    "ensure entry whose hash does not match its grant returns an error",
+			path:    modified,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Read(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && len(got.Approvals) != tt.wantCount {
+				t.Errorf("Read() approvals = %v, want %v", len(got.Approvals), tt.wantCount)
+			}
+		})
+	}
+}
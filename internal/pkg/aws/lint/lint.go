@@ -0,0 +1,165 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/sarif"
+)
+
+var (
+	ErrInvalidSeverity = errors.New("invalid severity")
+)
+
+// Severity represents the severity of a lint finding.
+type Severity string
+
+const (
+	SeverityHigh   Severity = "high"
+	SeverityMedium Severity = "medium"
+	SeverityLow    Severity = "low"
+)
+
+// NewSeverity returns a severity from its string representation.
+func NewSeverity(severity string) (Severity, error) {
+	switch Severity(severity) {
+	case SeverityHigh, SeverityMedium, SeverityLow:
+		return Severity(severity), nil
+	default:
+		return "", fmt.Errorf("%w [%s] - must be one of [%s, %s, %s]", ErrInvalidSeverity, severity, SeverityHigh, SeverityMedium, SeverityLow)
+	}
+}
+
+// AtLeast returns whether or not a severity is at least as severe as another severity.
+func (severity Severity) AtLeast(other Severity) bool {
+	return severity.rank() >= other.rank()
+}
+
+// Level returns the SARIF level of a severity.
+func (severity Severity) Level() string {
+	switch severity {
+	case SeverityHigh:
+		return sarif.LevelError
+	case SeverityMedium:
+		return sarif.LevelWarning
+	default:
+		return sarif.LevelNote
+	}
+}
+
+// rank returns the rank of a severity where a higher rank is more severe.
+func (severity Severity) rank() int {
+	switch severity {
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Finding represents a marker which violates a lint rule.
+type Finding struct {
+	Rule     string            `json:"rule"`
+	Severity Severity          `json:"severity"`
+	Name     string            `json:"name"`
+	Action   string            `json:"action"`
+	Resource string            `json:"resource"`
+	Message  string            `json:"message"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Source   *policy.Source    `json:"source"`
+}
+
+// Report represents the result of linting a set of markers.
+type Report struct {
+	Markers    int        `json:"markers"`
+	Findings   []*Finding `json:"findings"`
+	Suppressed int        `json:"suppressed"`
+}
+
+// NewReport lints a set of markers with the built-in rules.  Each rule checks the markers of each
+// policy separately.  Findings for a marker which ignores the rule are suppressed and only
+// counted.
+func NewReport(policyMarkers []policy.Marker) (*Report, error) {
+	policies := map[string][]*aws.Marker{}
+	names := []string{}
+
+	for i := range policyMarkers {
+		marker, ok := policyMarkers[i].(*aws.Marker)
+		if !ok {
+			return nil, aws.ErrMarkerConvert
+		}
+
+		if _, found := policies[marker.GetName()]; !found {
+			names = append(names, marker.GetName())
+		}
+
+		policies[marker.GetName()] = append(policies[marker.GetName()], marker)
+	}
+
+	sort.Strings(names)
+
+	report := &Report{
+		Markers:  len(policyMarkers),
+		Findings: []*Finding{},
+	}
+
+	for _, name := range names {
+		for _, rule := range Rules() {
+			violations, err := rule.check(policies[name])
+			if err != nil {
+				return nil, fmt.Errorf("unable to check rule [%s] for policy [%s] - %w", rule.ID, name, err)
+			}
+
+			for _, violation := range violations {
+				if violation.marker.Ignores(rule.ID) {
+					report.Suppressed++
+
+					continue
+				}
+
+				report.Findings = append(report.Findings, &Finding{
+					Rule:     rule.ID,
+					Severity: rule.Severity,
+					Name:     name,
+					Action:   violation.marker.PermissionColumn(),
+					Resource: violation.marker.ResourceColumn(),
+					Message:  violation.message,
+					Metadata: violation.marker.GetMetadata(),
+					Source:   violation.marker.GetSource(),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Severity.rank() > report.Findings[j].Severity.rank()
+	})
+
+	return report, nil
+}
+
+// HasFindings returns whether or not the report contains findings which are at least as severe
+// as the given severity.
+func (report *Report) HasFindings(severity Severity) bool {
+	return report.Count(severity) > 0
+}
+
+// Count returns the number of findings which are at least as severe as the given severity.
+func (report *Report) Count(severity Severity) int {
+	count := 0
+
+	for _, finding := range report.Findings {
+		if finding.Severity.AtLeast(severity) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,382 @@
+package lint
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/sarif"
+)
+
+func TestNewReport(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		markers        []policy.Marker
+		wantRules      []string
+		wantSuppressed int
+	}{
+		{
+			name: "ensure narrow read markers have no findings",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:GetObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("ec2:DescribeVpcs"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("*"),
+				},
+			},
+			wantRules: []string{},
+		},
+		{
+			name: "ensure service wildcard is reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:*"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				},
+			},
+			wantRules: []string{RuleServiceWildcard},
+		},
+		{
+			name: "ensure write action on every resource is reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:PutObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("*"),
+				},
+			},
+			wantRules: []string{RuleWriteWildcardResource},
+		},
+		{
+			name: "ensure write action on every resource with a condition is not reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:              pointers.String("test"),
+					Action:            pointers.String("s3:PutObject"),
+					Effect:            pointers.String(aws.ValidEffectAllow),
+					Resource:          pointers.String("*"),
+					ConditionKey:      pointers.String("aws:ResourceTag/managed"),
+					ConditionValue:    pointers.String("true"),
+					ConditionOperator: pointers.String("StringEquals"),
+				},
+			},
+			wantRules: []string{},
+		},
+		{
+			name: "ensure allow shadowed by a deny is reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:GetObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::secrets/key"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:Get*"),
+					Effect:   pointers.String(aws.ValidEffectDeny),
+					Resource: pointers.String("arn:aws:s3:::secrets/*"),
+				},
+			},
+			wantRules: []string{RuleShadowedAllow},
+		},
+		{
+			name: "ensure allow shadowed by a deny in another policy is not reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:GetObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::secrets/key"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("other"),
+					Action:   pointers.String("s3:Get*"),
+					Effect:   pointers.String(aws.ValidEffectDeny),
+					Resource: pointers.String("arn:aws:s3:::secrets/*"),
+				},
+			},
+			wantRules: []string{},
+		},
+		{
+			name: "ensure pass role without a passed to service condition is reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("iam:PassRole"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:iam::123456789012:role/app"),
+				},
+			},
+			wantRules: []string{RulePassRoleUnrestricted},
+		},
+		{
+			name: "ensure pass role with a passed to service condition is not reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:              pointers.String("test"),
+					Action:            pointers.String("iam:PassRole"),
+					Effect:            pointers.String(aws.ValidEffectAllow),
+					Resource:          pointers.String("arn:aws:iam::123456789012:role/app"),
+					ConditionKey:      pointers.String("iam:PassedToService"),
+					ConditionValue:    pointers.String("ec2.amazonaws.com"),
+					ConditionOperator: pointers.String("StringEquals"),
+				},
+			},
+			wantRules: []string{},
+		},
+		{
+			name: "ensure privilege escalation combination is reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("iam:UpdateAssumeRolePolicy"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:iam::123456789012:role/app"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("sts:AssumeRole"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:iam::123456789012:role/app"),
+				},
+			},
+			wantRules: []string{RulePrivilegeEscalation},
+		},
+		{
+			name: "ensure partial privilege escalation combination is not reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("iam:UpdateAssumeRolePolicy"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:iam::123456789012:role/app"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("other"),
+					Action:   pointers.String("sts:AssumeRole"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:iam::123456789012:role/app"),
+				},
+			},
+			wantRules: []string{},
+		},
+		{
+			name: "ensure marker covered by a broader marker is reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:GetObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/key"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:Get*"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				},
+			},
+			wantRules: []string{RuleRedundantStatement},
+		},
+		{
+			name: "ensure marker covered by a broader marker with a different condition is not reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:              pointers.String("test"),
+					Action:            pointers.String("s3:GetObject"),
+					Effect:            pointers.String(aws.ValidEffectAllow),
+					Resource:          pointers.String("arn:aws:s3:::bucket/key"),
+					ConditionOperator: pointers.String(conditions.StringEqualsOperator),
+					ConditionKey:      pointers.String("aws:SourceArn"),
+					ConditionValue:    pointers.String("arn:aws:sns:us-east-1:123456789012:topic"),
+				},
+				&aws.Marker{
+					Name:              pointers.String("test"),
+					Action:            pointers.String("s3:Get*"),
+					Effect:            pointers.String(aws.ValidEffectAllow),
+					Resource:          pointers.String("arn:aws:s3:::bucket/*"),
+					ConditionOperator: pointers.String(conditions.ArnEqualsOperator),
+					ConditionKey:      pointers.String("aws:SourceArn"),
+					ConditionValue:    pointers.String("arn:aws:sns:us-east-1:123456789012:topic"),
+				},
+			},
+			wantRules: []string{},
+		},
+		{
+			name: "ensure identical markers are not reported",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:GetObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:getobject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				},
+			},
+			wantRules: []string{},
+		},
+		{
+			name: "ensure ignored rules are suppressed",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:*"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("*"),
+					Ignore:   pointers.String("service-wildcard, Write-Wildcard-Resource"),
+				},
+			},
+			wantRules:      []string{},
+			wantSuppressed: 2,
+		},
+		{
+			name: "ensure findings are ordered by severity",
+			markers: []policy.Marker{
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:GetObject"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/key"),
+				},
+				&aws.Marker{
+					Name:     pointers.String("test"),
+					Action:   pointers.String("s3:*"),
+					Effect:   pointers.String(aws.ValidEffectAllow),
+					Resource: pointers.String("arn:aws:s3:::bucket/*"),
+				},
+			},
+			wantRules: []string{RuleServiceWildcard, RuleRedundantStatement},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewReport(tt.markers)
+			if err != nil {
+				t.Fatalf("NewReport() error = %v", err)
+			}
+
+			gotRules := make([]string, len(got.Findings))
+			for i := range got.Findings {
+				gotRules[i] = got.Findings[i].Rule
+			}
+
+			if !reflect.DeepEqual(gotRules, tt.wantRules) {
+				t.Errorf("NewReport() rules = %v, want %v", gotRules, tt.wantRules)
+			}
+
+			if got.Suppressed != tt.wantSuppressed {
+				t.Errorf("NewReport() suppressed = %v, want %v", got.Suppressed, tt.wantSuppressed)
+			}
+		})
+	}
+}
+
+func TestReport_Count(t *testing.T) {
+	t.Parallel()
+
+	report := &Report{
+		Findings: []*Finding{
+			{Severity: SeverityHigh},
+			{Severity: SeverityMedium},
+			{Severity: SeverityLow},
+			{Severity: SeverityLow},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		severity Severity
+		want     int
+	}{
+		{name: "ensure high counts only high findings", severity: SeverityHigh, want: 1},
+		{name: "ensure medium counts medium and high findings", severity: SeverityMedium, want: 2},
+		{name: "ensure low counts every finding", severity: SeverityLow, want: 4},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := report.Count(tt.severity); got != tt.want {
+				t.Errorf("Report.Count() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_SARIF(t *testing.T) {
+	t.Parallel()
+
+	report := &Report{
+		Findings: []*Finding{
+			{
+				Rule:     RuleServiceWildcard,
+				Severity: SeverityHigh,
+				Message:  "test message",
+				Metadata: map[string]string{"owner": "platform"},
+				Source:   &policy.Source{File: "lint.go", Line: 3},
+			},
+			{
+				Rule:     RuleRedundantStatement,
+				Severity: SeverityLow,
+				Message:  "test message",
+			},
+		},
+	}
+
+	data, err := report.SARIF()
+	if err != nil {
+		t.Fatalf("Report.SARIF() error = %v", err)
+	}
+
+	log := &sarif.Log{}
+	if err := json.Unmarshal(data, log); err != nil {
+		t.Fatalf("Report.SARIF() returned invalid json - %v", err)
+	}
+
+	want := []sarif.Result{
+		sarif.NewResult(RuleServiceWildcard, SeverityHigh.Level(), "test message", "lint.go", 3),
+		sarif.NewResult(RuleRedundantStatement, SeverityLow.Level(), "test message", "", 0),
+	}
+	want[0].Properties = map[string]string{"owner": "platform"}
+
+	if !reflect.DeepEqual(log.Runs[0].Results, want) {
+		t.Errorf("Report.SARIF() results = %+v, want %+v", log.Runs[0].Results, want)
+	}
+
+	if uri := want[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "internal/pkg/aws/lint/lint.go" {
+		t.Errorf("Report.SARIF() uri = %v, want %v", uri, "internal/pkg/aws/lint/lint.go")
+	}
+
+	if got, want := len(log.Runs[0].Tool.Driver.Rules), len(Rules()); got != want {
+		t.Errorf("Report.SARIF() rules = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,105 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/scottd018/policy-gen/internal/pkg/sarif"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Render renders a report in the given format.
+func (report *Report) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatTable:
+		return report.Table(), nil
+	case FormatJSON:
+		return report.JSON()
+	case FormatSARIF:
+		return report.SARIF()
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s, %s]", ErrInvalidFormat, format, FormatTable, FormatJSON, FormatSARIF)
+	}
+}
+
+// Table renders a report as a human-readable table.
+func (report *Report) Table() []byte {
+	tableBytes := &bytes.Buffer{}
+
+	table := tablewriter.NewWriter(tableBytes)
+	table.SetHeader([]string{"severity", "rule", "policy", "message", "source"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+
+	for _, finding := range report.Findings {
+		table.Append([]string{string(finding.Severity), finding.Rule, finding.Name, finding.Message, finding.Source.String()})
+	}
+
+	table.Render()
+
+	fmt.Fprintf(
+		tableBytes,
+		"\nmarkers: %d, findings: %d (high: %d, medium: %d, low: %d), suppressed: %d\n",
+		report.Markers,
+		len(report.Findings),
+		report.Count(SeverityHigh),
+		report.Count(SeverityMedium)-report.Count(SeverityHigh),
+		report.Count(SeverityLow)-report.Count(SeverityMedium),
+		report.Suppressed,
+	)
+
+	return tableBytes.Bytes()
+}
+
+// JSON renders a report as JSON.
+func (report *Report) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal lint report - %w", err)
+	}
+
+	return data, nil
+}
+
+// SARIF renders a report as a SARIF 2.1.0 log.  The rule id of each result is the id which
+// suppresses it with the ignore field of a marker.
+func (report *Report) SARIF() ([]byte, error) {
+	results := make([]sarif.Result, len(report.Findings))
+
+	for i, finding := range report.Findings {
+		var (
+			file string
+			line int
+		)
+
+		if finding.Source != nil {
+			file, line = finding.Source.File, finding.Source.Line
+		}
+
+		results[i] = sarif.NewResult(finding.Rule, finding.Severity.Level(), finding.Message, file, line)
+		results[i].Properties = finding.Metadata
+	}
+
+	lintRules := Rules()
+	rules := make([]sarif.Rule, len(lintRules))
+
+	for i, rule := range lintRules {
+		rules[i] = sarif.NewRule(rule.ID, rule.Description, rule.Severity.Level())
+	}
+
+	return sarif.NewLog(rules, results).Marshal()
+}
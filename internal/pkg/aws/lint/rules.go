@@ -0,0 +1,348 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+)
+
+const (
+	RuleServiceWildcard       = "service-wildcard"
+	RuleWriteWildcardResource = "write-wildcard-resource"
+	RuleShadowedAllow         = "shadowed-allow"
+	RulePassRoleUnrestricted  = "passrole-unrestricted"
+	RulePrivilegeEscalation   = "privilege-escalation"
+	RuleRedundantStatement    = "redundant-statement"
+
+	conditionKeyPassedToService = "iam:PassedToService"
+	actionPassRole              = "iam:PassRole"
+)
+
+// Rule represents a lint rule which checks the markers of a single policy.
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+
+	check func(markers []*aws.Marker) ([]*violation, error)
+}
+
+// violation represents a marker which violates a rule.
+type violation struct {
+	marker  *aws.Marker
+	message string
+}
+
+// Rules returns the built-in set of lint rules.
+func Rules() []Rule {
+	return []Rule{
+		{
+			ID:          RuleServiceWildcard,
+			Severity:    SeverityHigh,
+			Description: "Allow marker granting every action of a service, or of every service",
+			check:       checkServiceWildcard,
+		},
+		{
+			ID:          RuleWriteWildcardResource,
+			Severity:    SeverityMedium,
+			Description: "Allow marker granting a write action on every resource without a condition",
+			check:       checkWriteWildcardResource,
+		},
+		{
+			ID:          RuleShadowedAllow,
+			Severity:    SeverityMedium,
+			Description: "Allow marker which is entirely overridden by a Deny marker in the same policy",
+			check:       checkShadowedAllow,
+		},
+		{
+			ID:          RulePassRoleUnrestricted,
+			Severity:    SeverityHigh,
+			Description: "Allow marker granting iam:PassRole without an iam:PassedToService condition",
+			check:       checkPassRoleUnrestricted,
+		},
+		{
+			ID:          RulePrivilegeEscalation,
+			Severity:    SeverityHigh,
+			Description: "Policy granting a combination of actions known to allow privilege escalation",
+			check:       checkPrivilegeEscalation,
+		},
+		{
+			ID:          RuleRedundantStatement,
+			Severity:    SeverityLow,
+			Description: "Marker which is entirely covered by a broader marker in the same policy",
+			check:       checkRedundantStatement,
+		},
+	}
+}
+
+// escalations are the combinations of actions which are known to allow a principal to escalate
+// its own privileges.
+var escalations = [][]string{
+	{"iam:CreatePolicyVersion"},
+	{"iam:SetDefaultPolicyVersion"},
+	{"iam:AttachUserPolicy"},
+	{"iam:AttachGroupPolicy"},
+	{"iam:AttachRolePolicy"},
+	{"iam:PutUserPolicy"},
+	{"iam:PutGroupPolicy"},
+	{"iam:PutRolePolicy"},
+	{"iam:CreateAccessKey"},
+	{"iam:CreateLoginProfile"},
+	{"iam:UpdateLoginProfile"},
+	{"iam:AddUserToGroup"},
+	{"iam:UpdateAssumeRolePolicy", "sts:AssumeRole"},
+	{"iam:PassRole", "ec2:RunInstances"},
+	{"iam:PassRole", "lambda:CreateFunction", "lambda:InvokeFunction"},
+	{"iam:PassRole", "lambda:CreateFunction", "lambda:CreateEventSourceMapping"},
+	{"iam:PassRole", "glue:CreateDevEndpoint"},
+	{"iam:PassRole", "cloudformation:CreateStack"},
+	{"iam:PassRole", "datapipeline:CreatePipeline"},
+	{"lambda:UpdateFunctionCode"},
+	{"glue:UpdateDevEndpoint"},
+}
+
+// checkServiceWildcard reports allow markers whose action is a wildcard for an entire service.
+func checkServiceWildcard(markers []*aws.Marker) ([]*violation, error) {
+	violations := []*violation{}
+
+	for _, marker := range allows(markers) {
+		action := marker.PermissionColumn()
+
+		_, name, _ := strings.Cut(action, ":")
+		if action != "*" && strings.Trim(name, "*") != "" {
+			continue
+		}
+
+		violations = append(violations, &violation{
+			marker:  marker,
+			message: fmt.Sprintf("action [%s] grants every action of %s", action, serviceDescription(action)),
+		})
+	}
+
+	return violations, nil
+}
+
+// checkWriteWildcardResource reports allow markers which grant a write action on every resource
+// without a condition to narrow it.
+func checkWriteWildcardResource(markers []*aws.Marker) ([]*violation, error) {
+	violations := []*violation{}
+
+	for _, marker := range allows(markers) {
+		if marker.ResourceColumn() != "*" || marker.Condition() != nil {
+			continue
+		}
+
+		write, err := aws.IsWriteAction(marker.PermissionColumn())
+		if err != nil {
+			return nil, err
+		}
+
+		if !write {
+			continue
+		}
+
+		violations = append(violations, &violation{
+			marker:  marker,
+			message: fmt.Sprintf("write action [%s] is granted on every resource", marker.PermissionColumn()),
+		})
+	}
+
+	return violations, nil
+}
+
+// checkShadowedAllow reports allow markers which are entirely covered by an unconditional deny
+// marker, and so never grant anything.
+func checkShadowedAllow(markers []*aws.Marker) ([]*violation, error) {
+	violations := []*violation{}
+
+	for _, marker := range allows(markers) {
+		for _, deny := range markers {
+			if deny.EffectColumn() != aws.ValidEffectDeny || deny.Condition() != nil || !covers(deny, marker) {
+				continue
+			}
+
+			violations = append(violations, &violation{
+				marker: marker,
+				message: fmt.Sprintf(
+					"action [%s] on resource [%s] is always denied by the marker at [%s]",
+					marker.PermissionColumn(),
+					marker.ResourceColumn(),
+					deny.GetSource(),
+				),
+			})
+
+			break
+		}
+	}
+
+	return violations, nil
+}
+
+// checkPassRoleUnrestricted reports allow markers which grant iam:PassRole without restricting
+// the services to which a role may be passed.
+func checkPassRoleUnrestricted(markers []*aws.Marker) ([]*violation, error) {
+	violations := []*violation{}
+
+	for _, marker := range allows(markers) {
+		if !aws.MatchAction(marker.PermissionColumn(), actionPassRole) {
+			continue
+		}
+
+		if marker.HasConditionKey() && strings.EqualFold(*marker.ConditionKey, conditionKeyPassedToService) {
+			continue
+		}
+
+		violations = append(violations, &violation{
+			marker: marker,
+			message: fmt.Sprintf(
+				"action [%s] grants %s without a [%s] condition",
+				marker.PermissionColumn(),
+				actionPassRole,
+				conditionKeyPassedToService,
+			),
+		})
+	}
+
+	return violations, nil
+}
+
+// checkPrivilegeEscalation reports combinations of actions which are known to allow privilege
+// escalation when every action of the combination is granted by the policy.  Each combination
+// is reported at the marker which grants its first action, and combinations reported at the same
+// marker are reported together.
+func checkPrivilegeEscalation(markers []*aws.Marker) ([]*violation, error) {
+	allowMarkers := allows(markers)
+	combinations := map[*aws.Marker][]string{}
+	order := []*aws.Marker{}
+
+	for _, escalation := range escalations {
+		var first *aws.Marker
+
+		granted := true
+
+		for i, action := range escalation {
+			marker := granting(allowMarkers, action)
+			if marker == nil {
+				granted = false
+
+				break
+			}
+
+			if i == 0 {
+				first = marker
+			}
+		}
+
+		if !granted {
+			continue
+		}
+
+		if _, found := combinations[first]; !found {
+			order = append(order, first)
+		}
+
+		combinations[first] = append(combinations[first], strings.Join(escalation, " + "))
+	}
+
+	violations := make([]*violation, 0, len(order))
+
+	for _, marker := range order {
+		violations = append(violations, &violation{
+			marker: marker,
+			message: fmt.Sprintf(
+				"action [%s] allows privilege escalation through [%s]",
+				marker.PermissionColumn(),
+				strings.Join(combinations[marker], ", "),
+			),
+		})
+	}
+
+	return violations, nil
+}
+
+// checkRedundantStatement reports markers which are entirely covered by a broader marker with
+// the same effect.  Identical markers are not reported as they are merged into the same
+// statement when the policy is generated.
+func checkRedundantStatement(markers []*aws.Marker) ([]*violation, error) {
+	violations := []*violation{}
+
+	for _, marker := range markers {
+		for _, other := range markers {
+			if other == marker || other.EffectColumn() != marker.EffectColumn() || identical(other, marker) {
+				continue
+			}
+
+			if other.Condition() != nil && other.RawConditionColumn() != marker.RawConditionColumn() {
+				continue
+			}
+
+			if !covers(other, marker) {
+				continue
+			}
+
+			violations = append(violations, &violation{
+				marker: marker,
+				message: fmt.Sprintf(
+					"action [%s] on resource [%s] is already granted by the marker at [%s]",
+					marker.PermissionColumn(),
+					marker.ResourceColumn(),
+					other.GetSource(),
+				),
+			})
+
+			break
+		}
+	}
+
+	return violations, nil
+}
+
+// allows returns the markers which allow their action.
+func allows(markers []*aws.Marker) []*aws.Marker {
+	allowMarkers := []*aws.Marker{}
+
+	for _, marker := range markers {
+		if marker.EffectColumn() == aws.ValidEffectAllow {
+			allowMarkers = append(allowMarkers, marker)
+		}
+	}
+
+	return allowMarkers
+}
+
+// granting returns the first marker in a set of markers which grants an action, or nil if no
+// marker grants it.
+func granting(markers []*aws.Marker, action string) *aws.Marker {
+	for _, marker := range markers {
+		if aws.MatchAction(marker.PermissionColumn(), action) {
+			return marker
+		}
+	}
+
+	return nil
+}
+
+// covers determines whether the action and resource of a marker cover the action and resource of
+// another marker.
+func covers(marker, other *aws.Marker) bool {
+	return aws.MatchAction(marker.PermissionColumn(), other.PermissionColumn()) &&
+		aws.MatchResource(marker.ResourceColumn(), other.ResourceColumn())
+}
+
+// identical determines whether two markers grant exactly the same permission.
+func identical(marker, other *aws.Marker) bool {
+	return strings.EqualFold(marker.PermissionColumn(), other.PermissionColumn()) &&
+		marker.ResourceColumn() == other.ResourceColumn() &&
+		marker.RawConditionColumn() == other.RawConditionColumn()
+}
+
+// serviceDescription returns a description of the service of a wildcard action.
+func serviceDescription(action string) string {
+	service := aws.ServiceFor(action)
+	if service == "" || service == "*" {
+		return "every service"
+	}
+
+	return fmt.Sprintf("the [%s] service", service)
+}
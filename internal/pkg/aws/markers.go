@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/scottd018/go-utils/pkg/pointers"
@@ -23,6 +24,8 @@ var (
 	ErrMarkerInvalidConditionMissingValue    = errors.New("condition value is missing")
 	ErrMarkerInvalidConditionMissingOperator = errors.New("condition operator is missing")
 	ErrMarkerInvalidConditionOperator        = errors.New("invalid condition operator")
+	ErrMarkerInvalidExpires                  = errors.New("invalid expires - must be a date in the format YYYY-MM-DD")
+	ErrMarkerInvalidMetadata                 = errors.New("invalid metadata - must be a comma-separated list of key=value pairs")
 	ErrMarkerInvalidName                     = errors.New(
 		"invalid name - must contain only lowercase alphanumeric characters with underscores or dashes and is limited to 64 characters",
 	)
@@ -34,6 +37,9 @@ const (
 
 	ValidEffectAllow = "Allow"
 	ValidEffectDeny  = "Deny"
+
+	expiresLayout           = "2006-01-02"
+	conditionKeyCurrentTime = "aws:CurrentTime"
 )
 
 // we must not lint Id for ID here as the markers package incorrectly parses a
@@ -52,6 +58,23 @@ type Marker struct {
 	ConditionOperator *string
 	ConditionKey      *string
 	ConditionValue    *string
+
+	// lint rules to suppress for the marker, as a comma-separated list of rule ids
+	Ignore *string
+
+	// date, as YYYY-MM-DD, on which the permission expires
+	Expires *string
+
+	// user-defined metadata, as a comma-separated list of key=value pairs
+	Metadata *string
+
+	// source is the location the marker was found at.  it is unexported so that
+	// it is not parsed as a marker argument.
+	source *policy.Source
+
+	// expiryCondition determines whether the condition of the marker enforces its expiry.  it
+	// is unexported so that it is not parsed as a marker argument.
+	expiryCondition bool
 }
 
 // MarkerDefinition returns the marker definition for an AWS IAM policy marker.
@@ -108,6 +131,18 @@ func (marker *Marker) Validate() error {
 		}
 	}
 
+	// ensure the expiry date is valid if specified
+	if hasStringValue(marker.Expires) {
+		if _, err := time.Parse(expiresLayout, *marker.Expires); err != nil {
+			return fmt.Errorf("%w - [%s]", ErrMarkerInvalidExpires, *marker.Expires)
+		}
+	}
+
+	// ensure the metadata is valid if specified
+	if _, err := marker.parseMetadata(); err != nil {
+		return err
+	}
+
 	// ensure the condition is valid
 	if err := marker.ValidateCondition(); err != nil {
 		return fmt.Errorf("invalid condition specified - %w", err)
@@ -145,6 +180,73 @@ func (marker *Marker) GetName() string {
 	return *marker.Name
 }
 
+// WithName returns a copy of the marker with the given name.  It is used to satisfy the
+// policymarkers.Marker interface.
+func (marker *Marker) WithName(name string) policy.Marker {
+	renamed := *marker
+	renamed.Name = pointers.String(name)
+
+	return &renamed
+}
+
+// GetSource returns the location the marker was found at.  It is used to satisfy the
+// policymarkers.Marker interface.
+func (marker *Marker) GetSource() *policy.Source {
+	return marker.source
+}
+
+// SetSource sets the location the marker was found at.  It is used to satisfy the
+// policymarkers.Marker interface.
+func (marker *Marker) SetSource(source *policy.Source) {
+	marker.source = source
+}
+
+// String returns the marker text which, when parsed, results in the marker.  Only fields which
+// are set are included.
+func (marker *Marker) String() string {
+	fields := []string{}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{name: "name", value: marker.Name},
+		{name: "id", value: marker.Id},
+		{name: "action", value: marker.Action},
+		{name: "effect", value: marker.Effect},
+		{name: "resource", value: marker.Resource},
+		{name: "reason", value: marker.Reason},
+		{name: "conditionOperator", value: marker.ConditionOperator},
+		{name: "conditionKey", value: marker.ConditionKey},
+		{name: "conditionValue", value: marker.ConditionValue},
+		{name: "ignore", value: marker.Ignore},
+		{name: "expires", value: marker.Expires},
+		{name: "metadata", value: marker.Metadata},
+	} {
+		if hasStringValue(field.value) {
+			// backticks delimit values, so they may not be used within a value
+			fields = append(fields, fmt.Sprintf("%s=`%s`", field.name, strings.ReplaceAll(*field.value, "`", "'")))
+		}
+	}
+
+	return fmt.Sprintf("%s:%s", MarkerDefinition(), strings.Join(fields, ","))
+}
+
+// Ignores returns whether or not the marker suppresses the lint rule with the given id.
+func (marker *Marker) Ignores(ruleID string) bool {
+	if !hasStringValue(marker.Ignore) {
+		return false
+	}
+
+	for _, ignored := range strings.Split(*marker.Ignore, ",") {
+		if strings.EqualFold(strings.TrimSpace(ignored), ruleID) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ToStatement converts a marker to an AWS IAM policy statement.
 func (marker Marker) ToStatement() Statement {
 	return Statement{
@@ -156,13 +258,71 @@ func (marker Marker) ToStatement() Statement {
 	}
 }
 
-// Condition returns the condition for a given marker.
+// Condition returns the condition for a given marker.  If the marker enforces its expiry, the
+// condition also requires that the current time is before the expiry.  A condition of the marker
+// which already requires that the current time is before an earlier time is kept, so that
+// enforcing the expiry never extends a permission.
 func (marker *Marker) Condition() *conditions.Condition {
+	var condition *conditions.Condition
+
 	if marker.HasConditionKey() && marker.HasConditionValue() && marker.HasConditionOperator() {
-		return conditions.NewCondition(*marker.ConditionKey, *marker.ConditionValue, *marker.ConditionOperator)
+		condition = conditions.NewCondition(*marker.ConditionKey, *marker.ConditionValue, *marker.ConditionOperator)
 	}
 
-	return nil
+	expiresAt := marker.ExpiresAt()
+	if !marker.expiryCondition || expiresAt == nil {
+		return condition
+	}
+
+	if condition == nil {
+		condition = &conditions.Condition{}
+	}
+
+	if condition.DateLessThan == nil {
+		condition.DateLessThan = conditions.Operator{}
+	}
+
+	if existing, found := condition.DateLessThan[conditionKeyCurrentTime]; found {
+		if before, ok := conditions.ParseDate(existing); ok && before.Before(*expiresAt) {
+			return condition
+		}
+	}
+
+	condition.DateLessThan[conditionKeyCurrentTime] = expiresAt.Format(time.RFC3339)
+
+	return condition
+}
+
+// ExpiresAt returns the time at which the permission expires, or nil if the permission does not
+// expire.  A permission is granted through the whole of its expiry date, so it expires at the
+// start of the following day in UTC.
+func (marker *Marker) ExpiresAt() *time.Time {
+	if !hasStringValue(marker.Expires) {
+		return nil
+	}
+
+	expiresOn, err := time.Parse(expiresLayout, *marker.Expires)
+	if err != nil {
+		return nil
+	}
+
+	expiresAt := expiresOn.AddDate(0, 0, 1)
+
+	return &expiresAt
+}
+
+// Expired determines whether the permission has expired at a given time.  Permissions which do
+// not expire never expire.
+func (marker *Marker) Expired(now time.Time) bool {
+	expiresAt := marker.ExpiresAt()
+
+	return expiresAt != nil && !now.Before(*expiresAt)
+}
+
+// WithExpiryCondition sets the marker so that its condition enforces its expiry with a
+// DateLessThan condition on aws:CurrentTime.  It has no effect on markers which do not expire.
+func (marker *Marker) WithExpiryCondition() {
+	marker.expiryCondition = true
 }
 
 // EffectColumn returns the effect for the marker.  It is used to satisfy
@@ -205,10 +365,15 @@ func (marker *Marker) ReasonColumn() string {
 	return *marker.Reason
 }
 
-// ConditionColumn returns the conditions for the permission, represented in a comma-separated list.
-// It is used to satisfy the docs.Row interface.  For now, we only care about tag conditions, but this may expand
-// if more conditions are added in the future.
+// ConditionColumn returns the conditions for the permission as a human-readable expression, such
+// as aws:RequestTag/managed == "true".  It is used to satisfy the docs.Row interface.
 func (marker *Marker) ConditionColumn() string {
+	return marker.Condition().Expression()
+}
+
+// RawConditionColumn returns the conditions for the permission as they appear in the generated
+// policy, as JSON.  It is used to satisfy the docs.Row interface.
+func (marker *Marker) RawConditionColumn() string {
 	condition := marker.Condition()
 
 	if condition != nil {
@@ -218,6 +383,98 @@ func (marker *Marker) ConditionColumn() string {
 	return ""
 }
 
+// AccessLevelColumn returns the access level of the action of the permission, such as Read or
+// Permissions management, or an empty string if the catalog of access levels cannot be loaded,
+// which is reported when a processor is created.  It is used to satisfy the docs.Row interface.
+func (marker *Marker) AccessLevelColumn() string {
+	level, err := AccessLevel(marker.PermissionColumn())
+	if err != nil {
+		return ""
+	}
+
+	return level
+}
+
+// ExpiresColumn returns the date on which the permission expires.  It is used to satisfy the
+// docs.Row interface.
+func (marker *Marker) ExpiresColumn() string {
+	if marker.Expires == nil {
+		return ""
+	}
+
+	return *marker.Expires
+}
+
+// MetadataColumn returns the value of a user-defined metadata key for the permission.  It is used
+// to satisfy the docs.Row interface.
+func (marker *Marker) MetadataColumn(key string) string {
+	return marker.GetMetadata()[key]
+}
+
+// GetMetadata returns the user-defined metadata of the marker.  Invalid metadata is ignored, as
+// it is rejected when the marker is validated.  It is used to satisfy the policymarkers.Marker
+// interface.
+func (marker *Marker) GetMetadata() map[string]string {
+	metadata, err := marker.parseMetadata()
+	if err != nil {
+		return map[string]string{}
+	}
+
+	return metadata
+}
+
+// parseMetadata parses the user-defined metadata of the marker from its comma-separated list of
+// key=value pairs.
+func (marker *Marker) parseMetadata() (map[string]string, error) {
+	metadata := map[string]string{}
+
+	if !hasStringValue(marker.Metadata) {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(*marker.Metadata, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+
+		if !found || key == "" {
+			return nil, fmt.Errorf("%w - [%s]", ErrMarkerInvalidMetadata, pair)
+		}
+
+		if _, exists := metadata[key]; exists {
+			return nil, fmt.Errorf("%w - duplicate key [%s]", ErrMarkerInvalidMetadata, key)
+		}
+
+		metadata[key] = strings.TrimSpace(value)
+	}
+
+	return metadata, nil
+}
+
+// UsedByColumn returns the code which needs the permission.  For Go source code, this is the
+// function or method which encloses the marker, otherwise it is the file and line of the marker.
+// It is used to satisfy the docs.Row interface.
+func (marker *Marker) UsedByColumn() string {
+	if symbol := marker.source.Symbol(); symbol != "" {
+		return symbol
+	}
+
+	return marker.source.String()
+}
+
+// SourceColumn returns the location of the marker, linked to the exact line if a source URL
+// is known.  It is used to satisfy the docs.Row interface.
+func (marker *Marker) SourceColumn() string {
+	if marker.source == nil {
+		return ""
+	}
+
+	if marker.source.URL != "" {
+		return fmt.Sprintf("[%s](%s)", marker.source, marker.source.URL)
+	}
+
+	return marker.source.String()
+}
+
 // AdjustID adjusts an ID for situations where a conflict arises.
 func (marker *Marker) AdjustID() {
 	// this collects the suffix integers on the current id
@@ -3,10 +3,12 @@ package aws
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/scottd018/go-utils/pkg/pointers"
 
 	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
 )
 
 func TestMarker_Definition(t *testing.T) {
@@ -272,6 +274,231 @@ func TestMarker_ReasonColumn(t *testing.T) {
 	}
 }
 
+func TestMarker_ExpiresColumn(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		Expires *string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{
+			name: "ensure marker with nil expires returns appropriately",
+			fields: fields{
+				Expires: nil,
+			},
+			want: "",
+		},
+		{
+			name: "ensure marker with non-nil expires returns appropriately",
+			fields: fields{
+				Expires: pointers.String("2026-01-31"),
+			},
+			want: "2026-01-31",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			marker := &Marker{Expires: tt.fields.Expires}
+			if got := marker.ExpiresColumn(); got != tt.want {
+				t.Errorf("Marker.ExpiresColumn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarker_MetadataColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		metadata *string
+		key      string
+		want     string
+	}{
+		{
+			name:     "ensure marker with nil metadata returns appropriately",
+			metadata: nil,
+			key:      "owner",
+			want:     "",
+		},
+		{
+			name:     "ensure marker with metadata returns the value of the key",
+			metadata: pointers.String("owner=platform, ticket=SEC-1"),
+			key:      "ticket",
+			want:     "SEC-1",
+		},
+		{
+			name:     "ensure marker without the key returns appropriately",
+			metadata: pointers.String("owner=platform"),
+			key:      "ticket",
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			marker := &Marker{Metadata: tt.metadata}
+			if got := marker.MetadataColumn(tt.key); got != tt.want {
+				t.Errorf("Marker.MetadataColumn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarker_UsedByColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		source *policy.Source
+		want   string
+	}{
+		{
+			name:   "ensure marker with nil source returns appropriately",
+			source: nil,
+			want:   "",
+		},
+		{
+			name:   "ensure marker outside of a function returns the file and line",
+			source: &policy.Source{File: "vpc.tf", Line: 10},
+			want:   "vpc.tf:10",
+		},
+		{
+			name: "ensure marker within a method returns the method",
+			source: &policy.Source{
+				File:     "vpc.go",
+				Line:     10,
+				Package:  "example.com/module/vpc",
+				Receiver: "*vpc",
+				Function: "Create",
+			},
+			want: "example.com/module/vpc.(*vpc).Create",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			marker := &Marker{source: tt.source}
+			if got := marker.UsedByColumn(); got != tt.want {
+				t.Errorf("Marker.UsedByColumn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarker_SourceColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		source *policy.Source
+		want   string
+	}{
+		{
+			name:   "ensure marker with nil source returns appropriately",
+			source: nil,
+			want:   "",
+		},
+		{
+			name:   "ensure marker without a url returns the file and line",
+			source: &policy.Source{File: "vpc.go", Line: 10},
+			want:   "vpc.go:10",
+		},
+		{
+			name:   "ensure marker with a url returns a link",
+			source: &policy.Source{File: "vpc.go", Line: 10, URL: "https://example.com/blob/main/vpc.go#L10"},
+			want:   "[vpc.go:10](https://example.com/blob/main/vpc.go#L10)",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			marker := &Marker{source: tt.source}
+			if got := marker.SourceColumn(); got != tt.want {
+				t.Errorf("Marker.SourceColumn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarker_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		marker *Marker
+		want   string
+	}{
+		{
+			name: "ensure marker with only required fields returns appropriately",
+			marker: &Marker{
+				Name:   pointers.String("test"),
+				Action: pointers.String("ec2:DescribeVpcs"),
+			},
+			want: "+policy-gen:aws:iam:policy:name=`test`,action=`ec2:DescribeVpcs`",
+		},
+		{
+			name: "ensure marker with all fields returns appropriately",
+			marker: &Marker{
+				Name:              pointers.String("test"),
+				Id:                pointers.String("Test"),
+				Action:            pointers.String("s3:GetObject"),
+				Effect:            pointers.String("Allow"),
+				Resource:          pointers.String("arn:aws:s3:::bucket/*"),
+				Reason:            pointers.String("read objects"),
+				ConditionOperator: pointers.String("StringEquals"),
+				ConditionKey:      pointers.String("aws:RequestedRegion"),
+				ConditionValue:    pointers.String("us-east-1"),
+				Ignore:            pointers.String("redundant-statement"),
+				Expires:           pointers.String("2026-01-31"),
+				Metadata:          pointers.String("owner=platform"),
+			},
+			want: "+policy-gen:aws:iam:policy:name=`test`,id=`Test`,action=`s3:GetObject`,effect=`Allow`," +
+				"resource=`arn:aws:s3:::bucket/*`,reason=`read objects`,conditionOperator=`StringEquals`," +
+				"conditionKey=`aws:RequestedRegion`,conditionValue=`us-east-1`,ignore=`redundant-statement`," +
+				"expires=`2026-01-31`,metadata=`owner=platform`",
+		},
+		{
+			name: "ensure backticks within values are replaced",
+			marker: &Marker{
+				Name:   pointers.String("test"),
+				Action: pointers.String("ec2:DescribeVpcs"),
+				Reason: pointers.String("list `vpcs`"),
+			},
+			want: "+policy-gen:aws:iam:policy:name=`test`,action=`ec2:DescribeVpcs`,reason=`list 'vpcs'`",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.marker.String(); got != tt.want {
+				t.Errorf("Marker.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestMarker_AdjustID(t *testing.T) {
 	t.Parallel()
 
@@ -339,6 +566,8 @@ func TestMarker_Validate(t *testing.T) {
 		ConditionKey      *string
 		ConditionValue    *string
 		ConditionOperator *string
+		Expires           *string
+		Metadata          *string
 	}
 
 	tests := []struct {
@@ -510,6 +739,71 @@ func TestMarker_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ensure marker with an invalid expiry date returns an error",
+			fields: fields{
+				Name:     pointers.String("test"),
+				Id:       pointers.String("TestId"),
+				Action:   pointers.String("ec2:DescribeVpcs"),
+				Effect:   pointers.String(ValidEffectAllow),
+				Resource: pointers.String("*"),
+				Reason:   pointers.String("test"),
+				Expires:  pointers.String("31/01/2026"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid marker with an expiry date returns without an error",
+			fields: fields{
+				Name:     pointers.String("test"),
+				Id:       pointers.String("TestId"),
+				Action:   pointers.String("ec2:DescribeVpcs"),
+				Effect:   pointers.String(ValidEffectAllow),
+				Resource: pointers.String("*"),
+				Reason:   pointers.String("test"),
+				Expires:  pointers.String("2026-01-31"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure marker with metadata without a value returns an error",
+			fields: fields{
+				Name:     pointers.String("test"),
+				Id:       pointers.String("TestId"),
+				Action:   pointers.String("ec2:DescribeVpcs"),
+				Effect:   pointers.String(ValidEffectAllow),
+				Resource: pointers.String("*"),
+				Reason:   pointers.String("test"),
+				Metadata: pointers.String("owner"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure marker with duplicate metadata keys returns an error",
+			fields: fields{
+				Name:     pointers.String("test"),
+				Id:       pointers.String("TestId"),
+				Action:   pointers.String("ec2:DescribeVpcs"),
+				Effect:   pointers.String(ValidEffectAllow),
+				Resource: pointers.String("*"),
+				Reason:   pointers.String("test"),
+				Metadata: pointers.String("owner=platform,owner=data"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ensure valid marker with metadata returns without an error",
+			fields: fields{
+				Name:     pointers.String("test"),
+				Id:       pointers.String("TestId"),
+				Action:   pointers.String("ec2:DescribeVpcs"),
+				Effect:   pointers.String(ValidEffectAllow),
+				Resource: pointers.String("*"),
+				Reason:   pointers.String("test"),
+				Metadata: pointers.String("owner=platform, ticket=SEC-1"),
+			},
+			wantErr: false,
+		},
 		{
 			name: "ensure valid marker without effect returns without an error",
 			fields: fields{
@@ -554,6 +848,8 @@ func TestMarker_Validate(t *testing.T) {
 				ConditionKey:      tt.fields.ConditionKey,
 				ConditionValue:    tt.fields.ConditionValue,
 				ConditionOperator: tt.fields.ConditionOperator,
+				Expires:           tt.fields.Expires,
+				Metadata:          tt.fields.Metadata,
 			}
 			if err := marker.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Marker.Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -561,3 +857,193 @@ func TestMarker_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestMarker_Condition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		marker          *Marker
+		expiryCondition bool
+		want            *conditions.Condition
+	}{
+		{
+			name:   "ensure marker without a condition returns nil",
+			marker: &Marker{},
+			want:   nil,
+		},
+		{
+			name: "ensure expiring marker without the expiry condition returns its own condition",
+			marker: &Marker{
+				ConditionOperator: pointers.String(conditions.StringEqualsOperator),
+				ConditionKey:      pointers.String("aws:RequestedRegion"),
+				ConditionValue:    pointers.String("us-east-1"),
+				Expires:           pointers.String("2026-01-31"),
+			},
+			want: &conditions.Condition{
+				StringEquals: conditions.Operator{"aws:RequestedRegion": "us-east-1"},
+			},
+		},
+		{
+			name: "ensure expiring marker with the expiry condition returns a date condition",
+			marker: &Marker{
+				Expires: pointers.String("2026-01-31"),
+			},
+			expiryCondition: true,
+			want: &conditions.Condition{
+				DateLessThan: conditions.Operator{"aws:CurrentTime": "2026-02-01T00:00:00Z"},
+			},
+		},
+		{
+			name: "ensure expiring marker with the expiry condition merges its own condition",
+			marker: &Marker{
+				ConditionOperator: pointers.String(conditions.StringEqualsOperator),
+				ConditionKey:      pointers.String("aws:RequestedRegion"),
+				ConditionValue:    pointers.String("us-east-1"),
+				Expires:           pointers.String("2026-01-31"),
+			},
+			expiryCondition: true,
+			want: &conditions.Condition{
+				StringEquals: conditions.Operator{"aws:RequestedRegion": "us-east-1"},
+				DateLessThan: conditions.Operator{"aws:CurrentTime": "2026-02-01T00:00:00Z"},
+			},
+		},
+		{
+			name: "ensure expiring marker with the expiry condition keeps an earlier current time",
+			marker: &Marker{
+				ConditionOperator: pointers.String(conditions.DateLessThanOperator),
+				ConditionKey:      pointers.String("aws:CurrentTime"),
+				ConditionValue:    pointers.String("2026-01-15T00:00:00Z"),
+				Expires:           pointers.String("2026-01-31"),
+			},
+			expiryCondition: true,
+			want: &conditions.Condition{
+				DateLessThan: conditions.Operator{"aws:CurrentTime": "2026-01-15T00:00:00Z"},
+			},
+		},
+		{
+			name: "ensure expiring marker with the expiry condition replaces a later current time",
+			marker: &Marker{
+				ConditionOperator: pointers.String(conditions.DateLessThanOperator),
+				ConditionKey:      pointers.String("aws:CurrentTime"),
+				ConditionValue:    pointers.String("2026-03-01T00:00:00Z"),
+				Expires:           pointers.String("2026-01-31"),
+			},
+			expiryCondition: true,
+			want: &conditions.Condition{
+				DateLessThan: conditions.Operator{"aws:CurrentTime": "2026-02-01T00:00:00Z"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.expiryCondition {
+				tt.marker.WithExpiryCondition()
+			}
+
+			if got := tt.marker.Condition(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Marker.Condition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarker_Expired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		marker *Marker
+		now    time.Time
+		want   bool
+	}{
+		{
+			name:   "ensure marker without an expiry does not expire",
+			marker: &Marker{},
+			now:    time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "ensure marker has not expired before its expiry date",
+			marker: &Marker{Expires: pointers.String("2026-01-31")},
+			now:    time.Date(2026, time.January, 30, 12, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "ensure marker has not expired at the start of its expiry date",
+			marker: &Marker{Expires: pointers.String("2026-01-31")},
+			now:    time.Date(2026, time.January, 31, 0, 0, 0, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "ensure marker has not expired at the end of its expiry date",
+			marker: &Marker{Expires: pointers.String("2026-01-31")},
+			now:    time.Date(2026, time.January, 31, 23, 59, 59, 0, time.UTC),
+			want:   false,
+		},
+		{
+			name:   "ensure marker has expired the day after its expiry date",
+			marker: &Marker{Expires: pointers.String("2026-01-31")},
+			now:    time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.marker.Expired(tt.now); got != tt.want {
+				t.Errorf("Marker.Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarker_ConditionColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		marker  *Marker
+		want    string
+		wantRaw string
+	}{
+		{
+			name:    "ensure marker without a condition is empty",
+			marker:  &Marker{},
+			want:    "",
+			wantRaw: "",
+		},
+		{
+			name: "ensure marker with a condition is readable",
+			marker: &Marker{
+				ConditionOperator: pointers.String("IpAddress"),
+				ConditionKey:      pointers.String("aws:SourceIp"),
+				ConditionValue:    pointers.String("10.0.0.0/8"),
+			},
+			want:    "aws:SourceIp in 10.0.0.0/8",
+			wantRaw: `{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.marker.ConditionColumn(); got != tt.want {
+				t.Errorf("Marker.ConditionColumn() = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.marker.RawConditionColumn(); got != tt.wantRaw {
+				t.Errorf("Marker.RawConditionColumn() = %v, want %v", got, tt.wantRaw)
+			}
+		})
+	}
+}
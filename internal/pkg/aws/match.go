@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws/actions"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/wildcard"
+)
+
+// MatchAction determines whether an IAM action pattern, which may contain wildcards, matches a
+// given action.  Actions are matched case-insensitively as they are in IAM.
+func MatchAction(pattern, action string) bool {
+	return wildcard.Match(strings.ToLower(pattern), strings.ToLower(action))
+}
+
+// MatchResource determines whether a resource pattern, which may contain wildcards, matches a
+// given resource.  Resources are matched case-sensitively as they are in IAM.
+func MatchResource(pattern, resource string) bool {
+	return wildcard.Match(pattern, resource)
+}
+
+// MatchesAny determines whether any of a set of patterns matches a value.
+func MatchesAny(patterns []string, value string, match func(pattern, value string) bool) bool {
+	for _, pattern := range patterns {
+		if match(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Overlaps returns a match function which matches when either of a pattern or a value, which
+// may both contain wildcards, matches the other.
+func Overlaps(match func(pattern, value string) bool) func(pattern, value string) bool {
+	return func(pattern, value string) bool {
+		return match(pattern, value) || match(value, pattern)
+	}
+}
+
+// ServiceFor returns the service prefix of an IAM action, such as "ec2" for "ec2:CreateVpc".
+func ServiceFor(action string) string {
+	service, _, found := strings.Cut(action, ":")
+	if !found {
+		return ""
+	}
+
+	return strings.ToLower(service)
+}
+
+// AccessLevel returns the access level of an action, which may contain wildcards, from the
+// embedded catalog of actions.  An error is returned if the catalog cannot be loaded.
+func AccessLevel(action string) (string, error) {
+	level, err := actions.AccessLevel(action)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine access level of action [%s] - %w", action, err)
+	}
+
+	return level, nil
+}
+
+// IsWriteAction determines whether an action, which may contain wildcards, may grant an action
+// which writes data, which is any action whose access level is more sensitive than read.
+func IsWriteAction(action string) (bool, error) {
+	level, err := AccessLevel(action)
+	if err != nil {
+		return false, err
+	}
+
+	return policy.AccessLevelRank(level) > policy.AccessLevelRank(policy.AccessLevelRead), nil
+}
@@ -0,0 +1,168 @@
+package aws
+
+import "testing"
+
+func TestMatchAction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		pattern string
+		action  string
+		want    bool
+	}{
+		{
+			name:    "ensure exact action matches",
+			pattern: "ec2:CreateVpc",
+			action:  "ec2:CreateVpc",
+			want:    true,
+		},
+		{
+			name:    "ensure action matches case-insensitively",
+			pattern: "EC2:createvpc",
+			action:  "ec2:CreateVpc",
+			want:    true,
+		},
+		{
+			name:    "ensure trailing wildcard matches",
+			pattern: "ec2:Describe*",
+			action:  "ec2:DescribeVpcs",
+			want:    true,
+		},
+		{
+			name:    "ensure single character wildcard matches",
+			pattern: "ec2:DescribeVpc?",
+			action:  "ec2:DescribeVpcs",
+			want:    true,
+		},
+		{
+			name:    "ensure wildcard in the middle matches",
+			pattern: "ec2:*Vpc*",
+			action:  "ec2:CreateVpcEndpoint",
+			want:    true,
+		},
+		{
+			name:    "ensure full wildcard matches",
+			pattern: "*",
+			action:  "iam:CreateUser",
+			want:    true,
+		},
+		{
+			name:    "ensure mismatched service does not match",
+			pattern: "ec2:*",
+			action:  "iam:CreateUser",
+			want:    false,
+		},
+		{
+			name:    "ensure prefix without wildcard does not match",
+			pattern: "ec2:Describe",
+			action:  "ec2:DescribeVpcs",
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := MatchAction(tt.pattern, tt.action); got != tt.want {
+				t.Errorf("MatchAction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchResource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		pattern  string
+		resource string
+		want     bool
+	}{
+		{
+			name:     "ensure wildcard resource matches",
+			pattern:  "arn:aws:s3:::*/keys.json",
+			resource: "arn:aws:s3:::bucket/keys.json",
+			want:     true,
+		},
+		{
+			name:     "ensure resource is matched case-sensitively",
+			pattern:  "arn:aws:s3:::*/Keys.json",
+			resource: "arn:aws:s3:::bucket/keys.json",
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := MatchResource(tt.pattern, tt.resource); got != tt.want {
+				t.Errorf("MatchResource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		patterns []string
+		value    string
+		match    func(pattern, value string) bool
+		want     bool
+	}{
+		{
+			name:     "ensure a value matching any pattern matches",
+			patterns: []string{"ec2:*", "s3:Get*"},
+			value:    "s3:GetObject",
+			match:    MatchAction,
+			want:     true,
+		},
+		{
+			name:     "ensure a value matching no pattern does not match",
+			patterns: []string{"ec2:*", "s3:Get*"},
+			value:    "s3:PutObject",
+			match:    MatchAction,
+			want:     false,
+		},
+		{
+			name:     "ensure no patterns do not match",
+			patterns: []string{},
+			value:    "s3:GetObject",
+			match:    MatchAction,
+			want:     false,
+		},
+		{
+			name:     "ensure a wildcard value matches an overlapping pattern",
+			patterns: []string{"s3:GetObject"},
+			value:    "s3:*",
+			match:    Overlaps(MatchAction),
+			want:     true,
+		},
+		{
+			name:     "ensure a wildcard value does not match without overlapping",
+			patterns: []string{"s3:GetObject"},
+			value:    "s3:*",
+			match:    MatchAction,
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := MatchesAny(tt.patterns, tt.value, tt.match); got != tt.want {
+				t.Errorf("MatchesAny() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+)
+
+var (
+	ErrUnsupportedPolicy = errors.New("unsupported policy element")
+)
+
+// stringList represents a policy element which may be either a single string or a list of
+// strings, such as the Action and Resource elements.
+type stringList []string
+
+// UnmarshalJSON unmarshals either a single string or a list of strings.
+func (list *stringList) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return json.Unmarshal(data, (*[]string)(list))
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*list = stringList{value}
+
+	return nil
+}
+
+// rawStatement represents a statement as it may be written by hand.
+type rawStatement struct {
+	SID          string                           `json:"Sid"`
+	Effect       string                           `json:"Effect"`
+	Action       stringList                       `json:"Action"`
+	NotAction    stringList                       `json:"NotAction"`
+	Resource     stringList                       `json:"Resource"`
+	NotResource  stringList                       `json:"NotResource"`
+	Principal    json.RawMessage                  `json:"Principal"`
+	NotPrincipal json.RawMessage                  `json:"NotPrincipal"`
+	Condition    map[string]map[string]stringList `json:"Condition"`
+}
+
+// rawPolicyDocument represents a policy document as it may be written by hand, where the
+// Statement element may be either a single statement or a list of statements.
+type rawPolicyDocument struct {
+	Version   string          `json:"Version"`
+	Statement json.RawMessage `json:"Statement"`
+}
+
+// StatementError represents a statement of a policy document which could not be parsed.
+type StatementError struct {
+	Index int
+	SID   string
+	Err   error
+}
+
+// Error returns the error message for a statement which could not be parsed.
+func (statementErr *StatementError) Error() string {
+	return fmt.Sprintf("invalid statement [%d] with sid [%s] - %s", statementErr.Index, statementErr.SID, statementErr.Err)
+}
+
+// Unwrap returns the reason that a statement could not be parsed.
+func (statementErr *StatementError) Unwrap() error {
+	return statementErr.Err
+}
+
+// ParsePolicyDocument parses an identity policy document, such as one written by hand or
+// retrieved from AWS, into a policy document.  Elements which may be either a string or a list
+// of strings are normalized into lists.  Elements which cannot be represented by a policy
+// document, such as Principal or condition keys with multiple values, return an error.
+func ParsePolicyDocument(data []byte) (*PolicyDocument, error) {
+	document, skipped, err := ParseSupportedPolicyDocument(data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(skipped) > 0 {
+		return nil, skipped[0]
+	}
+
+	return document, nil
+}
+
+// ParseSupportedPolicyDocument parses a policy document like ParsePolicyDocument, except that
+// statements with unsupported elements are skipped rather than returning an error.  A statement
+// is also skipped when the optional check returns an error wrapping ErrUnsupportedPolicy.  The
+// skipped statements are returned, in order, along with the reason that each was skipped.
+func ParseSupportedPolicyDocument(data []byte, check func(*Statement) error) (*PolicyDocument, []*StatementError, error) {
+	raw := &rawPolicyDocument{}
+	if err := json.Unmarshal(data, raw); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse policy document - %w", err)
+	}
+
+	rawStatements := []rawStatement{}
+
+	if bytes.HasPrefix(bytes.TrimSpace(raw.Statement), []byte("{")) {
+		rawStatements = append(rawStatements, rawStatement{})
+		if err := json.Unmarshal(raw.Statement, &rawStatements[0]); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse policy statement - %w", err)
+		}
+	} else if len(raw.Statement) > 0 {
+		if err := json.Unmarshal(raw.Statement, &rawStatements); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse policy statements - %w", err)
+		}
+	}
+
+	document := &PolicyDocument{Version: raw.Version, Statements: Statements{}}
+	if document.Version == "" {
+		document.Version = defaultVersion
+	}
+
+	skipped := []*StatementError{}
+
+	for i := range rawStatements {
+		statement, err := rawStatements[i].toStatement()
+		if err == nil && check != nil {
+			err = check(statement)
+		}
+
+		if err != nil {
+			statementErr := &StatementError{Index: i, SID: rawStatements[i].SID, Err: err}
+			if !errors.Is(err, ErrUnsupportedPolicy) {
+				return nil, nil, statementErr
+			}
+
+			skipped = append(skipped, statementErr)
+
+			continue
+		}
+
+		document.Statements = append(document.Statements, *statement)
+	}
+
+	return document, skipped, nil
+}
+
+// toStatement converts a raw statement into a statement.
+func (raw *rawStatement) toStatement() (*Statement, error) {
+	for element, unsupported := range map[string]bool{
+		"Principal":    len(raw.Principal) > 0,
+		"NotPrincipal": len(raw.NotPrincipal) > 0,
+	} {
+		if unsupported {
+			return nil, fmt.Errorf("%w [%s] - only identity policies are supported", ErrUnsupportedPolicy, element)
+		}
+	}
+
+	if raw.Effect != ValidEffectAllow && raw.Effect != ValidEffectDeny {
+		return nil, fmt.Errorf("%w [%s]", ErrMarkerInvalidEffect, raw.Effect)
+	}
+
+	if (len(raw.Action) == 0) == (len(raw.NotAction) == 0) {
+		return nil, fmt.Errorf("%w - statement must have exactly one of Action or NotAction", ErrMarkerMissingAction)
+	}
+
+	if len(raw.Resource) > 0 && len(raw.NotResource) > 0 {
+		return nil, fmt.Errorf("%w [NotResource] - statement must not have both Resource and NotResource", ErrUnsupportedPolicy)
+	}
+
+	statement := &Statement{
+		SID:          raw.SID,
+		Effect:       raw.Effect,
+		Action:       raw.Action,
+		NotAction:    raw.NotAction,
+		Resources:    raw.Resource,
+		NotResources: raw.NotResource,
+	}
+
+	if len(statement.Resources) == 0 && len(statement.NotResources) == 0 {
+		statement.Resources = []string{defaultStatementResource}
+	}
+
+	if len(raw.Condition) == 0 {
+		return statement, nil
+	}
+
+	// convert the condition into its single valued representation
+	operators := map[string]conditions.Operator{}
+
+	for operator, keys := range raw.Condition {
+		if conditions.ToOperatorString(operator) == "" {
+			return nil, fmt.Errorf("%w [Condition] - found operator [%s] - %s", ErrUnsupportedPolicy, operator, ErrMarkerInvalidConditionOperator)
+		}
+
+		operators[operator] = conditions.Operator{}
+
+		for key, values := range keys {
+			if len(values) != 1 {
+				return nil, fmt.Errorf("%w [Condition] - condition key [%s] must have exactly one value", ErrUnsupportedPolicy, key)
+			}
+
+			operators[operator][key] = values[0]
+		}
+	}
+
+	data, err := json.Marshal(operators)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert condition - %w", err)
+	}
+
+	statement.Condition = &conditions.Condition{}
+	if err := json.Unmarshal(data, statement.Condition); err != nil {
+		return nil, fmt.Errorf("unable to convert condition - %w", err)
+	}
+
+	return statement, nil
+}
@@ -0,0 +1,160 @@
+package aws
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+)
+
+func TestParsePolicyDocument(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    *PolicyDocument
+		wantErr error
+	}{
+		{
+			name: "ensure single statement with string elements is normalized",
+			data: `{"Statement": {"Sid": "Test", "Effect": "Allow", "Action": "ec2:DescribeVpcs", "Resource": "*"}}`,
+			want: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{SID: "Test", Effect: "Allow", Action: []string{"ec2:DescribeVpcs"}, Resources: []string{"*"}},
+				},
+			},
+		},
+		{
+			name: "ensure statement without a resource defaults to all resources",
+			data: `{"Version": "2012-10-17", "Statement": [{"Effect": "Deny", "Action": ["iam:*"]}]}`,
+			want: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{Effect: "Deny", Action: []string{"iam:*"}, Resources: []string{"*"}},
+				},
+			},
+		},
+		{
+			name: "ensure condition with a single value is converted",
+			data: `{"Statement": [{
+				"Sid": "Test", "Effect": "Allow", "Action": "ec2:DeleteVpc", "Resource": "*",
+				"Condition": {"StringEquals": {"aws:ResourceTag/owner": ["me"]}}
+			}]}`,
+			want: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{
+						SID:       "Test",
+						Effect:    "Allow",
+						Action:    []string{"ec2:DeleteVpc"},
+						Resources: []string{"*"},
+						Condition: conditions.NewCondition("aws:ResourceTag/owner", "me", "StringEquals"),
+					},
+				},
+			},
+		},
+		{
+			name:    "ensure condition with multiple values returns an error",
+			data:    `{"Statement": [{"Effect": "Allow", "Action": "s3:*", "Condition": {"StringEquals": {"aws:PrincipalTag/team": ["a", "b"]}}}]}`,
+			wantErr: ErrUnsupportedPolicy,
+		},
+		{
+			name:    "ensure unknown condition operator returns an error",
+			data:    `{"Statement": [{"Effect": "Allow", "Action": "s3:*", "Condition": {"Null": {"aws:TokenIssueTime": "true"}}}]}`,
+			wantErr: ErrUnsupportedPolicy,
+		},
+		{
+			name: "ensure not action and not resource are parsed",
+			data: `{"Statement": [{"Effect": "Deny", "NotAction": "iam:*", "NotResource": ["arn:aws:iam::*:role/admin"]}]}`,
+			want: &PolicyDocument{
+				Version: defaultVersion,
+				Statements: Statements{
+					{Effect: "Deny", NotAction: []string{"iam:*"}, NotResources: []string{"arn:aws:iam::*:role/admin"}},
+				},
+			},
+		},
+		{
+			name:    "ensure principal returns an error",
+			data:    `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "*"}]}`,
+			wantErr: ErrUnsupportedPolicy,
+		},
+		{
+			name:    "ensure both action and not action returns an error",
+			data:    `{"Statement": [{"Effect": "Allow", "Action": "s3:*", "NotAction": "s3:DeleteObject"}]}`,
+			wantErr: ErrMarkerMissingAction,
+		},
+		{
+			name:    "ensure invalid effect returns an error",
+			data:    `{"Statement": [{"Effect": "allow", "Action": "s3:*"}]}`,
+			wantErr: ErrMarkerInvalidEffect,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParsePolicyDocument([]byte(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParsePolicyDocument() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePolicyDocument() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSupportedPolicyDocument(t *testing.T) {
+	t.Parallel()
+
+	data := `{"Statement": [
+		{"Sid": "Multiple", "Effect": "Allow", "Action": "s3:*", "Condition": {"StringEquals": {"aws:PrincipalTag/team": ["a", "b"]}}},
+		{"Sid": "Supported", "Effect": "Allow", "Action": "s3:GetObject", "Resource": "*"},
+		{"Sid": "Not", "Effect": "Deny", "NotAction": "iam:*"}
+	]}`
+
+	notElements := func(statement *Statement) error {
+		if statement.HasNotElements() {
+			return ErrUnsupportedPolicy
+		}
+
+		return nil
+	}
+
+	got, skipped, err := ParseSupportedPolicyDocument([]byte(data), notElements)
+	if err != nil {
+		t.Fatalf("ParseSupportedPolicyDocument() error = %v", err)
+	}
+
+	want := &PolicyDocument{
+		Version: defaultVersion,
+		Statements: Statements{
+			{SID: "Supported", Effect: "Allow", Action: []string{"s3:GetObject"}, Resources: []string{"*"}},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSupportedPolicyDocument() = %v, want %v", got, want)
+	}
+
+	gotSkipped := []string{}
+	for _, statementErr := range skipped {
+		gotSkipped = append(gotSkipped, statementErr.SID)
+	}
+
+	if wantSkipped := []string{"Multiple", "Not"}; !reflect.DeepEqual(gotSkipped, wantSkipped) {
+		t.Errorf("ParseSupportedPolicyDocument() skipped = %v, want %v", gotSkipped, wantSkipped)
+	}
+
+	if _, _, err := ParseSupportedPolicyDocument([]byte(`{"Statement": [{"Effect": "allow", "Action": "s3:*"}]}`), nil); !errors.Is(err, ErrMarkerInvalidEffect) {
+		t.Errorf("ParseSupportedPolicyDocument() error = %v, wantErr %v", err, ErrMarkerInvalidEffect)
+	}
+}
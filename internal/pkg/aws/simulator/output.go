@@ -0,0 +1,72 @@
+package simulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Render renders a result in the given format.
+func (result *Result) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatText:
+		return result.Text(), nil
+	case FormatJSON:
+		return result.JSON()
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s]", ErrInvalidFormat, format, FormatText, FormatJSON)
+	}
+}
+
+// Text renders a result as human-readable text.
+func (result *Result) Text() []byte {
+	text := &bytes.Buffer{}
+
+	fmt.Fprintf(text, "action:   %s\n", result.Request.Action)
+	fmt.Fprintf(text, "resource: %s\n", result.Request.Resource)
+
+	keys := make([]string, 0, len(result.Request.Context))
+	for key := range result.Request.Context {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(text, "context:  %s=%s\n", key, strings.Join(result.Request.Context[key], ","))
+	}
+
+	fmt.Fprintf(text, "decision: %s\n", result.Decision)
+
+	for _, matched := range result.Matched {
+		if matched.Policy != "" {
+			fmt.Fprintf(text, "  - %s by statement [%s] of policy [%s]\n", matched.Effect, matched.SID, matched.Policy)
+		} else {
+			fmt.Fprintf(text, "  - %s by statement [%s]\n", matched.Effect, matched.SID)
+		}
+	}
+
+	return text.Bytes()
+}
+
+// JSON renders a result as JSON.
+func (result *Result) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal simulation result - %w", err)
+	}
+
+	return data, nil
+}
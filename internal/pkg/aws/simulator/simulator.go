@@ -0,0 +1,131 @@
+package simulator
+
+import (
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+)
+
+// Decision represents the result of evaluating a request against a set of policies.
+type Decision string
+
+const (
+	DecisionAllowed      Decision = "allowed"
+	DecisionExplicitDeny Decision = "explicitly denied"
+	DecisionImplicitDeny Decision = "implicitly denied"
+)
+
+// Request represents a request to perform an action on a resource.
+type Request struct {
+	Action   string             `json:"action"`
+	Resource string             `json:"resource"`
+	Context  conditions.Context `json:"context,omitempty"`
+}
+
+// MatchedStatement represents a statement which matched a request.
+type MatchedStatement struct {
+	Policy string `json:"policy,omitempty"`
+	SID    string `json:"sid"`
+	Effect string `json:"effect"`
+}
+
+// Result represents the result of evaluating a request.
+type Result struct {
+	Request  *Request           `json:"request"`
+	Decision Decision           `json:"decision"`
+	Matched  []MatchedStatement `json:"matchedStatements"`
+}
+
+// Policy represents a named policy document to evaluate a request against.
+type Policy struct {
+	Name     string
+	Document *aws.PolicyDocument
+}
+
+// Allowed returns whether the request was allowed.
+func (result *Result) Allowed() bool {
+	return result.Decision == DecisionAllowed
+}
+
+// Evaluate evaluates a request against a set of identity policies as IAM does.  A request is
+// explicitly denied if any matching statement denies it, otherwise it is allowed if any matching
+// statement allows it, otherwise it is implicitly denied.  A statement matches a request when
+// its action and resource match, allowing for wildcards and NotAction or NotResource elements,
+// and its condition is satisfied by the context of the request.
+func Evaluate(request *Request, policies ...Policy) *Result {
+	result := &Result{Request: request, Decision: DecisionImplicitDeny, Matched: []MatchedStatement{}}
+
+	allowed, denied := false, false
+
+	for _, policy := range policies {
+		for i := range policy.Document.Statements {
+			statement := &policy.Document.Statements[i]
+			if !Matches(statement, request) {
+				continue
+			}
+
+			result.Matched = append(result.Matched, MatchedStatement{Policy: policy.Name, SID: statement.SID, Effect: statement.Effect})
+
+			switch statement.Effect {
+			case aws.ValidEffectDeny:
+				denied = true
+			case aws.ValidEffectAllow:
+				allowed = true
+			}
+		}
+	}
+
+	switch {
+	case denied:
+		result.Decision = DecisionExplicitDeny
+	case allowed:
+		result.Decision = DecisionAllowed
+	}
+
+	return result
+}
+
+// EvaluateDocument evaluates a request against a single policy document.
+func EvaluateDocument(request *Request, document *aws.PolicyDocument) *Result {
+	return Evaluate(request, Policy{Document: document})
+}
+
+// Matches determines whether a statement matches a request, regardless of its effect.
+func Matches(statement *aws.Statement, request *Request) bool {
+	if len(statement.Action) > 0 && !matchesAny(statement.Action, request.Action, aws.MatchAction, nil) {
+		return false
+	}
+
+	if len(statement.NotAction) > 0 && matchesAny(statement.NotAction, request.Action, aws.MatchAction, nil) {
+		return false
+	}
+
+	if len(statement.Resources) > 0 && !matchesAny(statement.Resources, request.Resource, aws.MatchResource, request.Context.Substitute) {
+		return false
+	}
+
+	if len(statement.NotResources) > 0 && matchesAny(statement.NotResources, request.Resource, aws.MatchResource, request.Context.Substitute) {
+		return false
+	}
+
+	return statement.Condition.Evaluate(request.Context)
+}
+
+// matchesAny determines whether any pattern matches a value.  If a substitute function is given,
+// such as for resources, policy variables in each pattern are substituted first, and patterns
+// with unresolved variables do not match.
+func matchesAny(patterns []string, value string, match func(pattern, value string) bool, substitute func(string) (string, bool)) bool {
+	for _, pattern := range patterns {
+		if substitute != nil {
+			var resolved bool
+			if pattern, resolved = substitute(pattern); !resolved {
+				continue
+			}
+		}
+
+		if match(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+package simulator
+
+import (
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/conditions"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	document := &aws.PolicyDocument{
+		Statements: aws.Statements{
+			{
+				SID:       "WriteManaged",
+				Effect:    aws.ValidEffectAllow,
+				Action:    []string{"s3:PutObject"},
+				Resources: []string{"arn:aws:s3:::bucket/*"},
+				Condition: conditions.NewCondition("aws:ResourceTag/managed", "true", conditions.StringEqualsOperator),
+			},
+			{
+				SID:       "Read",
+				Effect:    aws.ValidEffectAllow,
+				Action:    []string{"s3:Get*"},
+				Resources: []string{"*"},
+			},
+			{
+				SID:       "Home",
+				Effect:    aws.ValidEffectAllow,
+				Action:    []string{"s3:DeleteObject"},
+				Resources: []string{"arn:aws:s3:::bucket/home/${aws:username}/*"},
+			},
+			{
+				SID:          "ProtectSecrets",
+				Effect:       aws.ValidEffectDeny,
+				Action:       []string{"s3:*"},
+				NotResources: []string{"arn:aws:s3:::bucket/*", "arn:aws:s3:::public/*"},
+			},
+			{
+				SID:       "OnlyS3AndEC2",
+				Effect:    aws.ValidEffectDeny,
+				NotAction: []string{"s3:*", "ec2:*"},
+				Resources: []string{"*"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		request *Request
+		want    Decision
+	}{
+		{
+			name: "ensure request satisfying a condition is allowed",
+			request: &Request{
+				Action:   "s3:PutObject",
+				Resource: "arn:aws:s3:::bucket/keys.json",
+				Context:  conditions.Context{"aws:ResourceTag/managed": {"true"}},
+			},
+			want: DecisionAllowed,
+		},
+		{
+			name:    "ensure request not satisfying a condition is implicitly denied",
+			request: &Request{Action: "s3:PutObject", Resource: "arn:aws:s3:::bucket/keys.json"},
+			want:    DecisionImplicitDeny,
+		},
+		{
+			name:    "ensure request matching a wildcard action is allowed",
+			request: &Request{Action: "S3:GetObject", Resource: "arn:aws:s3:::public/file"},
+			want:    DecisionAllowed,
+		},
+		{
+			name:    "ensure explicit deny with not resource takes precedence over an allow",
+			request: &Request{Action: "s3:GetObject", Resource: "arn:aws:s3:::secrets/file"},
+			want:    DecisionExplicitDeny,
+		},
+		{
+			name:    "ensure explicit deny with not action takes precedence",
+			request: &Request{Action: "iam:CreateUser", Resource: "*"},
+			want:    DecisionExplicitDeny,
+		},
+		{
+			name: "ensure resource with a policy variable is allowed",
+			request: &Request{
+				Action:   "s3:DeleteObject",
+				Resource: "arn:aws:s3:::bucket/home/alice/file",
+				Context:  conditions.Context{"aws:username": {"alice"}},
+			},
+			want: DecisionAllowed,
+		},
+		{
+			name:    "ensure resource with an unresolved policy variable is implicitly denied",
+			request: &Request{Action: "s3:DeleteObject", Resource: "arn:aws:s3:::bucket/home/alice/file"},
+			want:    DecisionImplicitDeny,
+		},
+		{
+			name:    "ensure unmatched request is implicitly denied",
+			request: &Request{Action: "ec2:CreateVpc", Resource: "*"},
+			want:    DecisionImplicitDeny,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := EvaluateDocument(tt.request, document); got.Decision != tt.want {
+				t.Errorf("EvaluateDocument() = %v, want %v - matched %v", got.Decision, tt.want, got.Matched)
+			}
+		})
+	}
+}
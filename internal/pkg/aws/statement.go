@@ -17,11 +17,13 @@ var (
 )
 
 type Statement struct {
-	SID       string                `json:"Sid"`
-	Effect    string                `json:"Effect"`
-	Action    []string              `json:"Action"`
-	Resources []string              `json:"Resource"`
-	Condition *conditions.Condition `json:"Condition,omitempty"`
+	SID          string                `json:"Sid"`
+	Effect       string                `json:"Effect"`
+	Action       []string              `json:"Action,omitempty"`
+	NotAction    []string              `json:"NotAction,omitempty"`
+	Resources    []string              `json:"Resource,omitempty"`
+	NotResources []string              `json:"NotResource,omitempty"`
+	Condition    *conditions.Condition `json:"Condition,omitempty"`
 }
 
 type Statements []Statement
@@ -86,6 +88,13 @@ func (statement *Statement) HasCondition(condition *conditions.Condition) bool {
 	return reflect.DeepEqual(statement.Condition, condition)
 }
 
+// HasNotElements determines if a particular statement uses the NotAction or NotResource
+// elements.  Statements generated from markers never do, but statements parsed from an
+// existing policy may.
+func (statement *Statement) HasNotElements() bool {
+	return len(statement.NotAction) > 0 || len(statement.NotResources) > 0
+}
+
 // AppendAction appends an action to an existing statement.
 func (statement *Statement) AppendAction(action string) {
 	// if the statement actions are missing add them
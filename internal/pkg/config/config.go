@@ -0,0 +1,192 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/scottd018/policy-gen/internal/pkg/docs"
+	"github.com/scottd018/policy-gen/internal/pkg/justification"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+var (
+	ErrInvalidConfig      = errors.New("invalid config")
+	ErrUndeclaredMetadata = errors.New("undeclared metadata key")
+)
+
+const (
+	metadataKeyRegex = "^[a-z][a-z0-9_-]{0,63}$"
+)
+
+// File represents the project configuration file for policy-gen.
+type File struct {
+	// Metadata are the user-defined metadata keys which markers may set.  A marker which sets any
+	// other key is invalid, so that a misspelled key is not silently ignored.
+	Metadata []string `yaml:"metadata,omitempty"`
+
+	// Documentation is the configuration of the generated documentation.
+	Documentation Documentation `yaml:"documentation,omitempty"`
+
+	// Justification are the requirements for the reason of each marker.
+	Justification *justification.Rules `yaml:"justification,omitempty"`
+}
+
+// Documentation represents the configuration of the generated documentation.
+type Documentation struct {
+	// Columns are the ordered columns of the documentation table, which may be any of the
+	// built-in columns or declared metadata keys.
+	Columns []string `yaml:"columns,omitempty"`
+}
+
+// Read reads and validates a configuration file from a path.
+func Read(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file [%s] - %w", path, err)
+	}
+
+	file, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file [%s] - %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Parse parses and validates a configuration file.  Unknown fields are rejected so that a
+// misspelled setting is not silently ignored.
+func Parse(data []byte) (*File, error) {
+	file := &File{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse config - %w", err)
+	}
+
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Validate validates that the metadata keys are valid, that every documentation column is
+// either a built-in column or a declared metadata key and that the justification rules are valid.
+func (file *File) Validate() error {
+	if file.Justification != nil {
+		if err := file.Justification.Validate(); err != nil {
+			return err
+		}
+	}
+
+	keyCheck := regexp.MustCompile(metadataKeyRegex)
+	declared := map[string]bool{}
+
+	for _, key := range file.Metadata {
+		if !keyCheck.MatchString(key) {
+			return fmt.Errorf(
+				"%w - metadata key [%s] must contain only lowercase alphanumeric characters with underscores or dashes",
+				ErrInvalidConfig, key,
+			)
+		}
+
+		if docs.IsHeader(key) {
+			return fmt.Errorf("%w - metadata key [%s] conflicts with a built-in documentation column", ErrInvalidConfig, key)
+		}
+
+		if declared[key] {
+			return fmt.Errorf("%w - duplicate metadata key [%s]", ErrInvalidConfig, key)
+		}
+
+		declared[key] = true
+	}
+
+	columns := map[string]bool{}
+
+	for _, column := range file.Documentation.Columns {
+		if !docs.IsHeader(column) && !declared[column] {
+			return fmt.Errorf(
+				"%w - documentation column [%s] must be one of [%s] or a declared metadata key",
+				ErrInvalidConfig, column, strings.Join(append(docs.Header(), docs.OptionalHeader()...), ", "),
+			)
+		}
+
+		if columns[column] {
+			return fmt.Errorf("%w - duplicate documentation column [%s]", ErrInvalidConfig, column)
+		}
+
+		columns[column] = true
+	}
+
+	return nil
+}
+
+// CheckMetadata checks that every metadata key of a marker is declared.  A nil file declares no
+// metadata keys.
+func (file *File) CheckMetadata(marker policy.Marker) error {
+	declared := map[string]bool{}
+
+	if file != nil {
+		for _, key := range file.Metadata {
+			declared[key] = true
+		}
+	}
+
+	undeclared := []string{}
+
+	for key := range marker.GetMetadata() {
+		if !declared[key] {
+			undeclared = append(undeclared, key)
+		}
+	}
+
+	if len(undeclared) == 0 {
+		return nil
+	}
+
+	sort.Strings(undeclared)
+
+	return fmt.Errorf(
+		"%w [%s] - metadata keys must be declared in the config file",
+		ErrUndeclaredMetadata, strings.Join(undeclared, ", "),
+	)
+}
+
+// DocumentationColumns returns the ordered columns of the documentation table.  The built-in
+// columns are returned when no columns are configured.
+func (file *File) DocumentationColumns() []string {
+	if file == nil || len(file.Documentation.Columns) == 0 {
+		return docs.Header()
+	}
+
+	return file.Documentation.Columns
+}
+
+// MetadataKeys returns the declared metadata keys.
+func (file *File) MetadataKeys() []string {
+	if file == nil {
+		return []string{}
+	}
+
+	return file.Metadata
+}
+
+// JustificationRules returns the requirements for the reason of each marker, or the default
+// rules, which only warn about placeholder reasons, if there are none.
+func (file *File) JustificationRules() *justification.Rules {
+	if file == nil || file.Justification == nil {
+		return justification.DefaultRules()
+	}
+
+	return file.Justification
+}
@@ -0,0 +1,243 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/docs"
+	"github.com/scottd018/policy-gen/internal/pkg/justification"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "ensure valid config is parsed",
+			data: `
+metadata: [owner, ticket, since]
+documentation:
+  columns: [permission, owner, ticket, reason, "used by"]
+`,
+			wantErr: false,
+		},
+		{
+			name: "ensure optional built-in column is parsed",
+			data: `
+documentation:
+  columns: [permission, "condition json"]
+`,
+			wantErr: false,
+		},
+		{
+			name:    "ensure empty config is parsed",
+			data:    "",
+			wantErr: false,
+		},
+		{
+			name:    "ensure unknown field returns an error",
+			data:    `metadataKeys: [owner]`,
+			wantErr: true,
+		},
+		{
+			name:    "ensure invalid metadata key returns an error",
+			data:    `metadata: [Owner]`,
+			wantErr: true,
+		},
+		{
+			name:    "ensure metadata key which conflicts with a built-in column returns an error",
+			data:    `metadata: [reason]`,
+			wantErr: true,
+		},
+		{
+			name:    "ensure duplicate metadata key returns an error",
+			data:    `metadata: [owner, owner]`,
+			wantErr: true,
+		},
+		{
+			name: "ensure undeclared documentation column returns an error",
+			data: `
+documentation:
+  columns: [permission, owner]
+`,
+			wantErr: true,
+		},
+		{
+			name: "ensure duplicate documentation column returns an error",
+			data: `
+documentation:
+  columns: [permission, permission]
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Parse([]byte(tt.data)); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFile_CheckMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		file     *File
+		metadata *string
+		wantErr  error
+	}{
+		{
+			name:     "ensure marker without metadata is valid without a config file",
+			file:     nil,
+			metadata: nil,
+			wantErr:  nil,
+		},
+		{
+			name:     "ensure marker with metadata is invalid without a config file",
+			file:     nil,
+			metadata: pointers.String("owner=platform"),
+			wantErr:  ErrUndeclaredMetadata,
+		},
+		{
+			name:     "ensure marker with declared metadata is valid",
+			file:     &File{Metadata: []string{"owner", "ticket"}},
+			metadata: pointers.String("owner=platform,ticket=SEC-1"),
+			wantErr:  nil,
+		},
+		{
+			name:     "ensure marker with undeclared metadata is invalid",
+			file:     &File{Metadata: []string{"owner"}},
+			metadata: pointers.String("owner=platform,tciket=SEC-1"),
+			wantErr:  ErrUndeclaredMetadata,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			marker := &aws.Marker{Name: pointers.String("test"), Action: pointers.String("s3:GetObject"), Metadata: tt.metadata}
+			if err := tt.file.CheckMetadata(marker); !errors.Is(err, tt.wantErr) {
+				t.Errorf("File.CheckMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFile_DocumentationColumns(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		file *File
+		want []string
+	}{
+		{
+			name: "ensure nil config returns the built-in columns",
+			file: nil,
+			want: docs.Header(),
+		},
+		{
+			name: "ensure config without columns returns the built-in columns",
+			file: &File{Metadata: []string{"owner"}},
+			want: docs.Header(),
+		},
+		{
+			name: "ensure config with columns returns the columns in order",
+			file: &File{
+				Metadata:      []string{"owner"},
+				Documentation: Documentation{Columns: []string{docs.HeaderPermission, "owner", docs.HeaderReason}},
+			},
+			want: []string{docs.HeaderPermission, "owner", docs.HeaderReason},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.file.DocumentationColumns(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("File.DocumentationColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFile_JustificationRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		data             string
+		wantPlaceholders []string
+		wantEnforced     bool
+	}{
+		{
+			name:             "ensure config without justification warns about the default placeholders",
+			data:             `metadata: [owner]`,
+			wantPlaceholders: justification.DefaultPlaceholders(),
+			wantEnforced:     false,
+		},
+		{
+			name: "ensure justification without placeholders rejects the default placeholders",
+			data: `
+justification:
+  requireReason: write
+`,
+			wantPlaceholders: justification.DefaultPlaceholders(),
+			wantEnforced:     true,
+		},
+		{
+			name: "ensure justification with empty placeholders allows any word",
+			data: `
+justification:
+  placeholders: []
+`,
+			wantPlaceholders: []string{},
+			wantEnforced:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			file, err := Parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			rules := file.JustificationRules()
+
+			if !reflect.DeepEqual(rules.Placeholders, tt.wantPlaceholders) {
+				t.Errorf("File.JustificationRules() placeholders = %v, want %v", rules.Placeholders, tt.wantPlaceholders)
+			}
+
+			if rules.Enforced() != tt.wantEnforced {
+				t.Errorf("File.JustificationRules() enforced = %v, want %v", rules.Enforced(), tt.wantEnforced)
+			}
+		})
+	}
+
+	if rules := (*File)(nil).JustificationRules(); rules.Enforced() || rules.Check(&aws.Marker{Reason: pointers.String("TODO")}) == nil {
+		t.Errorf("File.JustificationRules() of a nil config must warn about placeholder reasons")
+	}
+}
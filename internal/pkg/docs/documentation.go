@@ -2,7 +2,15 @@ package docs
 
 import (
 	"bytes"
+	_ "embed"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
 
 	"github.com/olekukonko/tablewriter"
 
@@ -11,49 +19,183 @@ import (
 
 var (
 	ErrMissingFilename = errors.New("missing documentation file name")
+	ErrInvalidLayout   = errors.New("invalid documentation layout")
 )
 
 const (
-	DocumentationMarkdownHeader  = "# Policy Justification\n\nThis file contains justification for access policies needed by this project.\n\n"
 	DocumentationFilePermissions = 0600
+
+	LayoutFlat    = "flat"
+	LayoutGrouped = "grouped"
+
+	defaultTemplateName = "default.md.tmpl"
+	groupedTemplateName = "grouped.md.tmpl"
+)
+
+var (
+	// defaultTemplate is the template of the default documentation layout, which is a header
+	// followed by a table of every marker.
+	//
+	//go:embed templates/default.md.tmpl
+	defaultTemplate string
+
+	// groupedTemplate is the template of the grouped documentation layout, which is a table of
+	// contents followed by a section for each policy.
+	//
+	//go:embed templates/grouped.md.tmpl
+	groupedTemplate string
 )
 
 type Documentation struct {
 	File *files.File
+
+	// Template is the template which the documentation is rendered with.
+	Template *template.Template
 }
 
+// NewDocumentation returns documentation which is rendered to a file with the default template.
 func NewDocumentation(file *files.File) *Documentation {
-	file.Content = append(file.Content, []byte(DocumentationMarkdownHeader)...)
+	return &Documentation{File: file, Template: DefaultTemplate()}
+}
+
+// DefaultTemplate returns the template of the default documentation layout.
+func DefaultTemplate() *template.Template {
+	return template.Must(newTemplate(defaultTemplateName).Parse(defaultTemplate))
+}
+
+// LayoutTemplate returns the template of a built-in documentation layout.
+func LayoutTemplate(layout string) (*template.Template, error) {
+	switch layout {
+	case LayoutFlat:
+		return DefaultTemplate(), nil
+	case LayoutGrouped:
+		return template.Must(newTemplate(groupedTemplateName).Parse(groupedTemplate)), nil
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s]", ErrInvalidLayout, layout, LayoutFlat, LayoutGrouped)
+	}
+}
+
+// ReadTemplate reads and parses a documentation template from a path.
+func ReadTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read documentation template [%s] - %w", path, err)
+	}
+
+	documentationTemplate, err := newTemplate(filepath.Base(path)).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse documentation template [%s] - %w", path, err)
+	}
+
+	return documentationTemplate, nil
+}
+
+// Generate generates a document from a model by rendering the template.
+func (docs *Documentation) Generate(model *Model) error {
+	content := &bytes.Buffer{}
+
+	if err := docs.Template.Execute(content, model); err != nil {
+		return fmt.Errorf("unable to render documentation template [%s] - %w", docs.Template.Name(), err)
+	}
+
+	docs.File.Content = append(docs.File.Content, content.Bytes()...)
 
-	return &Documentation{File: file}
+	return nil
 }
 
-// Generate generates a document from a set of rows.
-func (docs *Documentation) Generate(rows ...Row) {
+// Table renders a set of markers as a table with the given columns.
+func Table(markers []*Marker, columns []string) string {
 	// create the table
 	tableBytes := &bytes.Buffer{}
 
 	table := tablewriter.NewWriter(tableBytes)
-	table.SetHeader(Header())
+	table.SetHeader(columns)
 	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
 	table.SetCenterSeparator("|")
 
-	// append the data for each row to the table
-	for _, row := range rows {
-		table.Append(
-			[]string{
-				row.EffectColumn(),
-				row.PermissionColumn(),
-				row.ResourceColumn(),
-				row.ReasonColumn(),
-				row.ConditionColumn(),
-			},
-		)
+	// append the data for each marker to the table
+	for _, marker := range markers {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = marker.Column(column)
+		}
+
+		table.Append(values)
 	}
 
 	// write the data to the bytes buffer and return
 	table.Render()
 
-	// append the rendered data to the existing data
-	docs.File.Content = append(docs.File.Content, tableBytes.Bytes()...)
+	return tableBytes.String()
+}
+
+// ServiceTable renders a summary of the actions of a set of services as a table.
+func ServiceTable(services []*Service) string {
+	tableBytes := &bytes.Buffer{}
+
+	table := tablewriter.NewWriter(tableBytes)
+	table.SetHeader([]string{"service", "allow", "deny"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+
+	for _, service := range services {
+		table.Append([]string{service.Name, strings.Join(service.Allow, ", "), strings.Join(service.Deny, ", ")})
+	}
+
+	table.Render()
+
+	return tableBytes.String()
+}
+
+// AccessLevelTable renders a summary of the access levels of the actions of a policy as a table.
+func AccessLevelTable(levels []*AccessLevel) string {
+	tableBytes := &bytes.Buffer{}
+
+	table := tablewriter.NewWriter(tableBytes)
+	table.SetHeader([]string{"access level", "markers", "actions"})
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAutoWrapText(false)
+
+	for _, level := range levels {
+		table.Append([]string{level.Level, strconv.Itoa(level.Markers), strings.Join(level.Actions, ", ")})
+	}
+
+	table.Render()
+
+	return tableBytes.String()
+}
+
+// Anchor returns the anchor of a Markdown heading, as generated by GitHub, so that headings may
+// be linked to from a table of contents.
+func Anchor(heading string) string {
+	anchor := &strings.Builder{}
+
+	for _, character := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(character), unicode.IsDigit(character), character == '-', character == '_':
+			anchor.WriteRune(character)
+		case character == ' ':
+			anchor.WriteRune('-')
+		}
+	}
+
+	return anchor.String()
+}
+
+// newTemplate returns a new template with the functions which are available to documentation
+// templates.
+func newTemplate(name string) *template.Template {
+	return template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		"table":        Table,
+		"services":     ServiceTable,
+		"accessLevels": AccessLevelTable,
+		"anchor":       Anchor,
+		"base":         filepath.Base,
+		"join":         strings.Join,
+		"lower":        strings.ToLower,
+		"upper":        strings.ToUpper,
+		"replace":      strings.ReplaceAll,
+	})
 }
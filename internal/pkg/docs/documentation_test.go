@@ -0,0 +1,226 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+// statementDocument is a policy document with a single statement, used to satisfy the
+// StatementDocument interface.
+type statementDocument struct{}
+
+func (document statementDocument) DocumentStatements() []Statement {
+	return []Statement{{Sid: "Default", Effect: policy.FakeEffectColumn, Actions: []string{policy.FakePermissionColumn}}}
+}
+
+func TestDocumentation_Generate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "ensure default template renders the header and table",
+			want: "# Policy Justification\n\n" +
+				"This file contains justification for access policies needed by this project.\n\n" +
+				Table(NewModel(nil, policy.NewFakeMarker()).Markers, Header()),
+			wantErr: false,
+		},
+		{
+			name: "ensure custom template renders the model",
+			template: "{{ range .Policies }}## {{ .Name }} ({{ len .Statements }})\n" +
+				"{{ range .Markers }}- {{ .Permission }}: {{ .Reason }}{{ end }}\n{{ end }}",
+			want:    "## fake (1)\n- *: fake\n",
+			wantErr: false,
+		},
+		{
+			name:     "ensure custom template with a missing field returns an error",
+			template: "{{ .Missing }}",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			documentation := NewDocumentation(&files.File{})
+
+			if tt.template != "" {
+				path := filepath.Join(t.TempDir(), "template.md.tmpl")
+				if err := os.WriteFile(path, []byte(tt.template), 0o600); err != nil {
+					t.Fatalf("unable to write template - %v", err)
+				}
+
+				documentationTemplate, err := ReadTemplate(path)
+				if err != nil {
+					t.Fatalf("ReadTemplate() error = %v", err)
+				}
+
+				documentation.Template = documentationTemplate
+			}
+
+			model := NewModel(nil, policy.NewFakeMarker())
+			model.AddPolicy(policy.FakeName, "fake.json", statementDocument{}, policy.NewFakeMarker())
+
+			err := documentation.Generate(model)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Documentation.Generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got := string(documentation.File.Content); got != tt.want {
+				t.Errorf("Documentation.Generate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadTemplate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "invalid.md.tmpl")
+	if err := os.WriteFile(path, []byte("{{ range }}"), 0o600); err != nil {
+		t.Fatalf("unable to write template - %v", err)
+	}
+
+	if _, err := ReadTemplate(path); err == nil || !strings.Contains(err.Error(), "unable to parse") {
+		t.Errorf("ReadTemplate() error = %v, want a parse error", err)
+	}
+}
+
+func TestLayoutTemplate(t *testing.T) {
+	t.Parallel()
+
+	model := NewModel(nil, policy.NewFakeMarker())
+	model.AddPolicy(policy.FakeName, filepath.Join("policies", "fake.json"), statementDocument{}, policy.NewFakeMarker())
+	model.RelativeTo("docs")
+
+	tests := []struct {
+		name     string
+		layout   string
+		rollup   bool
+		contains []string
+		excludes []string
+		wantErr  bool
+	}{
+		{
+			name:     "ensure flat layout renders a single table",
+			layout:   LayoutFlat,
+			contains: []string{"# Policy Justification"},
+			excludes: []string{"## Contents"},
+			wantErr:  false,
+		},
+		{
+			name:   "ensure grouped layout renders a section for each policy",
+			layout: LayoutGrouped,
+			contains: []string{
+				"## Contents\n\n* [fake](#fake)\n",
+				"## fake\n",
+				"Generated file: [fake.json](../policies/fake.json) (1 statements, 1 markers)",
+				"### Permissions",
+			},
+			excludes: []string{"### Services"},
+			wantErr:  false,
+		},
+		{
+			name:     "ensure grouped layout renders the service rollup",
+			layout:   LayoutGrouped,
+			rollup:   true,
+			contains: []string{"### Services", "SERVICE"},
+			wantErr:  false,
+		},
+		{
+			name:    "ensure unknown layout returns an error",
+			layout:  "nested",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			layoutTemplate, err := LayoutTemplate(tt.layout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LayoutTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			rendered := *model
+			rendered.ServiceRollup = tt.rollup
+
+			documentation := &Documentation{File: &files.File{}, Template: layoutTemplate}
+			if err := documentation.Generate(&rendered); err != nil {
+				t.Fatalf("Documentation.Generate() error = %v", err)
+			}
+
+			got := string(documentation.File.Content)
+
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Documentation.Generate() = %q, want it to contain %q", got, want)
+				}
+			}
+
+			for _, exclude := range tt.excludes {
+				if strings.Contains(got, exclude) {
+					t.Errorf("Documentation.Generate() = %q, want it not to contain %q", got, exclude)
+				}
+			}
+		})
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		heading string
+		want    string
+	}{
+		{
+			name:    "ensure heading is lowercased",
+			heading: "Installer",
+			want:    "installer",
+		},
+		{
+			name:    "ensure spaces are replaced with dashes",
+			heading: " installer local ",
+			want:    "installer-local",
+		},
+		{
+			name:    "ensure punctuation is removed",
+			heading: "installer.local (v2)",
+			want:    "installerlocal-v2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Anchor(tt.heading); got != tt.want {
+				t.Errorf("Anchor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,13 @@ const (
 	HeaderResource   = "resource"
 	HeaderReason     = "reason"
 	HeaderCondition  = "condition"
+
+	// optional headers.
+	HeaderConditionJSON = "condition json"
+	HeaderAccessLevel   = "access level"
+	HeaderExpires       = "expires"
+	HeaderUsedBy        = "used by"
+	HeaderSource        = "source"
 )
 
 // Header defines the table Header for our documentation page.  This is ordered, so be
@@ -19,3 +26,58 @@ func Header() []string {
 		HeaderCondition,
 	}
 }
+
+// OptionalHeader defines the built-in columns which are not included in the documentation table
+// unless the columns are configured, such as the conditions as they appear in the generated
+// policy, as JSON, and the access level of each action.  Columns which change without the
+// permissions changing, such as the source location of each marker, are optional so that the
+// default documentation stays stable.
+func OptionalHeader() []string {
+	return []string{
+		HeaderConditionJSON,
+		HeaderAccessLevel,
+		HeaderExpires,
+		HeaderUsedBy,
+		HeaderSource,
+	}
+}
+
+// IsHeader returns whether or not a column is one of the built-in columns of the documentation.
+func IsHeader(column string) bool {
+	for _, header := range append(Header(), OptionalHeader()...) {
+		if column == header {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Column returns the value of a column for a row.  Columns which are not one of the built-in
+// columns are user-defined metadata keys.
+func Column(row Row, column string) string {
+	switch column {
+	case HeaderEffect:
+		return row.EffectColumn()
+	case HeaderPermission:
+		return row.PermissionColumn()
+	case HeaderResource:
+		return row.ResourceColumn()
+	case HeaderReason:
+		return row.ReasonColumn()
+	case HeaderCondition:
+		return row.ConditionColumn()
+	case HeaderConditionJSON:
+		return row.RawConditionColumn()
+	case HeaderAccessLevel:
+		return row.AccessLevelColumn()
+	case HeaderExpires:
+		return row.ExpiresColumn()
+	case HeaderUsedBy:
+		return row.UsedByColumn()
+	case HeaderSource:
+		return row.SourceColumn()
+	default:
+		return row.MetadataColumn(column)
+	}
+}
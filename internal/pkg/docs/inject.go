@@ -0,0 +1,63 @@
+package docs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrMissingRegion = errors.New("missing documentation region")
+)
+
+const (
+	RegionBegin = "<!-- policy-gen:begin -->"
+	RegionEnd   = "<!-- policy-gen:end -->"
+)
+
+// Inject replaces the region between the begin and end comments of existing content with the
+// generated documentation.  Content outside of the region, including the comments themselves, is
+// preserved.
+func Inject(existing, generated []byte) ([]byte, error) {
+	start, end, err := region(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	injected := &bytes.Buffer{}
+	injected.Write(existing[:start])
+	injected.WriteString("\n")
+	injected.Write(bytes.TrimSpace(generated))
+	injected.WriteString("\n")
+	injected.Write(existing[end:])
+
+	return injected.Bytes(), nil
+}
+
+// Region returns the content of the region between the begin and end comments, without the
+// surrounding whitespace.
+func Region(content []byte) ([]byte, error) {
+	start, end, err := region(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(content[start:end]), nil
+}
+
+// region returns the offsets of the content between the begin and end comments.
+func region(content []byte) (start, end int, err error) {
+	begin := bytes.Index(content, []byte(RegionBegin))
+	if begin < 0 {
+		return 0, 0, fmt.Errorf("%w - unable to find begin comment [%s]", ErrMissingRegion, RegionBegin)
+	}
+
+	start = begin + len(RegionBegin)
+
+	length := bytes.Index(content[start:], []byte(RegionEnd))
+	if length < 0 {
+		return 0, 0, fmt.Errorf("%w - unable to find end comment [%s] after begin comment", ErrMissingRegion, RegionEnd)
+	}
+
+	return start, start + length, nil
+}
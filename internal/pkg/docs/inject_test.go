@@ -0,0 +1,80 @@
+package docs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestInject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		want      string
+		wantErr   error
+	}{
+		{
+			name:      "ensure region is replaced and surrounding content is preserved",
+			existing:  "# Project\n\n" + RegionBegin + "\nold\n" + RegionEnd + "\n\n## Footer\n",
+			generated: "\nnew table\n\n",
+			want:      "# Project\n\n" + RegionBegin + "\nnew table\n" + RegionEnd + "\n\n## Footer\n",
+			wantErr:   nil,
+		},
+		{
+			name:      "ensure empty region is replaced",
+			existing:  RegionBegin + RegionEnd,
+			generated: "new table",
+			want:      RegionBegin + "\nnew table\n" + RegionEnd,
+			wantErr:   nil,
+		},
+		{
+			name:      "ensure missing begin comment returns an error",
+			existing:  "# Project\n" + RegionEnd,
+			generated: "new table",
+			wantErr:   ErrMissingRegion,
+		},
+		{
+			name:      "ensure end comment before begin comment returns an error",
+			existing:  RegionEnd + "\n" + RegionBegin,
+			generated: "new table",
+			wantErr:   ErrMissingRegion,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Inject([]byte(tt.existing), []byte(tt.generated))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Inject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Inject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegion(t *testing.T) {
+	t.Parallel()
+
+	content := "# Project\n\n" + RegionBegin + "\n\ntable\n" + RegionEnd + "\n"
+
+	got, err := Region([]byte(content))
+	if err != nil {
+		t.Fatalf("Region() error = %v", err)
+	}
+
+	if string(got) != "table" {
+		t.Errorf("Region() = %q, want %q", got, "table")
+	}
+
+	if _, err := Region([]byte("# Project\n")); !errors.Is(err, ErrMissingRegion) {
+		t.Errorf("Region() error = %v, want %v", err, ErrMissingRegion)
+	}
+}
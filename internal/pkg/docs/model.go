@@ -0,0 +1,418 @@
+package docs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scottd018/policy-gen/internal/pkg/graph"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+var (
+	ErrInvalidSort = errors.New("invalid documentation sort")
+)
+
+const (
+	GeneratorName = "policy-gen"
+
+	SortSource      = "source"
+	SortAccessLevel = "access-level"
+
+	effectDeny = "Deny"
+)
+
+// Model represents the data which is passed to a documentation template.
+type Model struct {
+	// Generation is the metadata about the generation of the documentation.
+	Generation Generation
+
+	// Columns are the ordered columns of the documentation table.
+	Columns []string
+
+	// Policies are the generated policies, ordered by name.
+	Policies []*Policy
+
+	// Markers are the markers of every policy, in the order in which they were found.
+	Markers []*Marker
+
+	// ServiceRollup determines whether the section of each policy includes a summary of the
+	// actions of each service.
+	ServiceRollup bool
+}
+
+// Generation represents the metadata about the generation of the documentation.  The time is
+// derived from the source, rather than the clock, so that regenerating unchanged documentation
+// produces the same content.  It is zero when it cannot be derived.
+type Generation struct {
+	Generator string
+	Time      time.Time
+	SourceURL string
+	SourceRef string
+}
+
+// Policy represents a generated policy along with the markers which it was generated from.
+type Policy struct {
+	Name       string
+	File       string
+	Statements []Statement
+	Markers    []*Marker
+
+	// Link is the path of the generated file relative to the documentation file.
+	Link string
+
+	// Services are the services of the actions of the markers, ordered by name.
+	Services []*Service
+
+	// Document is the generated policy document as indented JSON.
+	Document string
+
+	// AccessLevels are the access levels of the actions of every marker of the policy, ordered
+	// from the most to the least sensitive.  They are not affected by filtering the markers.  The
+	// grouped layout summarizes them, while the flat layout has no section for each policy.
+	AccessLevels []*AccessLevel
+
+	// policyMarkers are the markers which the policy was generated from, which are kept so that
+	// its graph is only built when it is requested.
+	policyMarkers []policy.Marker
+}
+
+// AccessLevel represents the markers of a policy whose actions have a single access level.
+type AccessLevel struct {
+	Level   string
+	Markers int
+	Actions []string
+}
+
+// Service represents the actions of a single service which the markers of a policy allow or
+// deny.
+type Service struct {
+	Name  string
+	Allow []string
+	Deny  []string
+}
+
+// Statement represents a statement of a generated policy.
+type Statement struct {
+	Sid       string
+	Effect    string
+	Actions   []string
+	Resources []string
+
+	// Condition is the condition of the statement as a human-readable expression, and
+	// RawCondition is the condition as JSON.
+	Condition    string
+	RawCondition string
+}
+
+// StatementDocument represents a policy document whose statements may be documented.
+type StatementDocument interface {
+	DocumentStatements() []Statement
+}
+
+// Marker represents a marker and the value of each of its columns.
+type Marker struct {
+	Policy     string
+	Effect     string
+	Permission string
+	Resource   string
+	Reason     string
+	Condition  string
+	Expires    string
+	UsedBy     string
+	SourceLink string
+	Metadata   map[string]string
+	Source     *policy.Source
+
+	// RawCondition is the condition as it appears in the generated policy, as JSON.
+	RawCondition string
+
+	// AccessLevel is the access level of the action, such as Read or Permissions management.
+	AccessLevel string
+
+	// Values are the values of the columns of the model, in order.
+	Values []string
+
+	row Row
+}
+
+// NewModel returns a model of a set of markers, with the given columns, in which the markers
+// belong to no policy.  Policies may be added to the model with AddPolicy.
+func NewModel(columns []string, markers ...policy.Marker) *Model {
+	if len(columns) == 0 {
+		columns = Header()
+	}
+
+	model := &Model{
+		Generation: Generation{Generator: GeneratorName},
+		Columns:    columns,
+		Policies:   []*Policy{},
+		Markers:    make([]*Marker, len(markers)),
+	}
+
+	for i := range markers {
+		model.Markers[i] = model.newMarker(markers[i])
+	}
+
+	return model
+}
+
+// AddPolicy adds a generated policy to the model.  The statements of the policy are included if
+// the document is a StatementDocument.
+func (model *Model) AddPolicy(name, file string, document interface{}, markers ...policy.Marker) {
+	documented := &Policy{
+		Name:       name,
+		File:       file,
+		Link:       filepath.ToSlash(file),
+		Statements: []Statement{},
+		Markers:    make([]*Marker, len(markers)),
+
+		policyMarkers: markers,
+	}
+
+	if statementDocument, ok := document.(StatementDocument); ok {
+		documented.Statements = statementDocument.DocumentStatements()
+	}
+
+	if document != nil {
+		if data, err := json.MarshalIndent(document, "", "    "); err == nil {
+			documented.Document = string(data)
+		}
+	}
+
+	for i := range markers {
+		documented.Markers[i] = model.newMarker(markers[i])
+	}
+
+	documented.Services = services(documented.Markers)
+	documented.AccessLevels = accessLevels(documented.Markers)
+
+	model.Policies = append(model.Policies, documented)
+
+	sort.SliceStable(model.Policies, func(i, j int) bool {
+		return model.Policies[i].Name < model.Policies[j].Name
+	})
+}
+
+// RelativeTo sets the link of each policy to the path of its generated file relative to a
+// directory, which is the directory of the documentation file.
+func (model *Model) RelativeTo(directory string) {
+	for _, documented := range model.Policies {
+		if link, err := filepath.Rel(directory, documented.File); err == nil {
+			documented.Link = filepath.ToSlash(link)
+		}
+	}
+}
+
+// ForPolicy returns a model of a single policy of the model, whose link is relative to the
+// directory of its generated file.
+func (model *Model) ForPolicy(documented *Policy) *Model {
+	single := *documented
+	single.Link = filepath.Base(documented.File)
+
+	return &Model{
+		Generation:    model.Generation,
+		Columns:       model.Columns,
+		Policies:      []*Policy{&single},
+		Markers:       documented.Markers,
+		ServiceRollup: model.ServiceRollup,
+	}
+}
+
+// Graph returns a Mermaid diagram of the policies, services, actions and resources of the model
+// within a fenced code block, which templates may embed.  The diagram is built when it is called,
+// so that it is only built for templates which embed it.  It is not affected by filtering the
+// markers.
+func (model *Model) Graph() string {
+	markerMap := policy.MarkerMap{}
+	for _, documented := range model.Policies {
+		markerMap[documented.File] = documented.policyMarkers
+	}
+
+	return graph.New(markerMap).Markdown()
+}
+
+// Graph returns a Mermaid diagram of the services, actions and resources of the policy within a
+// fenced code block, which templates may embed.  It is not affected by filtering the markers.
+func (documented *Policy) Graph() string {
+	return graph.New(policy.MarkerMap{documented.File: documented.policyMarkers}).Markdown()
+}
+
+// ValidateSort validates the order of the documented markers.  An empty order is the order in
+// which the markers were found.
+func ValidateSort(order string) error {
+	switch order {
+	case "", SortSource, SortAccessLevel:
+		return nil
+	default:
+		return fmt.Errorf("%w [%s] - must be one of [%s, %s]", ErrInvalidSort, order, SortSource, SortAccessLevel)
+	}
+}
+
+// FilterAccessLevel removes the markers whose access level is less sensitive than a given access
+// level, so that only the most sensitive permissions are documented.
+func (model *Model) FilterAccessLevel(level string) {
+	minimum := policy.AccessLevelRank(level)
+
+	include := func(markers []*Marker) []*Marker {
+		included := []*Marker{}
+
+		for _, marker := range markers {
+			if policy.AccessLevelRank(marker.AccessLevel) >= minimum {
+				included = append(included, marker)
+			}
+		}
+
+		return included
+	}
+
+	model.Markers = include(model.Markers)
+
+	for _, documented := range model.Policies {
+		documented.Markers = include(documented.Markers)
+		documented.Services = services(documented.Markers)
+	}
+}
+
+// SortByAccessLevel orders the markers from the most to the least sensitive access level.
+// Markers with the same access level remain in the order in which they were found.
+func (model *Model) SortByAccessLevel() {
+	sortMarkers := func(markers []*Marker) {
+		sort.SliceStable(markers, func(i, j int) bool {
+			return policy.AccessLevelRank(markers[i].AccessLevel) > policy.AccessLevelRank(markers[j].AccessLevel)
+		})
+	}
+
+	sortMarkers(model.Markers)
+
+	for _, documented := range model.Policies {
+		sortMarkers(documented.Markers)
+	}
+}
+
+// Column returns the value of a column for the marker, which may be a user-defined metadata key.
+func (marker *Marker) Column(column string) string {
+	return Column(marker.row, column)
+}
+
+// newMarker returns the model of a marker.
+func (model *Model) newMarker(marker policy.Marker) *Marker {
+	documented := &Marker{
+		Policy:     marker.GetName(),
+		Effect:     marker.EffectColumn(),
+		Permission: marker.PermissionColumn(),
+		Resource:   marker.ResourceColumn(),
+		Reason:     marker.ReasonColumn(),
+		Condition:  marker.ConditionColumn(),
+		Expires:    marker.ExpiresColumn(),
+		UsedBy:     marker.UsedByColumn(),
+		SourceLink: marker.SourceColumn(),
+		Metadata:   marker.GetMetadata(),
+		Source:     marker.GetSource(),
+		Values:     make([]string, len(model.Columns)),
+		row:        marker,
+
+		RawCondition: marker.RawConditionColumn(),
+		AccessLevel:  marker.AccessLevelColumn(),
+	}
+
+	for i, column := range model.Columns {
+		documented.Values[i] = Column(marker, column)
+	}
+
+	return documented
+}
+
+// ServiceOf returns the service of an action, which is its prefix, such as "ec2" for
+// "ec2:CreateVpc".  Actions without a prefix, such as "*", belong to the "*" service.
+func ServiceOf(action string) string {
+	name, _, found := strings.Cut(action, ":")
+	if !found {
+		return "*"
+	}
+
+	return strings.ToLower(name)
+}
+
+// services returns the actions of each service which a set of markers allow or deny, ordered by
+// the name of the service.
+func services(markers []*Marker) []*Service {
+	byName := map[string]*Service{}
+	seen := map[string]bool{}
+
+	for _, marker := range markers {
+		name := ServiceOf(marker.Permission)
+
+		service, exists := byName[name]
+		if !exists {
+			service = &Service{Name: name, Allow: []string{}, Deny: []string{}}
+			byName[name] = service
+		}
+
+		key := marker.Effect + "/" + marker.Permission
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		if marker.Effect == effectDeny {
+			service.Deny = append(service.Deny, marker.Permission)
+		} else {
+			service.Allow = append(service.Allow, marker.Permission)
+		}
+	}
+
+	summary := make([]*Service, 0, len(byName))
+	for _, service := range byName {
+		sort.Strings(service.Allow)
+		sort.Strings(service.Deny)
+
+		summary = append(summary, service)
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].Name < summary[j].Name
+	})
+
+	return summary
+}
+
+// accessLevels returns the access levels of the actions of a set of markers, ordered from the
+// most to the least sensitive, with the distinct actions of each.
+func accessLevels(markers []*Marker) []*AccessLevel {
+	byLevel := map[string]*AccessLevel{}
+	seen := map[string]bool{}
+
+	for _, marker := range markers {
+		level, exists := byLevel[marker.AccessLevel]
+		if !exists {
+			level = &AccessLevel{Level: marker.AccessLevel, Actions: []string{}}
+			byLevel[marker.AccessLevel] = level
+		}
+
+		level.Markers++
+
+		if !seen[marker.Permission] {
+			seen[marker.Permission] = true
+			level.Actions = append(level.Actions, marker.Permission)
+		}
+	}
+
+	summary := make([]*AccessLevel, 0, len(byLevel))
+	for _, level := range byLevel {
+		sort.Strings(level.Actions)
+
+		summary = append(summary, level)
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		return policy.AccessLevelRank(summary[i].Level) > policy.AccessLevelRank(summary[j].Level)
+	})
+
+	return summary
+}
@@ -0,0 +1,199 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func Test_services(t *testing.T) {
+	t.Parallel()
+
+	markers := []*Marker{
+		{Effect: "Allow", Permission: "s3:PutObject"},
+		{Effect: "Allow", Permission: "ec2:CreateVpc"},
+		{Effect: "Allow", Permission: "s3:GetObject"},
+		{Effect: "Allow", Permission: "S3:GetObject"},
+		{Effect: "Allow", Permission: "s3:GetObject"},
+		{Effect: effectDeny, Permission: "s3:DeleteBucket"},
+		{Effect: "Allow", Permission: "*"},
+	}
+
+	want := []*Service{
+		{Name: "*", Allow: []string{"*"}, Deny: []string{}},
+		{Name: "ec2", Allow: []string{"ec2:CreateVpc"}, Deny: []string{}},
+		{Name: "s3", Allow: []string{"S3:GetObject", "s3:GetObject", "s3:PutObject"}, Deny: []string{"s3:DeleteBucket"}},
+	}
+
+	if got := services(markers); !reflect.DeepEqual(got, want) {
+		t.Errorf("services() = %+v, want %+v", got, want)
+	}
+}
+
+func TestModel_ForPolicy(t *testing.T) {
+	t.Parallel()
+
+	model := NewModel(nil)
+	model.AddPolicy("b", "policies/b.json", nil)
+	model.AddPolicy("a", "policies/a.json", nil)
+	model.RelativeTo("docs")
+
+	if model.Policies[0].Name != "a" || model.Policies[0].Link != "../policies/a.json" {
+		t.Fatalf("Model.RelativeTo() = %+v, want policy [a] linked to [../policies/a.json]", model.Policies[0])
+	}
+
+	single := model.ForPolicy(model.Policies[1])
+
+	if len(single.Policies) != 1 || single.Policies[0].Name != "b" || single.Policies[0].Link != "b.json" {
+		t.Errorf("Model.ForPolicy() = %+v, want only policy [b] linked to [b.json]", single.Policies)
+	}
+
+	if model.Policies[1].Link != "../policies/b.json" {
+		t.Errorf("Model.ForPolicy() modified the link of the original policy to [%s]", model.Policies[1].Link)
+	}
+}
+
+func TestModel_Graph(t *testing.T) {
+	t.Parallel()
+
+	model := NewModel(nil)
+	model.AddPolicy("b", "policies/b.json", nil, policy.NewFakeMarker())
+	model.AddPolicy("a", "policies/a.json", nil)
+
+	documented := model.Policies[1]
+
+	tests := []struct {
+		name  string
+		graph string
+		want  string
+	}{
+		{
+			name:  "ensure graph of the model is a fenced mermaid diagram of every policy",
+			graph: model.Graph(),
+			want:  "```mermaid\nflowchart LR\n",
+		},
+		{
+			name:  "ensure graph of the model includes the action of each marker",
+			graph: model.Graph(),
+			want:  `["` + policy.FakePermissionColumn + `"]`,
+		},
+		{
+			name:  "ensure graph of a policy includes the action of each of its markers",
+			graph: documented.Graph(),
+			want:  `["` + policy.FakePermissionColumn + `"]`,
+		},
+		{
+			name:  "ensure graph of a model of a single policy is the graph of the policy",
+			graph: model.ForPolicy(documented).Graph(),
+			want:  documented.Graph(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if !strings.Contains(tt.graph, tt.want) {
+				t.Errorf("Graph() = %s, want to contain %s", tt.graph, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_AccessLevels(t *testing.T) {
+	t.Parallel()
+
+	newModel := func() *Model {
+		model := NewModel(nil)
+		model.AddPolicy("test", "test.json", nil)
+
+		markers := []*Marker{
+			{Permission: "s3:GetObject", AccessLevel: policy.AccessLevelRead},
+			{Permission: "iam:PutRolePolicy", AccessLevel: policy.AccessLevelPermissionsManagement},
+			{Permission: "s3:ListBucket", AccessLevel: policy.AccessLevelList},
+			{Permission: "s3:PutObject", AccessLevel: policy.AccessLevelWrite},
+			{Permission: "s3:GetObjectTagging", AccessLevel: policy.AccessLevelRead},
+		}
+
+		model.Markers = markers
+		model.Policies[0].Markers = append([]*Marker{}, markers...)
+		model.Policies[0].AccessLevels = accessLevels(markers)
+
+		return model
+	}
+
+	permissions := func(markers []*Marker) []string {
+		names := make([]string, len(markers))
+		for i := range markers {
+			names[i] = markers[i].Permission
+		}
+
+		return names
+	}
+
+	t.Run("ensure summary is ordered from the most sensitive access level", func(t *testing.T) {
+		t.Parallel()
+
+		want := []*AccessLevel{
+			{Level: policy.AccessLevelPermissionsManagement, Markers: 1, Actions: []string{"iam:PutRolePolicy"}},
+			{Level: policy.AccessLevelWrite, Markers: 1, Actions: []string{"s3:PutObject"}},
+			{Level: policy.AccessLevelRead, Markers: 2, Actions: []string{"s3:GetObject", "s3:GetObjectTagging"}},
+			{Level: policy.AccessLevelList, Markers: 1, Actions: []string{"s3:ListBucket"}},
+		}
+
+		if got := newModel().Policies[0].AccessLevels; !reflect.DeepEqual(got, want) {
+			t.Errorf("accessLevels() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("ensure filter removes less sensitive markers", func(t *testing.T) {
+		t.Parallel()
+
+		model := newModel()
+		model.FilterAccessLevel(policy.AccessLevelWrite)
+
+		want := []string{"iam:PutRolePolicy", "s3:PutObject"}
+
+		if got := permissions(model.Markers); !reflect.DeepEqual(got, want) {
+			t.Errorf("Model.FilterAccessLevel() markers = %v, want %v", got, want)
+		}
+
+		if got := permissions(model.Policies[0].Markers); !reflect.DeepEqual(got, want) {
+			t.Errorf("Model.FilterAccessLevel() policy markers = %v, want %v", got, want)
+		}
+
+		if len(model.Policies[0].AccessLevels) != 4 {
+			t.Errorf("Model.FilterAccessLevel() modified the access level summary")
+		}
+	})
+
+	t.Run("ensure sort orders markers from the most sensitive access level", func(t *testing.T) {
+		t.Parallel()
+
+		model := newModel()
+		model.SortByAccessLevel()
+
+		want := []string{"iam:PutRolePolicy", "s3:PutObject", "s3:GetObject", "s3:GetObjectTagging", "s3:ListBucket"}
+
+		if got := permissions(model.Policies[0].Markers); !reflect.DeepEqual(got, want) {
+			t.Errorf("Model.SortByAccessLevel() = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestValidateSort(t *testing.T) {
+	t.Parallel()
+
+	for _, order := range []string{"", SortSource, SortAccessLevel} {
+		if err := ValidateSort(order); err != nil {
+			t.Errorf("ValidateSort(%q) error = %v", order, err)
+		}
+	}
+
+	if err := ValidateSort("name"); err == nil {
+		t.Errorf("ValidateSort() error = nil, want an error")
+	}
+}
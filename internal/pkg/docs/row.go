@@ -6,4 +6,10 @@ type Row interface {
 	ResourceColumn() string
 	ReasonColumn() string
 	ConditionColumn() string
+	RawConditionColumn() string
+	AccessLevelColumn() string
+	ExpiresColumn() string
+	MetadataColumn(key string) string
+	UsedByColumn() string
+	SourceColumn() string
 }
@@ -0,0 +1,178 @@
+package docs
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"sort"
+
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	SiteIndex = "index.html"
+
+	siteExtension = "html"
+)
+
+var (
+	// siteStyle is the stylesheet which is inlined into every page of the site so that the site
+	// has no external assets.
+	//
+	//go:embed templates/site/style.css
+	siteStyle string
+
+	// siteIndexTemplate is the template of the index page of the site, which is a searchable
+	// and filterable table of every marker.
+	//
+	//go:embed templates/site/index.html.tmpl
+	siteIndexTemplate string
+
+	// sitePolicyTemplate is the template of the page of a single policy, which shows the
+	// generated policy document along with its markers.
+	//
+	//go:embed templates/site/policy.html.tmpl
+	sitePolicyTemplate string
+)
+
+// Site represents a self-contained static HTML site of the documentation, which is written to a
+// directory.
+type Site struct {
+	Directory string
+}
+
+// SiteIndexPage represents the data which is passed to the index page of the site.
+type SiteIndexPage struct {
+	*Model
+
+	// Services are the distinct services of every marker, ordered by name.
+	Services []string
+
+	// Effects are the distinct effects of every marker, ordered by name.
+	Effects []string
+
+	// AccessLevels are the distinct access levels of every marker, ordered from the most to the
+	// least sensitive.
+	AccessLevels []string
+}
+
+// SitePolicyPage represents the data which is passed to the page of a single policy.
+type SitePolicyPage struct {
+	*Model
+
+	Policy *Policy
+}
+
+// NewSite returns a site which is written to a directory.
+func NewSite(directory string) *Site {
+	return &Site{Directory: directory}
+}
+
+// PolicyPage returns the file name of the page of a policy, relative to the site directory.
+func PolicyPage(name string) string {
+	return Anchor(name) + "." + siteExtension
+}
+
+// Files renders the pages of the site from a model.  The index page links to a page for each
+// policy, which are all written to the site directory.
+func (site *Site) Files(model *Model) ([]*files.File, error) {
+	index, err := newSiteTemplate(SiteIndex).Parse(siteIndexTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse site template [%s] - %w", SiteIndex, err)
+	}
+
+	policyPage, err := newSiteTemplate("policy.html").Parse(sitePolicyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse site template [%s] - %w", "policy.html", err)
+	}
+
+	indexFile, err := site.render(SiteIndex, index, newSiteIndexPage(model))
+	if err != nil {
+		return nil, err
+	}
+
+	siteFiles := []*files.File{indexFile}
+
+	for _, documented := range model.Policies {
+		policyFile, err := site.render(PolicyPage(documented.Name), policyPage, &SitePolicyPage{Model: model, Policy: documented})
+		if err != nil {
+			return nil, err
+		}
+
+		siteFiles = append(siteFiles, policyFile)
+	}
+
+	return siteFiles, nil
+}
+
+// render renders a page of the site to a file within the site directory.
+func (site *Site) render(name string, page *template.Template, data interface{}) (*files.File, error) {
+	path := filepath.Join(site.Directory, name)
+
+	file, err := files.NewFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid site file [%s] - %w", path, err)
+	}
+
+	content := &bytes.Buffer{}
+
+	if err := page.Execute(content, data); err != nil {
+		return nil, fmt.Errorf("unable to render site page [%s] - %w", name, err)
+	}
+
+	file.Content = content.Bytes()
+
+	return file, nil
+}
+
+// newSiteIndexPage returns the data of the index page, collecting the values which the table of
+// markers may be filtered by.
+func newSiteIndexPage(model *Model) *SiteIndexPage {
+	services := map[string]bool{}
+	effects := map[string]bool{}
+	levels := map[string]bool{}
+
+	for _, marker := range model.Markers {
+		services[ServiceOf(marker.Permission)] = true
+		effects[marker.Effect] = true
+		levels[marker.AccessLevel] = true
+	}
+
+	accessLevels := sortedKeys(levels)
+	sort.SliceStable(accessLevels, func(i, j int) bool {
+		return policy.AccessLevelRank(accessLevels[i]) > policy.AccessLevelRank(accessLevels[j])
+	})
+
+	return &SiteIndexPage{
+		Model:        model,
+		Services:     sortedKeys(services),
+		Effects:      sortedKeys(effects),
+		AccessLevels: accessLevels,
+	}
+}
+
+// sortedKeys returns the keys of a set in order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// newSiteTemplate returns a new template with the functions which are available to the pages of
+// the site.
+func newSiteTemplate(name string) *template.Template {
+	return template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		"style":   func() template.CSS { return template.CSS(siteStyle) },
+		"page":    PolicyPage,
+		"service": ServiceOf,
+		"base":    filepath.Base,
+	})
+}
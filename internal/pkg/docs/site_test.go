@@ -0,0 +1,79 @@
+package docs
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+func TestSite_Files(t *testing.T) {
+	t.Parallel()
+
+	marker := policy.NewFakeMarker()
+
+	model := NewModel(append(Header(), HeaderSource), marker)
+	model.AddPolicy("fake policy", "fake.json", map[string]string{"Version": "2012-10-17"}, marker)
+	model.Markers[0].Policy = "fake policy"
+	model.Markers[0].Source = &policy.Source{File: "main.go", Line: 7, URL: "https://example.com/main.go#L7"}
+
+	siteFiles, err := NewSite("site").Files(model)
+	if err != nil {
+		t.Fatalf("Site.Files() error = %v", err)
+	}
+
+	if len(siteFiles) != 2 {
+		t.Fatalf("Site.Files() returned [%d] files, want [2]", len(siteFiles))
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		contains []string
+	}{
+		{
+			name:    "ensure index page links to each policy and filters markers",
+			path:    filepath.Join("site", SiteIndex),
+			content: string(siteFiles[0].Content),
+			contains: []string{
+				`<a href="fake-policy.html">fake policy</a>`,
+				`data-policy="fake policy" data-service="*" data-level="` + policy.FakeAccessLevel + `" data-effect="` + policy.FakeEffectColumn + `"`,
+				`<a href="https://example.com/main.go#L7">main.go:7</a>`,
+				`id="search"`,
+			},
+		},
+		{
+			name:    "ensure policy page renders the policy document",
+			path:    filepath.Join("site", "fake-policy.html"),
+			content: string(siteFiles[1].Content),
+			contains: []string{
+				"<h1>fake policy</h1>",
+				"&#34;Version&#34;: &#34;2012-10-17&#34;",
+				`<a href="index.html">`,
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		i, tt := i, tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if siteFiles[i].File != tt.path {
+				t.Errorf("Site.Files() path = %v, want %v", siteFiles[i].File, tt.path)
+			}
+
+			if strings.Contains(tt.content, "<link") || strings.Contains(tt.content, "<script src") {
+				t.Errorf("Site.Files() content references an external asset")
+			}
+
+			for _, want := range tt.contains {
+				if !strings.Contains(tt.content, want) {
+					t.Errorf("Site.Files() content = %q, want it to contain %q", tt.content, want)
+				}
+			}
+		})
+	}
+}
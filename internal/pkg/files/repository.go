@@ -0,0 +1,52 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const repositoryDirectory = ".git"
+
+// RepositoryPath returns the slash separated path of a file relative to the root of the git
+// repository which contains it.  The cleaned path is returned if the file is not within a
+// git repository.
+func RepositoryPath(path string) string {
+	relative := filepath.ToSlash(filepath.Clean(path))
+
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return relative
+	}
+
+	root, err := repositoryRoot(filepath.Dir(absolutePath))
+	if err != nil {
+		return relative
+	}
+
+	repositoryPath, err := filepath.Rel(root, absolutePath)
+	if err != nil {
+		return relative
+	}
+
+	return filepath.ToSlash(repositoryPath)
+}
+
+// repositoryRoot finds the root of the git repository which contains a given directory.
+func repositoryRoot(directory string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(directory, repositoryDirectory)); err == nil {
+			return directory, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("unable to find repository root - %w", err)
+		}
+
+		parent := filepath.Dir(directory)
+		if parent == directory {
+			return "", fmt.Errorf("unable to find repository root - %w", os.ErrNotExist)
+		}
+
+		directory = parent
+	}
+}
@@ -0,0 +1,45 @@
+package files
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRepositoryPath(t *testing.T) {
+	t.Parallel()
+
+	outside := filepath.Join(t.TempDir(), "file.go")
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "ensure a file is relative to the repository root",
+			path: "repository.go",
+			want: "internal/pkg/files/repository.go",
+		},
+		{
+			name: "ensure a file with a relative path is cleaned and relative to the repository root",
+			path: "../files/./test/../repository.go",
+			want: "internal/pkg/files/repository.go",
+		},
+		{
+			name: "ensure a file outside of a repository is cleaned",
+			path: outside,
+			want: filepath.ToSlash(outside),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := RepositoryPath(tt.path); got != tt.want {
+				t.Errorf("RepositoryPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
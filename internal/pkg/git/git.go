@@ -0,0 +1,172 @@
+package git
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidArchivePath = errors.New("invalid path in archive")
+	ErrInvalidRevision    = errors.New("invalid revision")
+)
+
+const (
+	command           = "git"
+	temporaryPattern  = "policy-gen-"
+	directoryMode     = 0o700
+	fileModeMask      = 0o700
+	minimumFileMode   = 0o600
+	pathSeparatorText = string(filepath.Separator)
+)
+
+// Export writes the content of a directory, within a git repository, at a given revision to a
+// temporary directory using the local git command.  It returns the path of the directory at
+// the revision and a function which removes the temporary directory.  The revision is resolved
+// to a commit before it is used, so that it is never interpreted as an option of git.
+func Export(directory, revision string) (string, func(), error) {
+	if revision == "" || strings.HasPrefix(revision, "-") {
+		return "", nil, fmt.Errorf("%w [%s] - must name a commit", ErrInvalidRevision, revision)
+	}
+
+	root, err := run(directory, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to find git repository for directory [%s] - %w", directory, err)
+	}
+
+	prefix, err := run(directory, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to find path of directory [%s] in git repository - %w", directory, err)
+	}
+
+	commit, err := run(directory, "rev-parse", "--verify", "--quiet", "--end-of-options", revision+"^{commit}")
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to resolve revision [%s] to a commit - %w", revision, err)
+	}
+
+	// options are not parsed after the end of options, so the path is given without a separator
+	arguments := []string{"archive", "--format=tar", "--end-of-options", commit}
+	if prefix != "" {
+		arguments = append(arguments, prefix)
+	}
+
+	archive, err := output(root, arguments...)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to read directory [%s] at revision [%s] - %w", directory, revision, err)
+	}
+
+	temporary, err := os.MkdirTemp("", temporaryPattern)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to create temporary directory - %w", err)
+	}
+
+	cleanup := func() { os.RemoveAll(temporary) }
+
+	if err := extract(archive, temporary); err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("unable to extract directory [%s] at revision [%s] - %w", directory, revision, err)
+	}
+
+	exported := filepath.Join(temporary, filepath.FromSlash(prefix))
+
+	// an empty directory is not included in an archive, so ensure that it exists
+	if err := os.MkdirAll(exported, directoryMode); err != nil {
+		cleanup()
+
+		return "", nil, fmt.Errorf("unable to create directory [%s] - %w", exported, err)
+	}
+
+	return exported, cleanup, nil
+}
+
+// extract extracts the directories and regular files of a tar archive into a directory.
+func extract(archive []byte, directory string) error {
+	reader := tar.NewReader(bytes.NewReader(archive))
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("unable to read archive - %w", err)
+		}
+
+		path := filepath.Join(directory, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(path, filepath.Clean(directory)+pathSeparatorText) {
+			return fmt.Errorf("%w [%s]", ErrInvalidArchivePath, header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, directoryMode); err != nil {
+				return fmt.Errorf("unable to create directory [%s] - %w", path, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), directoryMode); err != nil {
+				return fmt.Errorf("unable to create directory [%s] - %w", filepath.Dir(path), err)
+			}
+
+			content, err := io.ReadAll(reader)
+			if err != nil {
+				return fmt.Errorf("unable to read file [%s] from archive - %w", header.Name, err)
+			}
+
+			mode := os.FileMode(header.Mode)&fileModeMask | minimumFileMode
+			if err := os.WriteFile(path, content, mode); err != nil {
+				return fmt.Errorf("unable to write file [%s] - %w", path, err)
+			}
+		}
+	}
+}
+
+// CommitTime returns the time of the commit which is checked out in the git repository which
+// contains a directory, using the local git command.
+func CommitTime(directory string) (time.Time, error) {
+	timestamp, err := run(directory, "log", "-1", "--format=%ct", "HEAD")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to find commit time for directory [%s] - %w", directory, err)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse commit time [%s] for directory [%s] - %w", timestamp, directory, err)
+	}
+
+	return time.Unix(seconds, 0).UTC(), nil
+}
+
+// run runs a git command in a directory and returns its trimmed output.
+func run(directory string, arguments ...string) (string, error) {
+	out, err := output(directory, arguments...)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// output runs a git command in a directory and returns its output.
+func output(directory string, arguments ...string) ([]byte, error) {
+	stderr := &bytes.Buffer{}
+
+	gitCommand := exec.Command(command, append([]string{"-C", directory}, arguments...)...)
+	gitCommand.Stderr = stderr
+
+	out, err := gitCommand.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s - %w - %s", strings.Join(arguments, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
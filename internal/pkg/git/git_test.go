@@ -0,0 +1,168 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newRepository creates a repository with a committed file within a nested directory and
+// returns the paths of the repository and the file.
+func newRepository(t *testing.T) (string, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath(command); err != nil {
+		t.Skip("git is not available")
+	}
+
+	repository := t.TempDir()
+
+	for _, arguments := range [][]string{
+		{"init", "--quiet"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "test"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		if _, err := run(repository, arguments...); err != nil {
+			t.Fatalf("unable to initialize repository - %v", err)
+		}
+	}
+
+	directory := filepath.Join(repository, "nested")
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(directory, "file.go")
+	if err := os.WriteFile(file, []byte("committed"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, arguments := range [][]string{{"add", "--all"}, {"commit", "--quiet", "--message", "test"}} {
+		if _, err := run(repository, arguments...); err != nil {
+			t.Fatalf("unable to commit to repository - %v", err)
+		}
+	}
+
+	return repository, file
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+
+	// create a repository with a committed file and a modification which is not committed
+	repository, file := newRepository(t)
+	directory := filepath.Dir(file)
+
+	if err := os.WriteFile(file, []byte("modified"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		revision string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "ensure directory is exported at the revision",
+			revision: "HEAD",
+			want:     "committed",
+			wantErr:  false,
+		},
+		{
+			name:     "ensure missing revision returns an error",
+			revision: "missing",
+			wantErr:  true,
+		},
+		{
+			name:     "ensure revision which is an option returns an error",
+			revision: "--output=" + filepath.Join(repository, "archive.tar"),
+			wantErr:  true,
+		},
+		{
+			name:     "ensure revision which is not a commit returns an error",
+			revision: "HEAD:nested",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exported, cleanup, err := Export(directory, tt.revision)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Export() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			defer cleanup()
+
+			got, err := os.ReadFile(filepath.Join(exported, "file.go"))
+			if err != nil {
+				t.Fatalf("Export() did not export file - %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Export() content = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitTime(t *testing.T) {
+	t.Parallel()
+
+	before := time.Now().Truncate(time.Second)
+	repository, file := newRepository(t)
+	after := time.Now()
+
+	tests := []struct {
+		name      string
+		directory string
+		wantErr   bool
+	}{
+		{
+			name:      "ensure commit time is found for a nested directory",
+			directory: filepath.Dir(file),
+			wantErr:   false,
+		},
+		{
+			name:      "ensure commit time is found for the repository",
+			directory: repository,
+			wantErr:   false,
+		},
+		{
+			name:      "ensure directory outside of a repository returns an error",
+			directory: t.TempDir(),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := CommitTime(tt.directory)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CommitTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.Before(before) || got.After(after) {
+				t.Errorf("CommitTime() = %v, want between %v and %v", got, before, after)
+			}
+		})
+	}
+}
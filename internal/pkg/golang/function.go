@@ -0,0 +1,117 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+const (
+	functionMain = "main"
+	functionInit = "init"
+)
+
+// Function represents a function or method declaration within Go source code.
+type Function struct {
+	Package     string
+	PackageName string
+	Receiver    string
+	Name        string
+	File        string
+	StartLine   int
+	EndLine     int
+
+	references []reference
+}
+
+// reference represents a reference from within a function body to another function.  A
+// reference is either a package-qualified function (including functions within the same
+// package) or a method, which is referenced only by name.
+type reference struct {
+	pkg    string
+	name   string
+	method bool
+}
+
+// ID returns the unique identifier of a function, which is its fully qualified name.
+func (function *Function) ID() string {
+	if function.Receiver == "" {
+		return fmt.Sprintf("%s.%s", function.Package, function.Name)
+	}
+
+	return fmt.Sprintf("%s.(%s).%s", function.Package, function.Receiver, function.Name)
+}
+
+// String returns the short name of a function, including its receiver if it is a method.
+func (function *Function) String() string {
+	if function.Receiver == "" {
+		return function.Name
+	}
+
+	return fmt.Sprintf("(%s).%s", function.Receiver, function.Name)
+}
+
+// IsMethod returns whether or not a function is a method.
+func (function *Function) IsMethod() bool {
+	return function.Receiver != ""
+}
+
+// Contains returns whether or not a line is within the function declaration, including its
+// doc comment.
+func (function *Function) Contains(line int) bool {
+	return line >= function.StartLine && line <= function.EndLine
+}
+
+// receiverString returns the string representation of a method receiver type.
+func receiverString(expression ast.Expr) string {
+	switch receiver := expression.(type) {
+	case *ast.Ident:
+		return receiver.Name
+	case *ast.StarExpr:
+		return "*" + receiverString(receiver.X)
+	case *ast.IndexExpr:
+		return receiverString(receiver.X)
+	case *ast.IndexListExpr:
+		return receiverString(receiver.X)
+	case *ast.ParenExpr:
+		return receiverString(receiver.X)
+	default:
+		return ""
+	}
+}
+
+// collectReferences collects all function and method references from a node.  Any identifier
+// is considered a possible reference to a function in the same package, any selector on an
+// imported package is considered a reference to a function in that package, and any other
+// selector is considered a possible reference to a method.
+func collectReferences(node ast.Node, pkg string, imports map[string]string) []reference {
+	references := []reference{}
+
+	var inspect func(ast.Node) bool
+
+	inspect = func(n ast.Node) bool {
+		switch expression := n.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := expression.X.(*ast.Ident); ok {
+				if importPath, found := imports[ident.Name]; found {
+					references = append(references, reference{pkg: importPath, name: expression.Sel.Name})
+
+					return false
+				}
+			}
+
+			references = append(references, reference{name: expression.Sel.Name, method: true})
+
+			ast.Inspect(expression.X, inspect)
+
+			return false
+		case *ast.Ident:
+			references = append(references, reference{pkg: pkg, name: expression.Name})
+		}
+
+		return true
+	}
+
+	ast.Inspect(node, inspect)
+
+	return references
+}
@@ -0,0 +1,348 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	extensionGo       = ".go"
+	suffixTest        = "_test.go"
+	directoryVendor   = "vendor"
+	directoryTestdata = "testdata"
+)
+
+var (
+	ErrPackageMissing     = errors.New("unable to find package")
+	ErrPackageNotMain     = errors.New("package is not a main package")
+	ErrPackageMissingMain = errors.New("package is missing main function")
+)
+
+// Package represents a Go package within a module.
+type Package struct {
+	Path      string
+	Name      string
+	Directory string
+	Imports   []string
+	Functions []*Function
+
+	// references are references from package-level declarations, such as variables, to
+	// functions.  these are considered reachable whenever the package is reachable.
+	references []reference
+}
+
+// Index represents an index of all functions within a Go module.  It is used to find the
+// function which encloses a particular line of source code and to determine which functions
+// are reachable from a given main package.
+type Index struct {
+	Module *Module
+
+	packages    map[string]*Package
+	directories map[string]*Package
+	files       map[string][]*Function
+}
+
+// parsedFile represents a single parsed file prior to indexing.
+type parsedFile struct {
+	path string
+	file *ast.File
+}
+
+// NewIndex creates a new index of all non-test Go source files within a module.
+func NewIndex(module *Module) (*Index, error) {
+	fileSet := token.NewFileSet()
+	parsed := map[string][]parsedFile{}
+
+	err := filepath.WalkDir(module.Directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if skipDirectory(module.Directory, filePath, entry.Name()) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !strings.HasSuffix(filePath, extensionGo) || strings.HasSuffix(filePath, suffixTest) {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fileSet, filePath, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("unable to parse go file [%s] - %w", filePath, err)
+		}
+
+		directory := filepath.Dir(filePath)
+		parsed[directory] = append(parsed[directory], parsedFile{path: filePath, file: file})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to index module [%s] - %w", module.Path, err)
+	}
+
+	index := &Index{
+		Module:      module,
+		packages:    map[string]*Package{},
+		directories: map[string]*Package{},
+		files:       map[string][]*Function{},
+	}
+
+	// create the packages first so that import names may be resolved to the
+	// actual package name of the imported package.
+	for directory, parsedFiles := range parsed {
+		pkg := &Package{
+			Path:      module.ImportPath(directory),
+			Name:      parsedFiles[0].file.Name.Name,
+			Directory: directory,
+		}
+
+		index.packages[pkg.Path] = pkg
+		index.directories[directory] = pkg
+	}
+
+	for directory, parsedFiles := range parsed {
+		for i := range parsedFiles {
+			index.add(fileSet, index.directories[directory], parsedFiles[i])
+		}
+	}
+
+	return index, nil
+}
+
+// Enclosing returns the function which encloses a given line of a given file.  It returns
+// nil if the line is not within a function or the file is not part of the index.
+func (index *Index) Enclosing(file string, line int) *Function {
+	absolutePath, err := filepath.Abs(file)
+	if err != nil {
+		return nil
+	}
+
+	for _, function := range index.files[absolutePath] {
+		if function.Contains(line) {
+			return function
+		}
+	}
+
+	return nil
+}
+
+// Package returns the package located at a given directory.  It returns nil if the directory
+// does not contain a package that is part of the index.
+func (index *Index) Package(directory string) *Package {
+	absolutePath, err := filepath.Abs(directory)
+	if err != nil {
+		return nil
+	}
+
+	return index.directories[absolutePath]
+}
+
+// Reachable returns the set of functions which are reachable from the main package located at
+// a given directory.  The call graph is built statically without type information, so it is
+// conservative: a method call is assumed to reach every method of the same name within any
+// package linked into the program.
+func (index *Index) Reachable(directory string) (map[*Function]bool, error) {
+	entrypoint := index.Package(directory)
+	if entrypoint == nil {
+		return nil, fmt.Errorf("%w - [%s]", ErrPackageMissing, directory)
+	}
+
+	if entrypoint.Name != functionMain {
+		return nil, fmt.Errorf("%w - [%s]", ErrPackageNotMain, directory)
+	}
+
+	// collect the packages which are linked into the program along with their
+	// functions, by name, and methods, by method name.
+	linked := index.imported(entrypoint)
+	functions := map[string][]*Function{}
+	methods := map[string][]*Function{}
+
+	for _, pkg := range linked {
+		for _, function := range pkg.Functions {
+			if function.IsMethod() {
+				methods[function.Name] = append(methods[function.Name], function)
+
+				continue
+			}
+
+			key := pkg.Path + "." + function.Name
+			functions[key] = append(functions[key], function)
+		}
+	}
+
+	if len(functions[entrypoint.Path+"."+functionMain]) == 0 {
+		return nil, fmt.Errorf("%w - [%s]", ErrPackageMissingMain, directory)
+	}
+
+	// the program begins at the main function along with package initialization
+	// of every linked package.
+	queue := []reference{{pkg: entrypoint.Path, name: functionMain}}
+
+	for _, pkg := range linked {
+		queue = append(queue, reference{pkg: pkg.Path, name: functionInit})
+		queue = append(queue, pkg.references...)
+	}
+
+	reachable := map[*Function]bool{}
+
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+
+		candidates := functions[ref.pkg+"."+ref.name]
+		if ref.method {
+			candidates = methods[ref.name]
+		}
+
+		for _, function := range candidates {
+			if reachable[function] {
+				continue
+			}
+
+			reachable[function] = true
+			queue = append(queue, function.references...)
+		}
+	}
+
+	return reachable, nil
+}
+
+// imported returns the given package along with all packages, within the module, which it
+// transitively imports.
+func (index *Index) imported(root *Package) []*Package {
+	seen := map[string]bool{root.Path: true}
+	queue := []*Package{root}
+	linked := []*Package{}
+
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		linked = append(linked, pkg)
+
+		for _, importPath := range pkg.Imports {
+			if seen[importPath] {
+				continue
+			}
+
+			seen[importPath] = true
+
+			if imported, found := index.packages[importPath]; found {
+				queue = append(queue, imported)
+			}
+		}
+	}
+
+	sort.Slice(linked, func(i, j int) bool { return linked[i].Path < linked[j].Path })
+
+	return linked
+}
+
+// add adds a parsed file to the index.
+func (index *Index) add(fileSet *token.FileSet, pkg *Package, parsed parsedFile) {
+	// resolve the names of the imports for this file so that we may determine which
+	// selectors refer to functions within other packages.
+	imports := map[string]string{}
+
+	for _, spec := range parsed.file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if imported, found := index.packages[importPath]; found {
+			name = imported.Name
+		}
+
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+
+		imports[name] = importPath
+
+		if !containsString(pkg.Imports, importPath) {
+			pkg.Imports = append(pkg.Imports, importPath)
+		}
+	}
+
+	for _, declaration := range parsed.file.Decls {
+		switch decl := declaration.(type) {
+		case *ast.FuncDecl:
+			function := &Function{
+				Package:     pkg.Path,
+				PackageName: pkg.Name,
+				Name:        decl.Name.Name,
+				File:        parsed.path,
+				StartLine:   fileSet.Position(decl.Pos()).Line,
+				EndLine:     fileSet.Position(decl.End()).Line,
+			}
+
+			if decl.Doc != nil {
+				function.StartLine = fileSet.Position(decl.Doc.Pos()).Line
+			}
+
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				function.Receiver = receiverString(decl.Recv.List[0].Type)
+			}
+
+			if decl.Body != nil {
+				function.references = collectReferences(decl.Body, pkg.Path, imports)
+			}
+
+			pkg.Functions = append(pkg.Functions, function)
+			index.files[parsed.path] = append(index.files[parsed.path], function)
+		case *ast.GenDecl:
+			if decl.Tok == token.VAR {
+				pkg.references = append(pkg.references, collectReferences(decl, pkg.Path, imports)...)
+			}
+		}
+	}
+}
+
+// skipDirectory determines whether or not a directory should be skipped when indexing a
+// module.  Hidden directories, vendor directories, testdata directories and nested modules
+// are skipped.
+func skipDirectory(root, directory, name string) bool {
+	if directory == root {
+		return false
+	}
+
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+		return true
+	}
+
+	if name == directoryVendor || name == directoryTestdata {
+		return true
+	}
+
+	if _, err := os.Stat(filepath.Join(directory, moduleFile)); err == nil {
+		return true
+	}
+
+	return false
+}
+
+// containsString determines whether or not a slice of strings contains a value.
+func containsString(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,152 @@
+package golang
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func testIndex(t *testing.T) *Index {
+	t.Helper()
+
+	module, err := FindModule(thisFilePathFor("test/input/module"))
+	if err != nil {
+		t.Fatalf("FindModule() error = %v", err)
+	}
+
+	index, err := NewIndex(module)
+	if err != nil {
+		t.Fatalf("NewIndex() error = %v", err)
+	}
+
+	return index
+}
+
+func TestIndex_Enclosing(t *testing.T) {
+	t.Parallel()
+
+	index := testIndex(t)
+
+	tests := []struct {
+		name string
+		file string
+		line int
+		want string
+	}{
+		{
+			name: "ensure marker in a doc comment returns the method",
+			file: thisFilePathFor("test/input/module/pkg/cloud/cloud.go"),
+			line: 13,
+			want: "example.com/module/pkg/cloud.(*Client).Create",
+		},
+		{
+			name: "ensure marker in a function body returns the method",
+			file: thisFilePathFor("test/input/module/pkg/cloud/cloud.go"),
+			line: 20,
+			want: "example.com/module/pkg/cloud.(*Client).Delete",
+		},
+		{
+			name: "ensure marker outside of a function returns nothing",
+			file: thisFilePathFor("test/input/module/pkg/cloud/cloud.go"),
+			line: 27,
+			want: "",
+		},
+		{
+			name: "ensure marker in an unknown file returns nothing",
+			file: thisFilePathFor("test/input/module/missing.go"),
+			line: 1,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			if function := index.Enclosing(tt.file, tt.line); function != nil {
+				got = function.ID()
+			}
+
+			if got != tt.want {
+				t.Errorf("Index.Enclosing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex_Reachable(t *testing.T) {
+	t.Parallel()
+
+	index := testIndex(t)
+
+	tests := []struct {
+		name      string
+		directory string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "ensure functions reachable from the installer are returned",
+			directory: thisFilePathFor("test/input/module/cmd/installer"),
+			want: []string{
+				"example.com/module/cmd/installer.main",
+				"example.com/module/pkg/cloud.(*Client).Create",
+				"example.com/module/pkg/cloud.(*Client).tag",
+				"example.com/module/pkg/cloud.NewClient",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ensure functions reachable from the uninstaller through package variables and aliases are returned",
+			directory: thisFilePathFor("test/input/module/cmd/uninstaller"),
+			want: []string{
+				"example.com/module/cmd/uninstaller.destroy",
+				"example.com/module/cmd/uninstaller.main",
+				"example.com/module/pkg/cloud.(*Client).Delete",
+				"example.com/module/pkg/cloud.NewClient",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "ensure non-main package returns an error",
+			directory: thisFilePathFor("test/input/module/pkg/cloud"),
+			wantErr:   true,
+		},
+		{
+			name:      "ensure missing package returns an error",
+			directory: thisFilePathFor("test/input/module/pkg/missing"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reachable, err := index.Reachable(tt.directory)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Index.Reachable() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got := []string{}
+			for function := range reachable {
+				got = append(got, function.ID())
+			}
+
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Index.Reachable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package golang
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	moduleFile      = "go.mod"
+	moduleDirective = "module"
+)
+
+var (
+	ErrModuleMissing     = errors.New("unable to find go.mod file")
+	ErrModuleMissingPath = errors.New("go.mod file is missing module directive")
+)
+
+// Module represents a Go module on the local filesystem.
+type Module struct {
+	Path      string
+	Directory string
+}
+
+// FindModule finds the module which contains a given path by walking up the directory
+// tree until a go.mod file is found.
+func FindModule(path string) (*Module, error) {
+	directory, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine absolute path for [%s] - %w", path, err)
+	}
+
+	for {
+		modulePath, err := readModulePath(filepath.Join(directory, moduleFile))
+		if err == nil {
+			return &Module{Path: modulePath, Directory: directory}, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		parent := filepath.Dir(directory)
+		if parent == directory {
+			return nil, fmt.Errorf("%w - [%s]", ErrModuleMissing, path)
+		}
+
+		directory = parent
+	}
+}
+
+// ImportPath returns the import path of a package given its directory.  It returns an
+// empty string if the directory does not belong to the module.
+func (module *Module) ImportPath(directory string) string {
+	relative, err := filepath.Rel(module.Directory, directory)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return ""
+	}
+
+	if relative == "." {
+		return module.Path
+	}
+
+	return fmt.Sprintf("%s/%s", module.Path, filepath.ToSlash(relative))
+}
+
+// readModulePath reads the module path from a go.mod file.
+func readModulePath(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to open file [%s] - %w", path, err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == moduleDirective {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("unable to read file [%s] - %w", path, err)
+	}
+
+	return "", fmt.Errorf("%w - [%s]", ErrModuleMissingPath, path)
+}
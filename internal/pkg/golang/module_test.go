@@ -0,0 +1,86 @@
+package golang
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func thisFilePath() string {
+	_, file, _, ok := runtime.Caller(1)
+	if !ok {
+		return "."
+	}
+
+	absPath, err := filepath.Abs(file)
+	if err != nil {
+		return "."
+	}
+
+	return filepath.Dir(absPath)
+}
+
+func thisFilePathFor(file string) string {
+	dirPath := thisFilePath()
+
+	return fmt.Sprintf("%s/%s", dirPath, file)
+}
+
+func TestFindModule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		want    *Module
+		wantErr bool
+	}{
+		{
+			name: "ensure module is found from the module directory",
+			path: thisFilePathFor("test/input/module"),
+			want: &Module{
+				Path:      "example.com/module",
+				Directory: thisFilePathFor("test/input/module"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure module is found from a nested directory",
+			path: thisFilePathFor("test/input/module/pkg/cloud"),
+			want: &Module{
+				Path:      "example.com/module",
+				Directory: thisFilePathFor("test/input/module"),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ensure missing module returns an error",
+			path:    "/",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FindModule(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FindModule() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.want == nil {
+				return
+			}
+
+			if got.Path != tt.want.Path || got.Directory != tt.want.Directory {
+				t.Errorf("FindModule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package main
+
+import (
+	"example.com/module/pkg/cloud"
+)
+
+func main() {
+	client := cloud.NewClient()
+	client.Create()
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	awscloud "example.com/module/pkg/cloud"
+)
+
+var run = destroy
+
+func main() {
+	run()
+}
+
+func destroy() {
+	client := awscloud.NewClient()
+	client.Delete()
+}
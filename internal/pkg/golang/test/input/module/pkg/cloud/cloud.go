@@ -0,0 +1,27 @@
+package cloud
+
+// Client is a client for a cloud provider.
+type Client struct{}
+
+// NewClient returns a new client.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// Create creates a bucket.
+//
+// +policy-gen:aws:iam:policy:name=module,action=`s3:CreateBucket`,reason=`create bucket`
+func (client *Client) Create() {
+	client.tag()
+}
+
+// Delete deletes a bucket.
+func (client *Client) Delete() {
+	// +policy-gen:aws:iam:policy:name=module,action=`s3:DeleteBucket`,reason=`delete bucket`
+}
+
+func (client *Client) tag() {
+	// +policy-gen:aws:iam:policy:name=module,action=`s3:PutBucketTagging`,reason=`tag bucket`
+}
+
+// +policy-gen:aws:iam:policy:name=module,action=`s3:ListBucket`,reason=`outside of function`
@@ -0,0 +1,6 @@
+package unused
+
+// Unused is never called.
+func Unused() {
+	// +policy-gen:aws:iam:policy:name=module,action=`s3:DeleteObject`,reason=`unused`
+}
@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	effectDeny = "Deny"
+
+	// wildcardService is the service of actions without a service prefix, such as "*".
+	wildcardService = "*"
+)
+
+// node kinds.
+const (
+	KindPolicy   = "policy"
+	KindService  = "service"
+	KindAction   = "action"
+	KindResource = "resource"
+)
+
+// Graph represents the policies generated from a set of markers as a graph of policies, the
+// services which they use, the actions of each service and the resources of each action.
+// Services and actions belong to a single policy, while resources are shared between policies
+// so that policies which use the same resources are connected.
+type Graph struct {
+	Policies  []*Cluster
+	Resources []*Node
+	Edges     []*Edge
+
+	nodes map[string]*Node
+	edges map[string]bool
+}
+
+// Cluster represents the nodes which belong to a single policy.
+type Cluster struct {
+	Policy *Node
+	Nodes  []*Node
+}
+
+// Node represents a policy, service, action or resource.
+type Node struct {
+	ID    string
+	Kind  string
+	Label string
+}
+
+// Edge represents a connection between two nodes.  Edges from an action to a resource have the
+// effect of the marker which connects them, while every other edge has no effect.
+type Edge struct {
+	From   *Node
+	To     *Node
+	Effect string
+}
+
+// IsDeny returns whether the edge denies an action on a resource.
+func (edge *Edge) IsDeny() bool {
+	return edge.Effect == effectDeny
+}
+
+// New returns the graph of a map of policy files to their markers.  Policies are ordered by
+// name, and their services, actions and resources by the order in which they were found.
+func New(markerMap policy.MarkerMap) *Graph {
+	graph := &Graph{
+		Policies:  []*Cluster{},
+		Resources: []*Node{},
+		Edges:     []*Edge{},
+		nodes:     map[string]*Node{},
+		edges:     map[string]bool{},
+	}
+
+	paths := make([]string, 0, len(markerMap))
+	for path := range markerMap {
+		if len(markerMap[path]) > 0 {
+			paths = append(paths, path)
+		}
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return markerMap[paths[i]][0].GetName() < markerMap[paths[j]][0].GetName()
+	})
+
+	for _, path := range paths {
+		name := markerMap[path][0].GetName()
+
+		cluster := &Cluster{Nodes: []*Node{}}
+		cluster.Policy, _ = graph.node(KindPolicy, name, name)
+
+		for _, marker := range markerMap[path] {
+			action := marker.PermissionColumn()
+
+			service := wildcardService
+			if prefix, _, found := strings.Cut(action, ":"); found {
+				service = strings.ToLower(prefix)
+			}
+
+			serviceNode, created := graph.node(KindService, name+"/"+service, service)
+			if created {
+				cluster.Nodes = append(cluster.Nodes, serviceNode)
+			}
+
+			actionNode, created := graph.node(KindAction, name+"/"+action, action)
+			if created {
+				cluster.Nodes = append(cluster.Nodes, actionNode)
+			}
+
+			resourceNode, created := graph.node(KindResource, marker.ResourceColumn(), marker.ResourceColumn())
+			if created {
+				graph.Resources = append(graph.Resources, resourceNode)
+			}
+
+			graph.edge(cluster.Policy, serviceNode, "")
+			graph.edge(serviceNode, actionNode, "")
+			graph.edge(actionNode, resourceNode, marker.EffectColumn())
+		}
+
+		graph.Policies = append(graph.Policies, cluster)
+	}
+
+	return graph
+}
+
+// node returns the node of a kind with a key, creating it if it does not exist, along with
+// whether it was created.
+func (graph *Graph) node(kind, key, label string) (*Node, bool) {
+	if existing, found := graph.nodes[kind+"|"+key]; found {
+		return existing, false
+	}
+
+	created := &Node{ID: "n" + strconv.Itoa(len(graph.nodes)), Kind: kind, Label: label}
+	graph.nodes[kind+"|"+key] = created
+
+	return created, true
+}
+
+// edge adds an edge between two nodes with an effect unless it already exists.
+func (graph *Graph) edge(from, to *Node, effect string) {
+	key := from.ID + "|" + to.ID + "|" + effect
+	if graph.edges[key] {
+		return
+	}
+
+	graph.edges[key] = true
+	graph.Edges = append(graph.Edges, &Edge{From: from, To: to, Effect: effect})
+}
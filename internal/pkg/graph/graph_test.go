@@ -0,0 +1,241 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+// marker is a fake marker which overrides the columns used to build a graph.
+type marker struct {
+	policy.Marker
+
+	name, action, effect, resource string
+}
+
+func (m *marker) GetName() string          { return m.name }
+func (m *marker) PermissionColumn() string { return m.action }
+func (m *marker) EffectColumn() string     { return m.effect }
+func (m *marker) ResourceColumn() string   { return m.resource }
+
+func newGraph() *Graph {
+	return New(policy.MarkerMap{
+		"writer.json": {
+			&marker{Marker: policy.NewFakeMarker(), name: "writer", action: "s3:PutObject", effect: "Allow", resource: "arn:aws:s3:::bucket/*"},
+			&marker{Marker: policy.NewFakeMarker(), name: "writer", action: "s3:DeleteObject", effect: "Deny", resource: "arn:aws:s3:::bucket/*"},
+		},
+		"reader.json": {
+			&marker{Marker: policy.NewFakeMarker(), name: "reader", action: "s3:GetObject", effect: "Allow", resource: "arn:aws:s3:::bucket/*"},
+			&marker{Marker: policy.NewFakeMarker(), name: "reader", action: "s3:GetObject", effect: "Allow", resource: "arn:aws:s3:::bucket/*"},
+		},
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	graph := newGraph()
+
+	labels := func(nodes []*Node) []string {
+		result := []string{}
+		for _, node := range nodes {
+			result = append(result, node.Label)
+		}
+
+		return result
+	}
+
+	policies := []string{}
+	for _, cluster := range graph.Policies {
+		policies = append(policies, cluster.Policy.Label)
+	}
+
+	if want := []string{"reader", "writer"}; !reflect.DeepEqual(policies, want) {
+		t.Errorf("New() policies = %v, want %v", policies, want)
+	}
+
+	if want := []string{"s3", "s3:PutObject", "s3:DeleteObject"}; !reflect.DeepEqual(labels(graph.Policies[1].Nodes), want) {
+		t.Errorf("New() writer nodes = %v, want %v", labels(graph.Policies[1].Nodes), want)
+	}
+
+	if want := []string{"arn:aws:s3:::bucket/*"}; !reflect.DeepEqual(labels(graph.Resources), want) {
+		t.Errorf("New() resources = %v, want %v", labels(graph.Resources), want)
+	}
+
+	// duplicate markers of the reader policy must not duplicate its edges
+	if len(graph.Edges) != 8 {
+		t.Errorf("New() edges = %d, want %d", len(graph.Edges), 8)
+	}
+
+	denied := 0
+
+	for _, edge := range graph.Edges {
+		if edge.IsDeny() {
+			denied++
+		}
+	}
+
+	if denied != 1 {
+		t.Errorf("New() denied edges = %d, want %d", denied, 1)
+	}
+}
+
+func TestFormatFor(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "ensure dot files are rendered as dot",
+			path: "graph.dot",
+			want: FormatDOT,
+		},
+		{
+			name: "ensure graphviz files are rendered as dot",
+			path: "graph.GV",
+			want: FormatDOT,
+		},
+		{
+			name: "ensure mermaid files are rendered as mermaid",
+			path: "graph.mmd",
+			want: FormatMermaid,
+		},
+		{
+			name: "ensure markdown files are rendered as markdown",
+			path: "docs/graph.md",
+			want: FormatMarkdown,
+		},
+		{
+			name:    "ensure unknown extensions return an error",
+			path:    "graph.png",
+			wantErr: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FormatFor(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FormatFor() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("FormatFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGraph_Render(t *testing.T) {
+	t.Parallel()
+
+	single := New(policy.MarkerMap{
+		"test.json": {
+			&marker{Marker: policy.NewFakeMarker(), name: "test", action: "s3:GetObject", effect: "Allow", resource: "*"},
+			&marker{Marker: policy.NewFakeMarker(), name: "test", action: "s3:DeleteObject", effect: "Deny", resource: "*"},
+		},
+	})
+
+	mermaid := `flowchart LR
+    subgraph policy0["test"]
+        n0(["test"])
+        n1{{"s3"}}
+        n2["s3:GetObject"]
+        n4["s3:DeleteObject"]
+    end
+    n3[("*")]
+    n0 --> n1
+    n1 --> n2
+    n2 -- Allow --> n3
+    n1 --> n4
+    n4 -. Deny .-> n3
+    linkStyle 2 stroke:#1a7f37
+    linkStyle 4 stroke:#cf222e
+`
+
+	for _, tt := range []struct {
+		name    string
+		format  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "ensure dot is rendered with clusters and denied edges dashed",
+			format: FormatDOT,
+			want: `digraph policies {
+    rankdir=LR;
+    node [fontname="Helvetica", fontsize=10];
+    edge [fontname="Helvetica", fontsize=9];
+
+    subgraph cluster_0 {
+        label="test";
+        n0 [label="test", shape=box, style=bold];
+        n1 [label="s3", shape=hexagon];
+        n2 [label="s3:GetObject", shape=box, style=rounded];
+        n4 [label="s3:DeleteObject", shape=box, style=rounded];
+    }
+
+    n3 [label="*", shape=cylinder];
+
+    n0 -> n1;
+    n1 -> n2;
+    n2 -> n3 [label="Allow", color="#1a7f37", fontcolor="#1a7f37"];
+    n1 -> n4;
+    n4 -> n3 [label="Deny", color="#cf222e", fontcolor="#cf222e", style=dashed];
+}
+`,
+		},
+		{
+			name:   "ensure mermaid is rendered with subgraphs and denied edges dotted",
+			format: FormatMermaid,
+			want:   mermaid,
+		},
+		{
+			name:   "ensure markdown is rendered as a fenced mermaid block",
+			format: FormatMarkdown,
+			want:   "```mermaid\n" + mermaid + "```\n",
+		},
+		{
+			name:    "ensure unknown formats return an error",
+			format:  "png",
+			wantErr: ErrInvalidFormat,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := single.Render(tt.format)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Render() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Render() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mermaidQuote(t *testing.T) {
+	t.Parallel()
+
+	if got, want := mermaidQuote(`say "hi"`), `"say #quot;hi#quot;"`; got != want {
+		t.Errorf("mermaidQuote() = %v, want %v", got, want)
+	}
+
+	if got, want := dotQuote(`say "hi" \`), `"say \"hi\" \\"`; got != want {
+		t.Errorf("dotQuote() = %v, want %v", got, want)
+	}
+}
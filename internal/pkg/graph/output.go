@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+const (
+	FormatDOT      = "dot"
+	FormatMermaid  = "mermaid"
+	FormatMarkdown = "markdown"
+
+	colorAllow = "#1a7f37"
+	colorDeny  = "#cf222e"
+)
+
+// FormatFor returns the format of a graph file from the extension of its path.  Markdown files
+// contain a Mermaid diagram within a fenced code block.
+func FormatFor(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".dot", ".gv":
+		return FormatDOT, nil
+	case ".mmd", ".mermaid":
+		return FormatMermaid, nil
+	case ".md":
+		return FormatMarkdown, nil
+	default:
+		return "", fmt.Errorf(
+			"%w [%s] - file extension must be one of [.dot, .gv, .mmd, .mermaid, .md]",
+			ErrInvalidFormat, path,
+		)
+	}
+}
+
+// Render renders a graph in the given format.
+func (graph *Graph) Render(format string) ([]byte, error) {
+	switch format {
+	case FormatDOT:
+		return []byte(graph.DOT()), nil
+	case FormatMermaid:
+		return []byte(graph.Mermaid()), nil
+	case FormatMarkdown:
+		return []byte(graph.Markdown()), nil
+	default:
+		return nil, fmt.Errorf(
+			"%w [%s] - must be one of [%s, %s, %s]",
+			ErrInvalidFormat, format, FormatDOT, FormatMermaid, FormatMarkdown,
+		)
+	}
+}
+
+// DOT renders a graph in the Graphviz DOT language.  Each policy is a cluster, and edges which
+// deny an action on a resource are dashed.
+func (graph *Graph) DOT() string {
+	dot := &strings.Builder{}
+
+	dot.WriteString("digraph policies {\n")
+	dot.WriteString("    rankdir=LR;\n")
+	dot.WriteString("    node [fontname=\"Helvetica\", fontsize=10];\n")
+	dot.WriteString("    edge [fontname=\"Helvetica\", fontsize=9];\n")
+
+	for i, cluster := range graph.Policies {
+		fmt.Fprintf(dot, "\n    subgraph cluster_%d {\n", i)
+		fmt.Fprintf(dot, "        label=%s;\n", dotQuote(cluster.Policy.Label))
+		fmt.Fprintf(dot, "        %s;\n", dotNode(cluster.Policy))
+
+		for _, node := range cluster.Nodes {
+			fmt.Fprintf(dot, "        %s;\n", dotNode(node))
+		}
+
+		dot.WriteString("    }\n")
+	}
+
+	if len(graph.Resources) > 0 {
+		dot.WriteString("\n")
+	}
+
+	for _, node := range graph.Resources {
+		fmt.Fprintf(dot, "    %s;\n", dotNode(node))
+	}
+
+	if len(graph.Edges) > 0 {
+		dot.WriteString("\n")
+	}
+
+	for _, edge := range graph.Edges {
+		switch {
+		case edge.Effect == "":
+			fmt.Fprintf(dot, "    %s -> %s;\n", edge.From.ID, edge.To.ID)
+		case edge.IsDeny():
+			fmt.Fprintf(dot, "    %s -> %s [label=%s, color=%q, fontcolor=%q, style=dashed];\n",
+				edge.From.ID, edge.To.ID, dotQuote(edge.Effect), colorDeny, colorDeny)
+		default:
+			fmt.Fprintf(dot, "    %s -> %s [label=%s, color=%q, fontcolor=%q];\n",
+				edge.From.ID, edge.To.ID, dotQuote(edge.Effect), colorAllow, colorAllow)
+		}
+	}
+
+	dot.WriteString("}\n")
+
+	return dot.String()
+}
+
+// Mermaid renders a graph as a Mermaid flowchart.  Each policy is a subgraph, and edges which
+// deny an action on a resource are dotted.
+func (graph *Graph) Mermaid() string {
+	mermaid := &strings.Builder{}
+
+	mermaid.WriteString("flowchart LR\n")
+
+	for i, cluster := range graph.Policies {
+		fmt.Fprintf(mermaid, "    subgraph policy%d[%s]\n", i, mermaidQuote(cluster.Policy.Label))
+		fmt.Fprintf(mermaid, "        %s\n", mermaidNode(cluster.Policy))
+
+		for _, node := range cluster.Nodes {
+			fmt.Fprintf(mermaid, "        %s\n", mermaidNode(node))
+		}
+
+		mermaid.WriteString("    end\n")
+	}
+
+	for _, node := range graph.Resources {
+		fmt.Fprintf(mermaid, "    %s\n", mermaidNode(node))
+	}
+
+	allow, deny := []string{}, []string{}
+
+	for i, edge := range graph.Edges {
+		switch {
+		case edge.Effect == "":
+			fmt.Fprintf(mermaid, "    %s --> %s\n", edge.From.ID, edge.To.ID)
+		case edge.IsDeny():
+			fmt.Fprintf(mermaid, "    %s -. %s .-> %s\n", edge.From.ID, edge.Effect, edge.To.ID)
+
+			deny = append(deny, fmt.Sprint(i))
+		default:
+			fmt.Fprintf(mermaid, "    %s -- %s --> %s\n", edge.From.ID, edge.Effect, edge.To.ID)
+
+			allow = append(allow, fmt.Sprint(i))
+		}
+	}
+
+	if len(allow) > 0 {
+		fmt.Fprintf(mermaid, "    linkStyle %s stroke:%s\n", strings.Join(allow, ","), colorAllow)
+	}
+
+	if len(deny) > 0 {
+		fmt.Fprintf(mermaid, "    linkStyle %s stroke:%s\n", strings.Join(deny, ","), colorDeny)
+	}
+
+	return mermaid.String()
+}
+
+// Markdown renders a graph as a Mermaid flowchart within a fenced code block, which may be
+// embedded in Markdown documentation.
+func (graph *Graph) Markdown() string {
+	return "```mermaid\n" + graph.Mermaid() + "```\n"
+}
+
+// dotNode returns the statement which declares a node in the DOT language, with a shape for
+// each kind of node.
+func dotNode(node *Node) string {
+	shape := map[string]string{
+		KindPolicy:   "box, style=bold",
+		KindService:  "hexagon",
+		KindAction:   "box, style=rounded",
+		KindResource: "cylinder",
+	}[node.Kind]
+
+	return fmt.Sprintf("%s [label=%s, shape=%s]", node.ID, dotQuote(node.Label), shape)
+}
+
+// dotQuote returns a quoted string in the DOT language.
+func dotQuote(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// mermaidNode returns the statement which declares a node in a Mermaid flowchart, with a shape
+// for each kind of node.
+func mermaidNode(node *Node) string {
+	label := mermaidQuote(node.Label)
+
+	switch node.Kind {
+	case KindPolicy:
+		return fmt.Sprintf("%s([%s])", node.ID, label)
+	case KindService:
+		return fmt.Sprintf("%s{{%s}}", node.ID, label)
+	case KindResource:
+		return fmt.Sprintf("%s[(%s)]", node.ID, label)
+	default:
+		return fmt.Sprintf("%s[%s]", node.ID, label)
+	}
+}
+
+// mermaidQuote returns a quoted label in a Mermaid flowchart, so that characters such as
+// colons and wildcards are not interpreted as syntax.
+func mermaidQuote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,188 @@
+package guardrails
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/wildcard"
+)
+
+var (
+	ErrInvalidGuardrails = errors.New("invalid guardrails")
+)
+
+const (
+	RuleForbiddenAction    = "guardrail-forbidden-action"
+	RuleUnapprovedAction   = "guardrail-unapproved-action"
+	RuleUnapprovedResource = "guardrail-unapproved-resource"
+)
+
+// Guardrails represents a set of organization-wide constraints on the permissions which markers
+// may grant.  Guardrails are intended to be kept in a single file which is shared between
+// repositories.  Only markers which allow their action are constrained, as denying an action
+// never widens a policy.
+type Guardrails struct {
+	// ForbiddenActions are patterns of actions which no marker may allow.
+	ForbiddenActions []string `yaml:"forbiddenActions,omitempty"`
+
+	// Policies are the sets of actions which markers of matching policy names may allow.
+	Policies []*PolicyActions `yaml:"policies,omitempty"`
+
+	// Resources are the resources which markers allowing matching actions may allow them on.
+	Resources []*ResourceConstraint `yaml:"resources,omitempty"`
+}
+
+// PolicyActions represents the set of actions which markers of policies with a matching name
+// may allow.
+type PolicyActions struct {
+	// Name is the pattern of the policy names which the actions apply to.
+	Name string `yaml:"name"`
+
+	// AllowedActions are the patterns of actions which markers of the policy may allow.
+	AllowedActions []string `yaml:"allowedActions"`
+}
+
+// ResourceConstraint represents the resources which markers allowing a set of actions may allow
+// them on.
+type ResourceConstraint struct {
+	// Actions are the patterns of actions which the constraint applies to.
+	Actions []string `yaml:"actions"`
+
+	// AllowedResources are the patterns of resources which the actions may be allowed on.
+	AllowedResources []string `yaml:"allowedResources"`
+}
+
+// Violation represents a marker which violates a guardrail.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// Error returns the string representation of a violation.  It is used to satisfy the error
+// interface.
+func (violation *Violation) Error() string {
+	return fmt.Sprintf("violates guardrail [%s] - %s", violation.Rule, violation.Message)
+}
+
+// Read reads and validates a guardrails file from a path.
+func Read(path string) (*Guardrails, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read guardrails file [%s] - %w", path, err)
+	}
+
+	guardrails, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid guardrails file [%s] - %w", path, err)
+	}
+
+	return guardrails, nil
+}
+
+// Parse parses and validates a guardrails file.  Unknown fields are rejected so that a
+// misspelled guardrail is not silently ignored.
+func Parse(data []byte) (*Guardrails, error) {
+	guardrails := &Guardrails{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(guardrails); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse guardrails - %w", err)
+	}
+
+	if err := guardrails.Validate(); err != nil {
+		return nil, err
+	}
+
+	return guardrails, nil
+}
+
+// Validate validates that a set of guardrails is complete.
+func (guardrails *Guardrails) Validate() error {
+	for i, policyActions := range guardrails.Policies {
+		if policyActions.Name == "" {
+			return fmt.Errorf("%w - policy at index [%d] is missing a name", ErrInvalidGuardrails, i)
+		}
+	}
+
+	for i, constraint := range guardrails.Resources {
+		if len(constraint.Actions) == 0 {
+			return fmt.Errorf("%w - resource constraint at index [%d] is missing actions", ErrInvalidGuardrails, i)
+		}
+
+		if len(constraint.AllowedResources) == 0 {
+			return fmt.Errorf("%w - resource constraint at index [%d] is missing allowed resources", ErrInvalidGuardrails, i)
+		}
+	}
+
+	return nil
+}
+
+// Check checks a marker against the guardrails, returning a *Violation for the first guardrail
+// that it violates.
+func (guardrails *Guardrails) Check(marker policy.Marker) error {
+	if marker.EffectColumn() == aws.ValidEffectDeny {
+		return nil
+	}
+
+	action, resource := marker.PermissionColumn(), marker.ResourceColumn()
+
+	// an action is forbidden if the marker may allow any action that it matches
+	for _, forbidden := range guardrails.ForbiddenActions {
+		if aws.Overlaps(aws.MatchAction)(forbidden, action) {
+			return &Violation{
+				Rule:    RuleForbiddenAction,
+				Message: fmt.Sprintf("action [%s] allows forbidden action [%s]", action, forbidden),
+			}
+		}
+	}
+
+	// every set of actions for a matching policy name must approve the action
+	for _, policyActions := range guardrails.Policies {
+		if !wildcard.Match(policyActions.Name, marker.GetName()) {
+			continue
+		}
+
+		if !aws.MatchesAny(policyActions.AllowedActions, action, aws.MatchAction) {
+			return &Violation{
+				Rule: RuleUnapprovedAction,
+				Message: fmt.Sprintf(
+					"action [%s] is not one of the approved actions for policy [%s]: [%s]",
+					action,
+					policyActions.Name,
+					strings.Join(policyActions.AllowedActions, ", "),
+				),
+			}
+		}
+	}
+
+	// every constraint on a matching action must approve the resource
+	for _, constraint := range guardrails.Resources {
+		if !aws.MatchesAny(constraint.Actions, action, aws.Overlaps(aws.MatchAction)) {
+			continue
+		}
+
+		if !aws.MatchesAny(constraint.AllowedResources, resource, aws.MatchResource) {
+			return &Violation{
+				Rule: RuleUnapprovedResource,
+				Message: fmt.Sprintf(
+					"resource [%s] is not one of the approved resources for actions [%s]: [%s]",
+					resource,
+					strings.Join(constraint.Actions, ", "),
+					strings.Join(constraint.AllowedResources, ", "),
+				),
+			}
+		}
+	}
+
+	return nil
+}
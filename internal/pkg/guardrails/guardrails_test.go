@@ -0,0 +1,165 @@
+package guardrails
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "ensure valid guardrails are parsed",
+			data: `
+forbiddenActions: ["organizations:*"]
+policies:
+  - name: installer-*
+    allowedActions: ["s3:*"]
+resources:
+  - actions: ["s3:*"]
+    allowedResources: ["arn:aws:s3:::company-*"]
+`,
+			wantErr: false,
+		},
+		{
+			name:    "ensure empty guardrails are parsed",
+			data:    "",
+			wantErr: false,
+		},
+		{
+			name:    "ensure unknown field returns an error",
+			data:    `forbidden: ["organizations:*"]`,
+			wantErr: true,
+		},
+		{
+			name: "ensure policy without a name returns an error",
+			data: `
+policies:
+  - allowedActions: ["s3:*"]
+`,
+			wantErr: true,
+		},
+		{
+			name: "ensure resource constraint without allowed resources returns an error",
+			data: `
+resources:
+  - actions: ["s3:*"]
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := Parse([]byte(tt.data)); (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGuardrails_Check(t *testing.T) {
+	t.Parallel()
+
+	guardrails := &Guardrails{
+		ForbiddenActions: []string{"organizations:*", "iam:CreateUser"},
+		Policies: []*PolicyActions{
+			{Name: "installer-*", AllowedActions: []string{"s3:*", "ec2:Describe*", "iam:*"}},
+		},
+		Resources: []*ResourceConstraint{
+			{Actions: []string{"s3:*"}, AllowedResources: []string{"arn:aws:s3:::company-*"}},
+		},
+	}
+
+	newMarker := func(name, action, effect, resource string) *aws.Marker {
+		return &aws.Marker{
+			Name:     pointers.String(name),
+			Action:   pointers.String(action),
+			Effect:   pointers.String(effect),
+			Resource: pointers.String(resource),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		marker   *aws.Marker
+		wantRule string
+	}{
+		{
+			name:   "ensure approved marker passes",
+			marker: newMarker("installer-local", "s3:GetObject", aws.ValidEffectAllow, "arn:aws:s3:::company-data/*"),
+		},
+		{
+			name:     "ensure forbidden action fails",
+			marker:   newMarker("other", "organizations:ListAccounts", aws.ValidEffectAllow, "*"),
+			wantRule: RuleForbiddenAction,
+		},
+		{
+			name:     "ensure wildcard allowing a forbidden action fails",
+			marker:   newMarker("installer-local", "iam:Create*", aws.ValidEffectAllow, "*"),
+			wantRule: RuleForbiddenAction,
+		},
+		{
+			name:   "ensure denying a forbidden action passes",
+			marker: newMarker("other", "organizations:*", aws.ValidEffectDeny, "*"),
+		},
+		{
+			name:     "ensure action not approved for the policy fails",
+			marker:   newMarker("installer-local", "ec2:CreateVpc", aws.ValidEffectAllow, "*"),
+			wantRule: RuleUnapprovedAction,
+		},
+		{
+			name:     "ensure wildcard broader than the approved actions fails",
+			marker:   newMarker("installer-local", "ec2:*", aws.ValidEffectAllow, "*"),
+			wantRule: RuleUnapprovedAction,
+		},
+		{
+			name:   "ensure action for a policy without approved actions passes",
+			marker: newMarker("other", "ec2:CreateVpc", aws.ValidEffectAllow, "*"),
+		},
+		{
+			name:     "ensure unapproved resource fails",
+			marker:   newMarker("other", "s3:PutObject", aws.ValidEffectAllow, "arn:aws:s3:::personal/*"),
+			wantRule: RuleUnapprovedResource,
+		},
+		{
+			name:     "ensure every resource fails a resource constraint",
+			marker:   newMarker("other", "s3:GetObject", aws.ValidEffectAllow, "*"),
+			wantRule: RuleUnapprovedResource,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := guardrails.Check(tt.marker)
+
+			if tt.wantRule == "" {
+				if err != nil {
+					t.Errorf("Guardrails.Check() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var violation *Violation
+			if !errors.As(err, &violation) || violation.Rule != tt.wantRule {
+				t.Errorf("Guardrails.Check() error = %v, want rule %v", err, tt.wantRule)
+			}
+		})
+	}
+}
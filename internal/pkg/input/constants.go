@@ -8,6 +8,50 @@ const (
 	FlagRecursive     = "recursive"
 	FlagForce         = "force"
 	FlagDebug         = "debug"
+	FlagEntrypoint    = "entrypoint"
+	FlagSourceURL     = "source-url"
+	FlagSourceRef     = "source-ref"
+	FlagSourceFormat  = "source-format"
+	FlagFormat        = "format"
+	FlagReportFile    = "report-file"
+	FlagScope         = "scope"
+	FlagName          = "name"
+	FlagPrincipalARN  = "principal-arn"
+	FlagSessionName   = "session-name"
+	FlagStartTime     = "start-time"
+	FlagEndTime       = "end-time"
+	FlagExcludeErrors = "exclude-errors"
+	FlagLastAccessed  = "last-accessed"
+	FlagDays          = "days"
+	FlagOutputFile    = "output-file"
+	FlagPackage       = "package"
+	FlagPolicy        = "policy"
+	FlagFailOn        = "fail-on"
+	FlagBaseRef       = "base-ref"
+	FlagAction        = "action"
+	FlagResource      = "resource"
+	FlagContext       = "context"
+	FlagTime          = "time"
+	FlagAssertions    = "assertions"
+	FlagGuardrails    = "guardrails"
+	FlagLedger        = "ledger"
+	FlagLedgerMode    = "ledger-mode"
+	FlagPrune         = "prune"
+	FlagExpiryWarning = "expiry-warning-days"
+	FlagOnExpired     = "on-expired"
+	FlagEnforceExpiry = "enforce-expiry"
+	FlagConfig        = "config"
+	FlagTemplate      = "template"
+	FlagInject        = "inject"
+	FlagCheck         = "check"
+	FlagLayout        = "layout"
+	FlagServiceRollup = "service-rollup"
+	FlagPerPolicyDocs = "per-policy-docs"
+	FlagSite          = "site"
+	FlagInventory     = "inventory"
+	FlagMinAccess     = "min-access-level"
+	FlagSort          = "sort"
+	FlagGraph         = "graph"
 
 	// input flag short values.
 	FlagInputPathShort     = "i"
@@ -17,18 +61,104 @@ const (
 	FlagForceShort         = "f"
 
 	// input flag default values.
-	FlagInputPathDefault     = "./"
-	FlagOutputPathDefault    = "./"
-	FlagDocumentationDefault = ""
-	FlagRecursiveDefault     = false
-	FlagForceDefault         = false
-	FlagDebugDefault         = false
+	FlagInputPathDefault      = "./"
+	FlagOutputPathDefault     = "./"
+	FlagDocumentationDefault  = ""
+	FlagRecursiveDefault      = false
+	FlagForceDefault          = false
+	FlagDebugDefault          = false
+	FlagSourceURLDefault      = ""
+	FlagSourceRefDefault      = "main"
+	FlagSourceFormatDefault   = ""
+	FlagFormatDefault         = "table"
+	FlagReportFileDefault     = ""
+	FlagScopeDefault          = "function"
+	FlagCloudTrailNameDefault = "cloudtrail"
+	FlagImportFormatDefault   = "markers"
+	FlagDaysDefault           = 90
+	FlagTextFormatDefault     = "text"
+	FlagFailOnDefault         = "any"
+	FlagResourceDefault       = "*"
+	FlagLintFailOnDefault     = "low"
+	FlagLedgerDefault         = "policy-gen.lock"
+	FlagLedgerModeDefault     = "fail"
+	FlagPruneDefault          = false
+	FlagExpiryWarningDefault  = 14
+	FlagOnExpiredDefault      = "fail"
+	FlagEnforceExpiryDefault  = false
+	FlagInjectDefault         = false
+	FlagCheckDefault          = false
+	FlagLayoutDefault         = "flat"
+	FlagSortDefault           = "source"
+	FlagServiceRollupDefault  = false
+	FlagPerPolicyDocsDefault  = false
 
 	// input flag descriptions.
-	FlagInputPathDescription     = "Input path to recursively begin parsing markers"
-	FlagOutputPathDescription    = "Output path to output generated policies"
-	FlagDocumentationDescription = "Documentation file to write"
-	FlagRecursiveDescription     = "Recursively find markers from the input-path input"
-	FlagForceDescription         = "Forcefully overwrite files with matching names"
-	FlagDebugDescription         = "Enable debug logging"
+	FlagInputPathDescription      = "Input path to recursively begin parsing markers"
+	FlagOutputPathDescription     = "Output path to output generated policies"
+	FlagDocumentationDescription  = "Documentation file to write"
+	FlagRecursiveDescription      = "Recursively find markers from the input-path input"
+	FlagForceDescription          = "Forcefully overwrite files with matching names"
+	FlagDebugDescription          = "Enable debug logging"
+	FlagEntrypointDescription     = "Go main package to generate a policy for from reachable markers, as [name=]path (may be repeated)"
+	FlagSourceURLDescription      = "Repository URL used to link documentation to the source of each marker (e.g. https://github.com/org/repo)"
+	FlagSourceRefDescription      = "Repository ref (branch, tag or commit) used to link documentation to the source of each marker"
+	FlagSourceFormatDescription   = "Format of the links to the source of each marker, with {url}, {ref}, {path} and {line} placeholders (defaults to the GitHub format)"
+	FlagReportFileDescription     = "Report file to write instead of standard output"
+	FlagScopeDescription          = "Scope in which a marker covers an SDK call (function, package or module)"
+	FlagCoverageFormatDescription = "Output format of the coverage report (table, json or sarif)"
+	FlagImportFormatDescription   = "Output format of the import (markers or policy)"
+	FlagImportInputDescription    = "Input path of existing markers to compare against, reporting only what they do not cover"
+	FlagCloudTrailNameDescription = "Policy name of the generated markers"
+	FlagPolicyNameDescription     = "Only include markers for the policy with this name"
+	FlagUnusedFormatDescription   = "Output format of the unused report (table or json)"
+	FlagLastAccessedDescription   = "File containing the output of the IAM GetServiceLastAccessedDetails API, preferably with action level granularity"
+	FlagImportNameDescription     = "Policy name of the generated markers (defaults to the policy file name)"
+	FlagOutputFileDescription     = "Output file to write the generated markers to instead of standard output"
+	FlagPackageDescription        = "Go package clause to begin the output file with, so that it may be placed in a Go package"
+	FlagPolicyDescription         = "Policy file, such as the policy deployed to production, to audit against the policy generated from markers"
+	FlagAuditNameDescription      = "Policy name, or entrypoint name, of the markers to audit the policy file against"
+	FlagAuditFormatDescription    = "Output format of the audit report (text or json)"
+	FlagFailOnDescription         = "Differences which cause the audit to fail (any, excess, missing or none)"
+	FlagBaseRefDescription        = "Git revision (branch, tag or commit) to compare the directory with, read through the local git command"
+	FlagSimulateNameDescription   = "Policy name, or entrypoint name, of the markers to simulate the request against"
+	FlagSimulatePolicyDescription = "Policy file to simulate the request against instead of markers"
+	FlagSimulateFormatDescription = "Output format of the simulation result (text or json)"
+	FlagActionDescription         = "Action of the request to simulate (e.g. s3:PutObject)"
+	FlagResourceDescription       = "Resource ARN of the request to simulate"
+	FlagContextDescription        = "Condition key of the request to simulate, as key=value (may be repeated)"
+	FlagTimeDescription           = "Time of the request to simulate, as the aws:CurrentTime condition key (RFC3339, defaults to now)"
+	FlagSimulateExpiryDescription = "Simulate against the policy as generated with --enforce-expiry, with a condition which enforces the expiry of permissions"
+	FlagGenerateFormatDescription = "Output format of the marker diagnostics (text or sarif)"
+	FlagGenerateReportDescription = "Report file to write marker diagnostics to, validating every marker before generating policies"
+	FlagGuardrailsDescription     = "Guardrails file (YAML), which may be shared between repositories, that every marker must satisfy"
+	FlagAssertionsDescription     = "Assertions file (YAML) to evaluate against the generated policies before writing them"
+	FlagLedgerDescription         = "Ledger file of approved grants to compare the generated policies with"
+	FlagLedgerModeDescription     = "Whether grants which are not approved in the ledger fail or warn (fail or warn)"
+	FlagApproveLedgerDescription  = "Ledger file of approved grants to add the grants pending approval to"
+	FlagPruneDescription          = "Remove approved grants which the markers no longer produce from the ledger"
+	FlagApproveExpiryDescription  = "Approve the grants as generated with --enforce-expiry, with a condition which enforces the expiry of permissions"
+	FlagExpiryWarningDescription  = "Number of days before a permission expires to begin warning about its expiry"
+	FlagOnExpiredDescription      = "Whether permissions which have expired fail or warn (fail or warn)"
+	FlagTemplateDescription       = "Go text/template file to render the documentation with instead of the default layout"
+	FlagInjectDescription         = "Inject the documentation between the policy-gen:begin and policy-gen:end comments of the existing documentation file"
+	FlagCheckDescription          = "Check that the policy and documentation files are up to date instead of writing them"
+	FlagInventoryDescription      = "Inventory file of every permission to write, as JSON, CSV or YAML by its file extension"
+	FlagMinAccessDescription      = "Document only the markers at or above an access level (list, read, tagging, write or permissions-management)"
+	FlagSortDescription           = "Order of the markers of the documentation (source, or access-level from the most sensitive)"
+	FlagGraphDescription          = "Graph file of the policies, services, actions and resources to write, as DOT, Mermaid or Markdown by its file extension"
+	FlagSiteDescription           = "Directory to write a self-contained static HTML site of the documentation to"
+	FlagLayoutDescription         = "Layout of the documentation (flat, or grouped by policy with a table of contents)"
+	FlagServiceRollupDescription  = "Summarize the actions of each service in the section of each policy of the documentation"
+	FlagPerPolicyDocsDescription  = "Write a documentation file for each policy next to its policy file"
+	FlagConfigDescription         = "Config file (YAML) which declares the metadata keys of markers and configures the documentation"
+	FlagEnforceExpiryDescription  = "Enforce the expiry of permissions with a DateLessThan aws:CurrentTime condition"
+	FlagLintFormatDescription     = "Output format of the lint report (table, json or sarif)"
+	FlagLintFailOnDescription     = "Minimum severity of findings which cause the lint to fail (high, medium, low or none)"
+	FlagDaysDescription           = "Number of days without use after which a permission is reported as unused"
+	FlagPrincipalARNDescription   = "Only include events from the principal, or session issuer, with this ARN (may contain wildcards)"
+	FlagSessionNameDescription    = "Only include events from assumed role sessions with this name (may contain wildcards)"
+	FlagStartTimeDescription      = "Only include events at or after this time (RFC3339)"
+	FlagEndTimeDescription        = "Only include events at or before this time (RFC3339)"
+	FlagExcludeErrorsDescription  = "Exclude events for requests which failed, such as those which were denied"
 )
@@ -2,23 +2,36 @@ package input
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
+	"github.com/scottd018/policy-gen/internal/pkg/aws/inventory"
+	"github.com/scottd018/policy-gen/internal/pkg/config"
+	"github.com/scottd018/policy-gen/internal/pkg/docs"
 	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/graph"
+	"github.com/scottd018/policy-gen/internal/pkg/guardrails"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
 	"github.com/scottd018/policy-gen/internal/pkg/processor"
 )
 
 // FlagInput represents an individual flag value as determined from user input.
 type FlagInput struct {
-	CommandFunc    func(*cobra.Command, *FlagInput)
-	StringDefault  string
-	StringValue    string
-	BooleanDefault bool
-	BooleanValue   bool
-	Description    string
-	Short          string
-	Required       bool
+	CommandFunc        func(*cobra.Command, *FlagInput)
+	StringDefault      string
+	StringValue        string
+	StringArrayDefault []string
+	StringArrayValue   []string
+	BooleanDefault     bool
+	BooleanValue       bool
+	IntDefault         int
+	IntValue           int
+	Description        string
+	Short              string
+	Required           bool
 }
 
 // Flags represents the user input into the command line.
@@ -80,7 +93,400 @@ func NewFlags() Flags {
 				command.Flags().BoolVar(&input.BooleanValue, FlagDebug, input.BooleanDefault, input.Description)
 			},
 		},
+		FlagSourceURL: &FlagInput{
+			StringDefault: FlagSourceURLDefault,
+			Description:   FlagSourceURLDescription,
+			Required:      false,
+			CommandFunc: func(command *cobra.Command, input *FlagInput) {
+				command.Flags().StringVar(&input.StringValue, FlagSourceURL, input.StringDefault, input.Description)
+			},
+		},
+		FlagSourceRef: &FlagInput{
+			StringDefault: FlagSourceRefDefault,
+			Description:   FlagSourceRefDescription,
+			Required:      false,
+			CommandFunc: func(command *cobra.Command, input *FlagInput) {
+				command.Flags().StringVar(&input.StringValue, FlagSourceRef, input.StringDefault, input.Description)
+			},
+		},
+		FlagSourceFormat: &FlagInput{
+			StringDefault: FlagSourceFormatDefault,
+			Description:   FlagSourceFormatDescription,
+			Required:      false,
+			CommandFunc: func(command *cobra.Command, input *FlagInput) {
+				command.Flags().StringVar(&input.StringValue, FlagSourceFormat, input.StringDefault, input.Description)
+			},
+		},
+		FlagConfig: &FlagInput{
+			Description: FlagConfigDescription,
+			Required:    false,
+			CommandFunc: func(command *cobra.Command, input *FlagInput) {
+				command.Flags().StringVar(&input.StringValue, FlagConfig, input.StringDefault, input.Description)
+			},
+		},
+		FlagGuardrails: &FlagInput{
+			Description: FlagGuardrailsDescription,
+			Required:    false,
+			CommandFunc: func(command *cobra.Command, input *FlagInput) {
+				command.Flags().StringVar(&input.StringValue, FlagGuardrails, input.StringDefault, input.Description)
+			},
+		},
+		FlagEntrypoint: &FlagInput{
+			Description: FlagEntrypointDescription,
+			Required:    false,
+			CommandFunc: func(command *cobra.Command, input *FlagInput) {
+				command.Flags().StringArrayVar(&input.StringArrayValue, FlagEntrypoint, input.StringArrayDefault, input.Description)
+			},
+		},
+	}
+}
+
+// NewGenerateFlags returns a new set of flags for the policy-gen aws command.
+func NewGenerateFlags() Flags {
+	flags := NewFlags()
+
+	for flag, input := range map[string]*FlagInput{
+		FlagFormat:     {StringDefault: FlagTextFormatDefault, Description: FlagGenerateFormatDescription, Required: true},
+		FlagReportFile: {StringDefault: FlagReportFileDefault, Description: FlagGenerateReportDescription},
+		FlagAssertions: {Description: FlagAssertionsDescription},
+		FlagLedger:     {Description: FlagLedgerDescription},
+		FlagLedgerMode: {StringDefault: FlagLedgerModeDefault, Description: FlagLedgerModeDescription, Required: true},
+		FlagOnExpired:  {StringDefault: FlagOnExpiredDefault, Description: FlagOnExpiredDescription, Required: true},
+		FlagTemplate:   {Description: FlagTemplateDescription},
+		FlagLayout:     {StringDefault: FlagLayoutDefault, Description: FlagLayoutDescription, Required: true},
+		FlagSite:       {Description: FlagSiteDescription},
+		FlagInventory:  {Description: FlagInventoryDescription},
+		FlagGraph:      {Description: FlagGraphDescription},
+		FlagMinAccess:  {Description: FlagMinAccessDescription},
+		FlagSort:       {StringDefault: FlagSortDefault, Description: FlagSortDescription, Required: true},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	flags[FlagExpiryWarning] = &FlagInput{
+		IntDefault:  FlagExpiryWarningDefault,
+		Description: FlagExpiryWarningDescription,
+		Required:    false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().IntVar(&input.IntValue, FlagExpiryWarning, input.IntDefault, input.Description)
+		},
+	}
+
+	flags[FlagInject] = &FlagInput{
+		BooleanDefault: FlagInjectDefault,
+		Description:    FlagInjectDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagInject, input.BooleanDefault, input.Description)
+		},
+	}
+
+	flags[FlagServiceRollup] = &FlagInput{
+		BooleanDefault: FlagServiceRollupDefault,
+		Description:    FlagServiceRollupDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagServiceRollup, input.BooleanDefault, input.Description)
+		},
+	}
+
+	flags[FlagPerPolicyDocs] = &FlagInput{
+		BooleanDefault: FlagPerPolicyDocsDefault,
+		Description:    FlagPerPolicyDocsDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagPerPolicyDocs, input.BooleanDefault, input.Description)
+		},
+	}
+
+	flags[FlagCheck] = &FlagInput{
+		BooleanDefault: FlagCheckDefault,
+		Description:    FlagCheckDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagCheck, input.BooleanDefault, input.Description)
+		},
+	}
+
+	flags[FlagEnforceExpiry] = &FlagInput{
+		BooleanDefault: FlagEnforceExpiryDefault,
+		Description:    FlagEnforceExpiryDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagEnforceExpiry, input.BooleanDefault, input.Description)
+		},
+	}
+
+	return flags
+}
+
+// NewCoverageFlags returns a new set of flags for the policy-gen aws coverage command.
+func NewCoverageFlags() Flags {
+	flags := NewFlags().Only(FlagInputPath, FlagRecursive, FlagDebug, FlagConfig)
+
+	flags[FlagFormat] = &FlagInput{
+		StringDefault: FlagFormatDefault,
+		Description:   FlagCoverageFormatDescription,
+		Required:      true,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, FlagFormat, input.StringDefault, input.Description)
+		},
 	}
+
+	flags[FlagReportFile] = &FlagInput{
+		StringDefault: FlagReportFileDefault,
+		Description:   FlagReportFileDescription,
+		Required:      false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, FlagReportFile, input.StringDefault, input.Description)
+		},
+	}
+
+	flags[FlagScope] = &FlagInput{
+		StringDefault: FlagScopeDefault,
+		Description:   FlagScopeDescription,
+		Required:      true,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, FlagScope, input.StringDefault, input.Description)
+		},
+	}
+
+	return flags
+}
+
+// NewCloudTrailFlags returns a new set of flags for the policy-gen aws import-cloudtrail command.
+func NewCloudTrailFlags() Flags {
+	flags := NewFlags().Only(FlagRecursive, FlagDebug, FlagConfig)
+
+	flags[FlagInputPath] = &FlagInput{
+		Description: FlagImportInputDescription,
+		Short:       FlagInputPathShort,
+		Required:    false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVarP(&input.StringValue, FlagInputPath, input.Short, input.StringDefault, input.Description)
+		},
+	}
+
+	for flag, input := range map[string]*FlagInput{
+		FlagFormat:       {StringDefault: FlagImportFormatDefault, Description: FlagImportFormatDescription, Required: true},
+		FlagReportFile:   {StringDefault: FlagReportFileDefault, Description: FlagReportFileDescription},
+		FlagName:         {StringDefault: FlagCloudTrailNameDefault, Description: FlagCloudTrailNameDescription, Required: true},
+		FlagPrincipalARN: {Description: FlagPrincipalARNDescription},
+		FlagSessionName:  {Description: FlagSessionNameDescription},
+		FlagStartTime:    {Description: FlagStartTimeDescription},
+		FlagEndTime:      {Description: FlagEndTimeDescription},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	flags[FlagExcludeErrors] = &FlagInput{
+		Description: FlagExcludeErrorsDescription,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagExcludeErrors, input.BooleanDefault, input.Description)
+		},
+	}
+
+	return flags
+}
+
+// NewUnusedFlags returns a new set of flags for the policy-gen aws unused command.
+func NewUnusedFlags() Flags {
+	flags := NewFlags().Only(FlagInputPath, FlagRecursive, FlagDebug, FlagConfig)
+
+	for flag, input := range map[string]*FlagInput{
+		FlagLastAccessed: {Description: FlagLastAccessedDescription, Required: true},
+		FlagName:         {Description: FlagPolicyNameDescription},
+		FlagFormat:       {StringDefault: FlagFormatDefault, Description: FlagUnusedFormatDescription, Required: true},
+		FlagReportFile:   {StringDefault: FlagReportFileDefault, Description: FlagReportFileDescription},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	flags[FlagDays] = &FlagInput{
+		IntDefault:  FlagDaysDefault,
+		Description: FlagDaysDescription,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().IntVar(&input.IntValue, FlagDays, input.IntDefault, input.Description)
+		},
+	}
+
+	return flags
+}
+
+// NewImportFlags returns a new set of flags for the policy-gen aws import command.
+func NewImportFlags() Flags {
+	flags := NewFlags().Only(FlagForce, FlagDebug)
+
+	for flag, input := range map[string]*FlagInput{
+		FlagName:       {Description: FlagImportNameDescription},
+		FlagOutputFile: {Description: FlagOutputFileDescription},
+		FlagPackage:    {Description: FlagPackageDescription},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	return flags
+}
+
+// NewAuditFlags returns a new set of flags for the policy-gen aws audit command.
+func NewAuditFlags() Flags {
+	flags := NewFlags().Only(FlagInputPath, FlagRecursive, FlagDebug, FlagEntrypoint, FlagConfig)
+
+	for flag, input := range map[string]*FlagInput{
+		FlagPolicy:     {Description: FlagPolicyDescription, Required: true},
+		FlagName:       {Description: FlagAuditNameDescription, Required: true},
+		FlagFormat:     {StringDefault: FlagTextFormatDefault, Description: FlagAuditFormatDescription, Required: true},
+		FlagReportFile: {StringDefault: FlagReportFileDefault, Description: FlagReportFileDescription},
+		FlagFailOn:     {StringDefault: FlagFailOnDefault, Description: FlagFailOnDescription, Required: true},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	return flags
+}
+
+// NewDiffFlags returns a new set of flags for the policy-gen aws diff command.
+func NewDiffFlags() Flags {
+	flags := NewFlags().Only(FlagRecursive, FlagDebug, FlagConfig)
+
+	for flag, input := range map[string]*FlagInput{
+		FlagBaseRef:    {Description: FlagBaseRefDescription},
+		FlagReportFile: {StringDefault: FlagReportFileDefault, Description: FlagReportFileDescription},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	return flags
+}
+
+// NewLintFlags returns a new set of flags for the policy-gen aws lint command.
+func NewLintFlags() Flags {
+	flags := NewFlags().Only(FlagInputPath, FlagRecursive, FlagDebug, FlagEntrypoint, FlagConfig)
+
+	for flag, input := range map[string]*FlagInput{
+		FlagName:       {Description: FlagPolicyNameDescription},
+		FlagFormat:     {StringDefault: FlagFormatDefault, Description: FlagLintFormatDescription, Required: true},
+		FlagReportFile: {StringDefault: FlagReportFileDefault, Description: FlagReportFileDescription},
+		FlagFailOn:     {StringDefault: FlagLintFailOnDefault, Description: FlagLintFailOnDescription, Required: true},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	return flags
+}
+
+// NewApproveFlags returns a new set of flags for the policy-gen aws approve command.
+func NewApproveFlags() Flags {
+	flags := NewFlags().Only(FlagInputPath, FlagRecursive, FlagDebug, FlagEntrypoint, FlagGuardrails, FlagConfig)
+
+	flags[FlagLedger] = &FlagInput{
+		StringDefault: FlagLedgerDefault,
+		Description:   FlagApproveLedgerDescription,
+		Required:      true,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, FlagLedger, input.StringDefault, input.Description)
+		},
+	}
+
+	flags[FlagPrune] = &FlagInput{
+		BooleanDefault: FlagPruneDefault,
+		Description:    FlagPruneDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagPrune, input.BooleanDefault, input.Description)
+		},
+	}
+
+	flags[FlagEnforceExpiry] = &FlagInput{
+		BooleanDefault: FlagEnforceExpiryDefault,
+		Description:    FlagApproveExpiryDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagEnforceExpiry, input.BooleanDefault, input.Description)
+		},
+	}
+
+	return flags
+}
+
+// NewSimulateFlags returns a new set of flags for the policy-gen aws simulate command.
+func NewSimulateFlags() Flags {
+	flags := NewFlags().Only(FlagInputPath, FlagRecursive, FlagDebug, FlagEntrypoint, FlagConfig)
+
+	for flag, input := range map[string]*FlagInput{
+		FlagName:     {Description: FlagSimulateNameDescription},
+		FlagPolicy:   {Description: FlagSimulatePolicyDescription},
+		FlagAction:   {Description: FlagActionDescription, Required: true},
+		FlagResource: {StringDefault: FlagResourceDefault, Description: FlagResourceDescription, Required: true},
+		FlagFormat:   {StringDefault: FlagTextFormatDefault, Description: FlagSimulateFormatDescription, Required: true},
+	} {
+		flag := flag
+		input.CommandFunc = func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, flag, input.StringDefault, input.Description)
+		}
+
+		flags[flag] = input
+	}
+
+	flags[FlagContext] = &FlagInput{
+		Description: FlagContextDescription,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringArrayVar(&input.StringArrayValue, FlagContext, input.StringArrayDefault, input.Description)
+		},
+	}
+
+	flags[FlagTime] = &FlagInput{
+		Description: FlagTimeDescription,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().StringVar(&input.StringValue, FlagTime, input.StringDefault, input.Description)
+		},
+	}
+
+	flags[FlagEnforceExpiry] = &FlagInput{
+		BooleanDefault: FlagEnforceExpiryDefault,
+		Description:    FlagSimulateExpiryDescription,
+		Required:       false,
+		CommandFunc: func(command *cobra.Command, input *FlagInput) {
+			command.Flags().BoolVar(&input.BooleanValue, FlagEnforceExpiry, input.BooleanDefault, input.Description)
+		},
+	}
+
+	return flags
 }
 
 // Initialize initializes a set of flags by running adding the flags to the command using the CommandFunc.
@@ -105,9 +511,14 @@ func (flags Flags) ToProcessorConfig() (*processor.Config, error) {
 		return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagInputPath, err)
 	}
 
-	outputDirectory, err := files.NewDirectory(flags.For(FlagOutputPath).StringValue, files.WithPreExistingDirectory)
-	if err != nil {
-		return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagOutputPath, err)
+	// the output path is not used by every command, so it is only validated when it is set
+	var outputDirectory *files.Directory
+
+	if outputInput := flags.For(FlagOutputPath).StringValue; outputInput != "" {
+		outputDirectory, err = files.NewDirectory(outputInput, files.WithPreExistingDirectory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagOutputPath, err)
+		}
 	}
 
 	// validate existence of file objects and add them to the processor
@@ -121,16 +532,169 @@ func (flags Flags) ToProcessorConfig() (*processor.Config, error) {
 		}
 	}
 
+	if flags.For(FlagInject).BooleanValue && documentationFile == nil {
+		return nil, fmt.Errorf("missing value for flag: [--%s] - required by [--%s]", FlagDocumentation, FlagInject)
+	}
+
+	// read the template which the documentation is rendered with, which takes precedence over
+	// the built-in layouts
+	var documentationTemplate *template.Template
+
+	if templateInput := flags.For(FlagTemplate).StringValue; templateInput != "" {
+		documentationTemplate, err = docs.ReadTemplate(templateInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagTemplate, err)
+		}
+	} else if layoutInput := flags.For(FlagLayout).StringValue; layoutInput != "" {
+		documentationTemplate, err = docs.LayoutTemplate(layoutInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagLayout, err)
+		}
+	}
+
+	// validate the access level and order of the documented markers
+	var minimumAccessLevel string
+
+	if minAccessInput := flags.For(FlagMinAccess).StringValue; minAccessInput != "" {
+		minimumAccessLevel, err = policy.ParseAccessLevel(minAccessInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagMinAccess, err)
+		}
+	}
+
+	documentationSort := flags.For(FlagSort).StringValue
+	if err := docs.ValidateSort(documentationSort); err != nil {
+		return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagSort, err)
+	}
+
+	graphFile := flags.For(FlagGraph).StringValue
+	if graphFile != "" {
+		if _, err := graph.FormatFor(graphFile); err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagGraph, err)
+		}
+	}
+
+	inventoryFile := flags.For(FlagInventory).StringValue
+	if inventoryFile != "" {
+		if _, err := inventory.FormatFor(inventoryFile); err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagInventory, err)
+		}
+	}
+
+	// validate existence of entrypoint directories and add them to the processor
+	entrypoints, err := toEntrypoints(flags.For(FlagEntrypoint).StringArrayValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagEntrypoint, err)
+	}
+
+	// read the project configuration file
+	project, err := flags.Project()
+	if err != nil {
+		return nil, err
+	}
+
+	// read the guardrails which every marker must satisfy
+	var markerGuardrails *guardrails.Guardrails
+
+	if guardrailsInput := flags.For(FlagGuardrails).StringValue; guardrailsInput != "" {
+		markerGuardrails, err = guardrails.Read(guardrailsInput)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagGuardrails, err)
+		}
+	}
+
 	return &processor.Config{
-		InputDirectory:    inputDirectory,
-		OutputDirectory:   outputDirectory,
-		DocumentationFile: documentationFile,
-		Force:             flags.For(FlagForce).BooleanValue,
-		Debug:             flags.For(FlagDebug).BooleanValue,
+		InputDirectory:        inputDirectory,
+		OutputDirectory:       outputDirectory,
+		DocumentationFile:     documentationFile,
+		DocumentationTemplate: documentationTemplate,
+		Entrypoints:           entrypoints,
+		Recursive:             flags.For(FlagRecursive).BooleanValue,
+		SourceURL:             flags.For(FlagSourceURL).StringValue,
+		SourceRef:             flags.For(FlagSourceRef).StringValue,
+		SourceFormat:          flags.For(FlagSourceFormat).StringValue,
+		Force:                 flags.For(FlagForce).BooleanValue,
+		Debug:                 flags.For(FlagDebug).BooleanValue,
+		InjectDocumentation:   flags.For(FlagInject).BooleanValue,
+		Check:                 flags.For(FlagCheck).BooleanValue,
+		ServiceRollup:         flags.For(FlagServiceRollup).BooleanValue,
+		PolicyDocumentation:   flags.For(FlagPerPolicyDocs).BooleanValue,
+		SiteDirectory:         flags.For(FlagSite).StringValue,
+		GraphFile:             graphFile,
+		InventoryFile:         inventoryFile,
+		MinimumAccessLevel:    minimumAccessLevel,
+		DocumentationSort:     documentationSort,
+		Project:               project,
+		Guardrails:            markerGuardrails,
 	}, nil
 }
 
-// For returns the FlagInput for a particular flag.
+// Project reads the project configuration file if one was given.
+func (flags Flags) Project() (*config.File, error) {
+	configInput := flags.For(FlagConfig).StringValue
+	if configInput == "" {
+		return nil, nil
+	}
+
+	project, err := config.Read(configInput)
+	if err != nil {
+		return nil, fmt.Errorf("invalid flag: [--%s] - %w", FlagConfig, err)
+	}
+
+	return project, nil
+}
+
+// For returns the FlagInput for a particular flag.  An empty FlagInput is returned if the flag
+// is not part of the set of flags for a command.
 func (flags Flags) For(flag string) *FlagInput {
-	return flags[flag]
+	if input, found := flags[flag]; found {
+		return input
+	}
+
+	return &FlagInput{}
+}
+
+// Only returns the subset of flags with the given names.
+func (flags Flags) Only(names ...string) Flags {
+	subset := Flags{}
+
+	for _, name := range names {
+		if input, found := flags[name]; found {
+			subset[name] = input
+		}
+	}
+
+	return subset
+}
+
+// toEntrypoints converts a set of entrypoint inputs into a map of policy names to the directory
+// of their main package.  Each input is in the form of [name=]path, where the name defaults
+// to the base name of the path.
+func toEntrypoints(inputs []string) (map[string]string, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	entrypoints := map[string]string{}
+
+	for _, entrypoint := range inputs {
+		name, path, found := strings.Cut(entrypoint, "=")
+		if !found {
+			path = entrypoint
+			name = filepath.Base(filepath.Clean(path))
+		}
+
+		directory, err := files.NewDirectory(path, files.WithPreExistingDirectory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid entrypoint [%s] - %w", entrypoint, err)
+		}
+
+		if _, exists := entrypoints[name]; exists {
+			return nil, fmt.Errorf("duplicate entrypoint name [%s]", name)
+		}
+
+		entrypoints[name] = directory.Path
+	}
+
+	return entrypoints, nil
 }
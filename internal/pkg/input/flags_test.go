@@ -70,6 +70,18 @@ func TestFlags_ToProcessorConfig(t *testing.T) {
 				f[FlagDocumentation].StringValue = "this/path/is/fake/README.md"
 			},
 		},
+		{
+			name:    "ensure missing entrypoint path returns an error",
+			flags:   NewFlags(),
+			want:    nil,
+			wantErr: true,
+			overrideFunc: func(flags *Flags) {
+				f := *flags
+				f[FlagInputPath].StringValue = "."
+				f[FlagOutputPath].StringValue = "."
+				f[FlagEntrypoint].StringArrayValue = []string{"installer=this/path/is/fake/cmd"}
+			},
+		},
 		{
 			name:  "ensure processor config returns correctly",
 			flags: NewFlags(),
@@ -80,8 +92,9 @@ func TestFlags_ToProcessorConfig(t *testing.T) {
 					Directory: &files.Directory{Path: "."},
 					File:      "README.md",
 				},
-				Force: false,
-				Debug: false,
+				SourceRef: FlagSourceRefDefault,
+				Force:     false,
+				Debug:     false,
 			},
 			wantErr: false,
 			overrideFunc: func(flags *Flags) {
@@ -91,6 +104,35 @@ func TestFlags_ToProcessorConfig(t *testing.T) {
 				f[FlagDocumentation].StringValue = "README.md"
 			},
 		},
+		{
+			name:  "ensure recursive flag is passed to the processor config",
+			flags: NewFlags(),
+			want: &processor.Config{
+				InputDirectory:  &files.Directory{Path: "."},
+				OutputDirectory: &files.Directory{Path: "."},
+				SourceRef:       FlagSourceRefDefault,
+				Recursive:       true,
+			},
+			wantErr: false,
+			overrideFunc: func(flags *Flags) {
+				f := *flags
+				f[FlagInputPath].StringValue = "."
+				f[FlagOutputPath].StringValue = "."
+				f[FlagRecursive].BooleanValue = true
+			},
+		},
+		{
+			name:    "ensure inventory file with an unknown extension returns an error",
+			flags:   NewGenerateFlags(),
+			want:    nil,
+			wantErr: true,
+			overrideFunc: func(flags *Flags) {
+				f := *flags
+				f[FlagInputPath].StringValue = "."
+				f[FlagOutputPath].StringValue = "."
+				f[FlagInventory].StringValue = "inventory.txt"
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,216 @@
+package justification
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+var (
+	ErrInvalidRules = errors.New("invalid justification rules")
+)
+
+const (
+	RuleMissingReason = "justification-missing-reason"
+	RuleTooShort      = "justification-too-short"
+	RuleMissingTicket = "justification-missing-ticket"
+	RulePlaceholder   = "justification-placeholder"
+
+	RequireAll   = "all"
+	RequireWrite = "write"
+	RequireNone  = "none"
+
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Rules represents the requirements for the reason which justifies the permission of a marker.
+// The reason of a marker which requires one, or which gives one, must satisfy every rule.
+type Rules struct {
+	// RequireReason determines which markers require a reason: all markers, only markers whose
+	// action may write data, or none.
+	RequireReason string `yaml:"requireReason,omitempty"`
+
+	// MinLength is the minimum number of characters of a reason.
+	MinLength int `yaml:"minLength,omitempty"`
+
+	// TicketPattern is a regular expression which a reason must contain a match of, such as a
+	// reference to the ticket which requested the permission.
+	TicketPattern string `yaml:"ticketPattern,omitempty"`
+
+	// Placeholders are words, such as TODO, which a reason may not contain.  They are matched
+	// case-insensitively as whole words.  The default placeholders apply unless they are set, so
+	// an empty list allows any word.
+	Placeholders []string `yaml:"placeholders,omitempty"`
+
+	// Severity determines whether markers which violate a rule are errors, which prevent
+	// policies from being generated, or only warnings.
+	Severity string `yaml:"severity,omitempty"`
+
+	ticket       *regexp.Regexp
+	placeholders []*regexp.Regexp
+}
+
+// defaultRules are the rules which apply when none are configured.
+var defaultRules = &Rules{
+	Placeholders: DefaultPlaceholders(),
+	Severity:     SeverityWarning,
+	placeholders: compilePlaceholders(DefaultPlaceholders()),
+}
+
+// DefaultPlaceholders returns the placeholders which a reason may not contain unless others are
+// configured, such as the TODO reason of imported markers.
+func DefaultPlaceholders() []string {
+	return []string{"TODO", "TBD", "FIXME"}
+}
+
+// DefaultRules returns the rules which apply when none are configured, which only warn about
+// reasons which contain one of the default placeholders.
+func DefaultRules() *Rules {
+	return defaultRules
+}
+
+// Violation represents a marker whose reason violates a justification rule.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+// Error returns the string representation of a violation.  It is used to satisfy the error
+// interface.
+func (violation *Violation) Error() string {
+	return fmt.Sprintf("violates justification rule [%s] - %s", violation.Rule, violation.Message)
+}
+
+// Validate validates the rules and compiles their patterns.
+func (rules *Rules) Validate() error {
+	switch rules.RequireReason {
+	case "", RequireAll, RequireWrite, RequireNone:
+	default:
+		return fmt.Errorf(
+			"%w - requireReason must be one of [%s, %s, %s], found [%s]",
+			ErrInvalidRules, RequireAll, RequireWrite, RequireNone, rules.RequireReason,
+		)
+	}
+
+	switch rules.Severity {
+	case "", SeverityError, SeverityWarning:
+	default:
+		return fmt.Errorf(
+			"%w - severity must be one of [%s, %s], found [%s]",
+			ErrInvalidRules, SeverityError, SeverityWarning, rules.Severity,
+		)
+	}
+
+	if rules.MinLength < 0 {
+		return fmt.Errorf("%w - minLength must not be negative, found [%d]", ErrInvalidRules, rules.MinLength)
+	}
+
+	if rules.TicketPattern != "" {
+		ticket, err := regexp.Compile(rules.TicketPattern)
+		if err != nil {
+			return fmt.Errorf("%w - invalid ticketPattern [%s] - %s", ErrInvalidRules, rules.TicketPattern, err)
+		}
+
+		rules.ticket = ticket
+	}
+
+	if rules.Placeholders == nil {
+		rules.Placeholders = DefaultPlaceholders()
+	}
+
+	for i, placeholder := range rules.Placeholders {
+		if strings.TrimSpace(placeholder) == "" {
+			return fmt.Errorf("%w - placeholder at index [%d] is empty", ErrInvalidRules, i)
+		}
+	}
+
+	rules.placeholders = compilePlaceholders(rules.Placeholders)
+
+	return nil
+}
+
+// compilePlaceholders compiles a set of placeholders into patterns which match them
+// case-insensitively as whole words.
+func compilePlaceholders(placeholders []string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, len(placeholders))
+
+	for i, placeholder := range placeholders {
+		patterns[i] = regexp.MustCompile(`(?i)(^|\W)` + regexp.QuoteMeta(placeholder) + `($|\W)`)
+	}
+
+	return patterns
+}
+
+// Enforced returns whether or not markers which violate a rule are errors.  A nil set of rules is
+// enforced as it never reports a violation.
+func (rules *Rules) Enforced() bool {
+	return rules == nil || rules.Severity != SeverityWarning
+}
+
+// Check checks the reason of a marker against the rules, returning a *Violation for the first
+// rule that it violates.  The rules must have been validated.
+func (rules *Rules) Check(marker policy.Marker) error {
+	if rules == nil {
+		return nil
+	}
+
+	reason := strings.TrimSpace(marker.ReasonColumn())
+
+	if reason == "" {
+		required, err := rules.requires(marker)
+		if err != nil {
+			return err
+		}
+
+		if required {
+			return &Violation{
+				Rule:    RuleMissingReason,
+				Message: fmt.Sprintf("action [%s] requires a reason", marker.PermissionColumn()),
+			}
+		}
+
+		return nil
+	}
+
+	for i, placeholder := range rules.placeholders {
+		if placeholder.MatchString(reason) {
+			return &Violation{
+				Rule:    RulePlaceholder,
+				Message: fmt.Sprintf("reason [%s] contains placeholder text [%s]", reason, rules.Placeholders[i]),
+			}
+		}
+	}
+
+	if length := len([]rune(reason)); length < rules.MinLength {
+		return &Violation{
+			Rule:    RuleTooShort,
+			Message: fmt.Sprintf("reason [%s] has [%d] characters but requires at least [%d]", reason, length, rules.MinLength),
+		}
+	}
+
+	if rules.ticket != nil && !rules.ticket.MatchString(reason) {
+		return &Violation{
+			Rule:    RuleMissingTicket,
+			Message: fmt.Sprintf("reason [%s] does not reference a ticket matching [%s]", reason, rules.TicketPattern),
+		}
+	}
+
+	return nil
+}
+
+// requires determines whether a marker requires a reason.
+func (rules *Rules) requires(marker policy.Marker) (bool, error) {
+	switch rules.RequireReason {
+	case RequireAll:
+		return true, nil
+	case RequireWrite:
+		return aws.IsWriteAction(marker.PermissionColumn())
+	default:
+		return false, nil
+	}
+}
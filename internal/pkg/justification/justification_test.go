@@ -0,0 +1,177 @@
+package justification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/scottd018/go-utils/pkg/pointers"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+)
+
+func TestRules_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rules   *Rules
+		wantErr bool
+	}{
+		{
+			name:    "ensure empty rules are valid",
+			rules:   &Rules{},
+			wantErr: false,
+		},
+		{
+			name: "ensure complete rules are valid",
+			rules: &Rules{
+				RequireReason: RequireWrite,
+				MinLength:     10,
+				TicketPattern: "[A-Z]+-[0-9]+",
+				Placeholders:  []string{"TODO"},
+				Severity:      SeverityWarning,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ensure invalid requireReason returns an error",
+			rules:   &Rules{RequireReason: "some"},
+			wantErr: true,
+		},
+		{
+			name:    "ensure invalid severity returns an error",
+			rules:   &Rules{Severity: "fatal"},
+			wantErr: true,
+		},
+		{
+			name:    "ensure negative minLength returns an error",
+			rules:   &Rules{MinLength: -1},
+			wantErr: true,
+		},
+		{
+			name:    "ensure invalid ticketPattern returns an error",
+			rules:   &Rules{TicketPattern: "[A-Z"},
+			wantErr: true,
+		},
+		{
+			name:    "ensure empty placeholder returns an error",
+			rules:   &Rules{Placeholders: []string{" "}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.rules.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Rules.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRules_Check(t *testing.T) {
+	t.Parallel()
+
+	rules := &Rules{
+		RequireReason: RequireWrite,
+		MinLength:     10,
+		TicketPattern: "[A-Z]+-[0-9]+",
+		Placeholders:  []string{"TODO", "TBD"},
+	}
+
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("Rules.Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rules    *Rules
+		action   string
+		reason   *string
+		wantRule string
+	}{
+		{
+			name:     "ensure nil rules are satisfied",
+			rules:    nil,
+			action:   "s3:PutObject",
+			reason:   nil,
+			wantRule: "",
+		},
+		{
+			name:     "ensure read action without a reason is satisfied",
+			rules:    rules,
+			action:   "s3:GetObject",
+			reason:   nil,
+			wantRule: "",
+		},
+		{
+			name:     "ensure write action without a reason is a violation",
+			rules:    rules,
+			action:   "s3:PutObject",
+			reason:   nil,
+			wantRule: RuleMissingReason,
+		},
+		{
+			name:     "ensure reason with a placeholder is a violation",
+			rules:    rules,
+			action:   "s3:GetObject",
+			reason:   pointers.String("todo: explain OPS-1"),
+			wantRule: RulePlaceholder,
+		},
+		{
+			name:     "ensure placeholder within a word is not a violation",
+			rules:    rules,
+			action:   "s3:GetObject",
+			reason:   pointers.String("read the TODOLIST table for OPS-1"),
+			wantRule: "",
+		},
+		{
+			name:     "ensure short reason is a violation",
+			rules:    rules,
+			action:   "s3:PutObject",
+			reason:   pointers.String("OPS-1"),
+			wantRule: RuleTooShort,
+		},
+		{
+			name:     "ensure reason without a ticket is a violation",
+			rules:    rules,
+			action:   "s3:PutObject",
+			reason:   pointers.String("upload reports to the bucket"),
+			wantRule: RuleMissingTicket,
+		},
+		{
+			name:     "ensure reason satisfying every rule is satisfied",
+			rules:    rules,
+			action:   "s3:PutObject",
+			reason:   pointers.String("upload reports to the bucket, see OPS-1"),
+			wantRule: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			marker := &aws.Marker{Name: pointers.String("test"), Action: pointers.String(tt.action), Reason: tt.reason}
+
+			err := tt.rules.Check(marker)
+
+			var violation *Violation
+			if errors.As(err, &violation) {
+				if violation.Rule != tt.wantRule {
+					t.Errorf("Rules.Check() rule = %v, want %v", violation.Rule, tt.wantRule)
+				}
+
+				return
+			}
+
+			if err != nil || tt.wantRule != "" {
+				t.Errorf("Rules.Check() error = %v, want rule %v", err, tt.wantRule)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidAccessLevel = errors.New("invalid access level")
+)
+
+// access levels, ordered from the least to the most sensitive.
+const (
+	AccessLevelList                  = "List"
+	AccessLevelRead                  = "Read"
+	AccessLevelTagging               = "Tagging"
+	AccessLevelWrite                 = "Write"
+	AccessLevelPermissionsManagement = "Permissions management"
+)
+
+// AccessLevels returns the access levels which classify an action, ordered from the least to the
+// most sensitive.
+func AccessLevels() []string {
+	return []string{
+		AccessLevelList,
+		AccessLevelRead,
+		AccessLevelTagging,
+		AccessLevelWrite,
+		AccessLevelPermissionsManagement,
+	}
+}
+
+// AccessLevelRank returns the rank of an access level, where a more sensitive access level has a
+// higher rank.  An unknown access level has a rank of -1.
+func AccessLevelRank(level string) int {
+	for rank, known := range AccessLevels() {
+		if level == known {
+			return rank
+		}
+	}
+
+	return -1
+}
+
+// ParseAccessLevel returns the access level given by a value, which is matched case-insensitively
+// and may use dashes in place of spaces, such as permissions-management.
+func ParseAccessLevel(value string) (string, error) {
+	normalized := strings.ReplaceAll(strings.TrimSpace(value), "-", " ")
+
+	for _, level := range AccessLevels() {
+		if strings.EqualFold(normalized, level) {
+			return level, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"%w [%s] - must be one of [%s]",
+		ErrInvalidAccessLevel, value, strings.Join(AccessLevels(), ", "),
+	)
+}
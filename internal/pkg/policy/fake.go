@@ -9,6 +9,12 @@ const (
 	FakeReasonColumn     = FakeString
 	FakeResourceColumn   = "*"
 	FakeConditionColumn  = ""
+	FakeRawCondition     = ""
+	FakeAccessLevel      = AccessLevelPermissionsManagement
+	FakeExpiresColumn    = ""
+	FakeMetadataColumn   = ""
+	FakeUsedByColumn     = ""
+	FakeSourceColumn     = ""
 )
 
 // fake is a struct to fulfill the policymarkers.Marker interface, but is used
@@ -23,14 +29,28 @@ func NewFakeMarker() *fake {
 }
 
 // fake methods for policies.
-func (f *fake) Definition() string { return FakeDefinition }
-func (f *fake) Validate() error    { return nil }
-func (f *fake) GetName() string    { return FakeName }
-func (f *fake) WithDefault()       {}
+func (f *fake) Definition() string       { return FakeDefinition }
+func (f *fake) Validate() error          { return nil }
+func (f *fake) GetName() string          { return FakeName }
+func (f *fake) WithName(_ string) Marker { return f }
+func (f *fake) WithDefault()             {}
+
+// fake methods for sources.
+func (f *fake) GetSource() *Source  { return nil }
+func (f *fake) SetSource(_ *Source) {}
+
+// fake methods for metadata.
+func (f *fake) GetMetadata() map[string]string { return map[string]string{} }
 
 // fake methods for documentation.
-func (f *fake) EffectColumn() string     { return FakeEffectColumn }
-func (f *fake) PermissionColumn() string { return FakePermissionColumn }
-func (f *fake) ReasonColumn() string     { return FakeReasonColumn }
-func (f *fake) ResourceColumn() string   { return FakeResourceColumn }
-func (f *fake) ConditionColumn() string  { return FakeConditionColumn }
+func (f *fake) EffectColumn() string           { return FakeEffectColumn }
+func (f *fake) PermissionColumn() string       { return FakePermissionColumn }
+func (f *fake) ReasonColumn() string           { return FakeReasonColumn }
+func (f *fake) ResourceColumn() string         { return FakeResourceColumn }
+func (f *fake) ConditionColumn() string        { return FakeConditionColumn }
+func (f *fake) RawConditionColumn() string     { return FakeRawCondition }
+func (f *fake) AccessLevelColumn() string      { return FakeAccessLevel }
+func (f *fake) ExpiresColumn() string          { return FakeExpiresColumn }
+func (f *fake) MetadataColumn(_ string) string { return FakeMetadataColumn }
+func (f *fake) UsedByColumn() string           { return FakeUsedByColumn }
+func (f *fake) SourceColumn() string           { return FakeSourceColumn }
@@ -11,7 +11,11 @@ type Marker interface {
 	Definition() string
 	Validate() error
 	GetName() string
+	WithName(name string) Marker
 	WithDefault()
+	GetSource() *Source
+	SetSource(source *Source)
+	GetMetadata() map[string]string
 
 	// for documentation
 	EffectColumn() string
@@ -19,8 +23,27 @@ type Marker interface {
 	ReasonColumn() string
 	ResourceColumn() string
 	ConditionColumn() string
+	RawConditionColumn() string
+	AccessLevelColumn() string
+	ExpiresColumn() string
+	MetadataColumn(key string) string
+	UsedByColumn() string
+	SourceColumn() string
 }
 
 // MarkerMap is a map of a string to a set of markers.  In this case the string represents
 // a file name where the markers will be used to generate content in a file.
 type MarkerMap map[string][]Marker
+
+// FilterByName returns the subset of markers for the policy with the given name.
+func FilterByName(markers []Marker, name string) []Marker {
+	filtered := []Marker{}
+
+	for i := range markers {
+		if markers[i].GetName() == name {
+			filtered = append(filtered, markers[i])
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,41 @@
+package policy
+
+import "fmt"
+
+// Source represents the location of a marker within an input file.  The package, receiver and
+// function are only set when the marker is found within Go source code.
+type Source struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Package  string `json:"package,omitempty"`
+	Receiver string `json:"receiver,omitempty"`
+	Function string `json:"function,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// String returns the string representation of a source location.
+func (source *Source) String() string {
+	if source == nil {
+		return ""
+	}
+
+	if source.Line == 0 {
+		return source.File
+	}
+
+	return fmt.Sprintf("%s:%d", source.File, source.Line)
+}
+
+// Symbol returns the fully qualified name of the function which encloses the source location.
+// It returns an empty string if the enclosing function is unknown.
+func (source *Source) Symbol() string {
+	if source == nil || source.Function == "" {
+		return ""
+	}
+
+	if source.Receiver == "" {
+		return fmt.Sprintf("%s.%s", source.Package, source.Function)
+	}
+
+	return fmt.Sprintf("%s.(%s).%s", source.Package, source.Receiver, source.Function)
+}
@@ -1,7 +1,12 @@
 package processor
 
 import (
+	"io"
+	"text/template"
+
+	"github.com/scottd018/policy-gen/internal/pkg/config"
 	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/guardrails"
 )
 
 // Config represents the configuration for a processor.
@@ -9,7 +14,61 @@ type Config struct {
 	InputDirectory    *files.Directory
 	OutputDirectory   *files.Directory
 	DocumentationFile *files.File
+	Entrypoints       map[string]string
+	SourceURL         string
+	SourceRef         string
+	SourceFormat      string
 	Recursive         bool
 	Force             bool
 	Debug             bool
+
+	// InjectDocumentation determines whether the documentation is injected into the region of an
+	// existing documentation file between the begin and end comments.
+	InjectDocumentation bool
+
+	// ServiceRollup determines whether the documentation of each policy includes a summary of
+	// the actions of each service.
+	ServiceRollup bool
+
+	// PolicyDocumentation determines whether a documentation file is written for each policy
+	// next to its policy file.
+	PolicyDocumentation bool
+
+	// SiteDirectory is the directory which a static HTML site of the documentation is written
+	// to.  No site is written when it is not set.
+	SiteDirectory string
+
+	// GraphFile is the file which a graph of the policies, services, actions and resources is
+	// written to, in the format given by its extension.  No graph is written when it is not set.
+	GraphFile string
+
+	// InventoryFile is the file which an inventory of every permission is written to, in the
+	// format given by its extension.  No inventory is written when it is not set.
+	InventoryFile string
+
+	// MinimumAccessLevel is the least sensitive access level of the markers which are
+	// documented.  Every marker is documented when it is not set.
+	MinimumAccessLevel string
+
+	// DocumentationSort is the order of the documented markers.
+	DocumentationSort string
+
+	// Check determines whether generated files are compared with the existing files, rather
+	// than written, so that stale files may be detected.
+	Check bool
+
+	// DocumentationTemplate is the template which the documentation is rendered with.  The
+	// default layout is used when it is not set.
+	DocumentationTemplate *template.Template
+
+	// Project is the project configuration file, which declares the metadata keys of markers
+	// and configures the generated documentation.
+	Project *config.File
+
+	// Guardrails are the organization-wide constraints which every marker must satisfy.
+	Guardrails *guardrails.Guardrails
+
+	// LogWriter is where log output is written.  It defaults to standard output and may be set
+	// to standard error by commands which write reports to standard output.
+	LogWriter io.Writer
 }
@@ -0,0 +1,159 @@
+package processor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/guardrails"
+	"github.com/scottd018/policy-gen/internal/pkg/justification"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/sarif"
+)
+
+var (
+	ErrInvalidFormat = errors.New("invalid format")
+)
+
+const (
+	FormatText  = "text"
+	FormatSARIF = "sarif"
+
+	RuleMarkerParseError = "marker-parse-error"
+	RuleMarkerInvalid    = "marker-invalid"
+)
+
+// Diagnostic represents a problem with a marker, such as a marker which is invalid or violates
+// the guardrails, which prevents a policy from being generated.  Diagnostics with a warning level,
+// such as violations of justification rules which are not enforced, do not prevent a policy from
+// being generated.
+type Diagnostic struct {
+	Rule    string
+	Level   string
+	Text    string
+	Message string
+	Source  *policy.Source
+}
+
+// String returns the string representation of a diagnostic.
+func (diagnostic *Diagnostic) String() string {
+	text := fmt.Sprintf("%s: %s [%s] - %s", diagnostic.Source, diagnostic.Rule, diagnostic.Text, diagnostic.Message)
+	if diagnostic.Level == sarif.LevelWarning {
+		return "warning: " + text
+	}
+
+	return text
+}
+
+// Diagnostics returns a diagnostic for every parsed result which is not a valid marker, and a
+// warning for every valid marker which violates a justification rule that is not enforced.
+// Unlike FindMarkers, which fails at the first invalid marker, every invalid marker is reported.
+func (processor *Processor) Diagnostics(results []*Result) []*Diagnostic {
+	diagnostics := []*Diagnostic{}
+
+	for i := range results {
+		level := sarif.LevelError
+
+		markerResult, rule, err := processor.toMarker(results[i])
+		if err == nil {
+			err = processor.Config.Project.JustificationRules().Check(markerResult)
+
+			var violation *justification.Violation
+			if !errors.As(err, &violation) {
+				continue
+			}
+
+			rule, level = violation.Rule, sarif.LevelWarning
+		}
+
+		diagnostics = append(diagnostics, &Diagnostic{
+			Rule:    rule,
+			Level:   level,
+			Text:    strings.TrimSpace(results[i].MarkerText),
+			Message: err.Error(),
+			Source:  results[i].Source,
+		})
+	}
+
+	return diagnostics
+}
+
+// CountErrors returns the number of diagnostics which prevent a policy from being generated.
+func CountErrors(diagnostics []*Diagnostic) int {
+	count := 0
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Level != sarif.LevelWarning {
+			count++
+		}
+	}
+
+	return count
+}
+
+// RenderDiagnostics renders a set of diagnostics in the given format.
+func RenderDiagnostics(diagnostics []*Diagnostic, format string) ([]byte, error) {
+	switch format {
+	case FormatText:
+		return DiagnosticsText(diagnostics), nil
+	case FormatSARIF:
+		return DiagnosticsSARIF(diagnostics)
+	default:
+		return nil, fmt.Errorf("%w [%s] - must be one of [%s, %s]", ErrInvalidFormat, format, FormatText, FormatSARIF)
+	}
+}
+
+// DiagnosticsText renders a set of diagnostics as one line per diagnostic.
+func DiagnosticsText(diagnostics []*Diagnostic) []byte {
+	text := &bytes.Buffer{}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Fprintln(text, diagnostic)
+	}
+
+	return text.Bytes()
+}
+
+// DiagnosticsSARIF renders a set of diagnostics as a SARIF 2.1.0 log.
+func DiagnosticsSARIF(diagnostics []*Diagnostic) ([]byte, error) {
+	results := make([]sarif.Result, len(diagnostics))
+
+	for i, diagnostic := range diagnostics {
+		var (
+			file string
+			line int
+		)
+
+		if diagnostic.Source != nil {
+			file, line = diagnostic.Source.File, diagnostic.Source.Line
+		}
+
+		level, description := sarif.LevelError, "invalid marker"
+		if diagnostic.Level == sarif.LevelWarning {
+			level, description = sarif.LevelWarning, "marker"
+		}
+
+		results[i] = sarif.NewResult(
+			diagnostic.Rule,
+			level,
+			fmt.Sprintf("%s [%s] - %s", description, diagnostic.Text, diagnostic.Message),
+			file,
+			line,
+		)
+	}
+
+	rules := []sarif.Rule{
+		sarif.NewRule(RuleMarkerParseError, "Marker which could not be parsed", sarif.LevelError),
+		sarif.NewRule(RuleMarkerInvalid, "Marker with missing or invalid fields", sarif.LevelError),
+		sarif.NewRule(guardrails.RuleForbiddenAction, "Marker allowing an action forbidden by the guardrails", sarif.LevelError),
+		sarif.NewRule(guardrails.RuleUnapprovedAction, "Marker allowing an action not approved for its policy by the guardrails", sarif.LevelError),
+		sarif.NewRule(guardrails.RuleUnapprovedResource, "Marker allowing an action on a resource not approved by the guardrails", sarif.LevelError),
+		sarif.NewRule(justification.RuleMissingReason, "Marker without a reason when one is required", sarif.LevelError),
+		sarif.NewRule(justification.RuleTooShort, "Marker with a reason shorter than the minimum length", sarif.LevelError),
+		sarif.NewRule(justification.RuleMissingTicket, "Marker with a reason which does not reference a ticket", sarif.LevelError),
+		sarif.NewRule(justification.RulePlaceholder, "Marker with a reason containing placeholder text", sarif.LevelError),
+	}
+
+	return sarif.NewLog(rules, results).Marshal()
+}
@@ -0,0 +1,118 @@
+package processor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/config"
+	"github.com/scottd018/policy-gen/internal/pkg/justification"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+	"github.com/scottd018/policy-gen/internal/pkg/sarif"
+)
+
+const testDiagnosticsContent = `package test
+
+// +policy-gen:aws:iam:policy:name=test,action=` + "`s3:GetObject`" + `,resource=` + "`*`" + `,reason=` + "`reason which is long enough`" + `
+// +policy-gen:aws:iam:policy:name=test,resource=` + "`*`" + `
+// +policy-gen:aws:iam:policy:name=test,action=` + "`s3:PutObject`" + `,resource=` + "`*`" + `,reason=` + "`short`" + `
+`
+
+func TestProcessor_Diagnostics(t *testing.T) {
+	t.Parallel()
+
+	rules := &justification.Rules{MinLength: 10, Severity: justification.SeverityWarning}
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("Rules.Validate() error = %v", err)
+	}
+
+	markerProcessor := newTestProcessor(t, &Config{Project: &config.File{Justification: rules}})
+
+	diagnostics := markerProcessor.Diagnostics(markerProcessor.ParseContent("test.go", testDiagnosticsContent))
+
+	got := []string{}
+	for _, diagnostic := range diagnostics {
+		got = append(got, diagnostic.Rule+"="+diagnostic.Level+"="+diagnostic.Source.String())
+	}
+
+	want := []string{
+		RuleMarkerInvalid + "=" + sarif.LevelError + "=test.go:4",
+		justification.RuleTooShort + "=" + sarif.LevelWarning + "=test.go:5",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Processor.Diagnostics() = %v, want %v", got, want)
+	}
+
+	if count := CountErrors(diagnostics); count != 1 {
+		t.Errorf("CountErrors() = %v, want %v", count, 1)
+	}
+}
+
+func TestDiagnosticsSARIF(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := []*Diagnostic{
+		{
+			Rule:    RuleMarkerInvalid,
+			Level:   sarif.LevelError,
+			Text:    "+policy-gen:aws:iam:policy:name=test",
+			Message: "missing action",
+			Source:  &policy.Source{File: "diagnostics.go", Line: 3},
+		},
+		{
+			Rule:    justification.RuleTooShort,
+			Level:   sarif.LevelWarning,
+			Text:    "+policy-gen:aws:iam:policy:name=test",
+			Message: "reason is too short",
+		},
+	}
+
+	data, err := DiagnosticsSARIF(diagnostics)
+	if err != nil {
+		t.Fatalf("DiagnosticsSARIF() error = %v", err)
+	}
+
+	log := &sarif.Log{}
+	if err := json.Unmarshal(data, log); err != nil {
+		t.Fatalf("DiagnosticsSARIF() returned invalid json - %v", err)
+	}
+
+	results := log.Runs[0].Results
+
+	want := []sarif.Result{
+		sarif.NewResult(
+			RuleMarkerInvalid,
+			sarif.LevelError,
+			"invalid marker [+policy-gen:aws:iam:policy:name=test] - missing action",
+			"diagnostics.go",
+			3,
+		),
+		sarif.NewResult(
+			justification.RuleTooShort,
+			sarif.LevelWarning,
+			"marker [+policy-gen:aws:iam:policy:name=test] - reason is too short",
+			"",
+			0,
+		),
+	}
+
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("DiagnosticsSARIF() results = %+v, want %+v", results, want)
+	}
+
+	if uri := results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != "internal/pkg/processor/diagnostics.go" {
+		t.Errorf("DiagnosticsSARIF() uri = %v, want %v", uri, "internal/pkg/processor/diagnostics.go")
+	}
+
+	rules := map[string]bool{}
+	for _, rule := range log.Runs[0].Tool.Driver.Rules {
+		rules[rule.ID] = true
+	}
+
+	for _, result := range results {
+		if !rules[result.RuleID] {
+			t.Errorf("DiagnosticsSARIF() rule [%s] is not described", result.RuleID)
+		}
+	}
+}
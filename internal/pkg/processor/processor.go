@@ -1,8 +1,15 @@
 package processor
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/nukleros/markers"
@@ -10,12 +17,31 @@ import (
 	"github.com/nukleros/markers/parser"
 	"github.com/rs/zerolog"
 
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/aws/inventory"
 	"github.com/scottd018/policy-gen/internal/pkg/docs"
 	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/git"
+	"github.com/scottd018/policy-gen/internal/pkg/golang"
+	"github.com/scottd018/policy-gen/internal/pkg/graph"
+	"github.com/scottd018/policy-gen/internal/pkg/guardrails"
+	"github.com/scottd018/policy-gen/internal/pkg/justification"
 	"github.com/scottd018/policy-gen/internal/pkg/policy"
 	"github.com/scottd018/policy-gen/internal/pkg/utils"
 )
 
+var (
+	ErrOutOfDate = errors.New("generated files are out of date")
+)
+
+const (
+	siteDirectoryPermissions = 0755
+
+	// sourceDateEpoch is the environment variable which sets the generation time, as the number
+	// of seconds since the unix epoch.
+	sourceDateEpoch = "SOURCE_DATE_EPOCH"
+)
+
 // Processor represents the object used to process markers
 // for a file.
 type Processor struct {
@@ -24,6 +50,17 @@ type Processor struct {
 	Definition          *marker.Definition
 	Registry            *marker.Registry
 	PolicyFileGenerator policy.DocumentGenerator
+
+	index   *golang.Index
+	results []*Result
+	markers []policy.Marker
+}
+
+// Result represents a parsed marker result along with the location that it was found at.
+type Result struct {
+	*parser.Result
+
+	Source *policy.Source
 }
 
 // NewProcessor instantiates a new instance of a Processor object.  A processor
@@ -36,8 +73,13 @@ func NewProcessor(config *Config, policyMarker string, object interface{}, gener
 		level = zerolog.DebugLevel
 	}
 
+	var out io.Writer = os.Stdout
+	if config.LogWriter != nil {
+		out = config.LogWriter
+	}
+
 	logger := zerolog.ConsoleWriter{
-		Out: os.Stdout,
+		Out: out,
 		PartsExclude: []string{
 			"time",
 		},
@@ -66,20 +108,9 @@ func NewProcessor(config *Config, policyMarker string, object interface{}, gener
 
 // Process executes the marker processing.
 func (processor *Processor) Process() error {
-	// retrieve the marker results from the input path
-	results, err := processor.Parse()
-	if err != nil {
-		return fmt.Errorf(
-			"error parsing marker results from input path [%s] - %w",
-			processor.Config.InputDirectory.Path,
-			err,
-		)
-	}
-
-	// convert our file markers into a set of policyMarkers markers
-	policyMarkers, err := processor.FindMarkers(results)
+	policyMarkers, err := processor.Markers()
 	if err != nil {
-		return fmt.Errorf("error converting results to markers - %w", err)
+		return err
 	}
 
 	// retrieve our policy files from our markers
@@ -94,7 +125,20 @@ func (processor *Processor) Process() error {
 		options = []files.Option{files.WithOverwrite}
 	}
 
+	// in check mode, files are compared with the existing files rather than written
+	stale := []string{}
+
 	for _, policyFile := range policyFiles {
+		if processor.Config.Check {
+			processor.Log.Info().Msgf("checking policy file: [%s]", policyFile.Path())
+
+			if !upToDate(policyFile.File, policyFile.Content) {
+				stale = append(stale, policyFile.File)
+			}
+
+			continue
+		}
+
 		processor.Log.Info().Msgf("writing policy file: [%s]", policyFile.Path())
 
 		if err := policyFile.Write(files.ModePolicyFile, options...); err != nil {
@@ -103,24 +147,346 @@ func (processor *Processor) Process() error {
 	}
 
 	// write the documentation if it was requested
-	if processor.Config.DocumentationFile != nil && processor.Config.DocumentationFile.File != "" {
-		processor.Log.Info().Msgf("writing documentation file: [%s]", processor.Config.DocumentationFile.Path())
+	hasDocumentation := processor.Config.DocumentationFile != nil && processor.Config.DocumentationFile.File != ""
+
+	if hasDocumentation || processor.Config.PolicyDocumentation || processor.Config.SiteDirectory != "" {
+		model, err := processor.DocumentationModel(policyMarkers)
+		if err != nil {
+			return err
+		}
+
+		type documented struct {
+			file  *files.File
+			model *docs.Model
+		}
+
+		documentation := []documented{}
+
+		if hasDocumentation {
+			model.RelativeTo(filepath.Dir(processor.Config.DocumentationFile.File))
+
+			documentation = append(documentation, documented{file: processor.Config.DocumentationFile, model: model})
+		}
+
+		// write a documentation file for each policy next to its policy file if requested
+		if processor.Config.PolicyDocumentation {
+			for _, policyModel := range model.Policies {
+				path := strings.TrimSuffix(policyModel.File, filepath.Ext(policyModel.File)) + "." + files.ExtensionMarkdown
+
+				policyDocumentationFile, err := files.NewFile(path)
+				if err != nil {
+					return fmt.Errorf("invalid documentation file for policy [%s] - %w", policyModel.Name, err)
+				}
+
+				documentation = append(documentation, documented{file: policyDocumentationFile, model: model.ForPolicy(policyModel)})
+			}
+		}
+
+		// write the static site of the documentation if it was requested
+		if processor.Config.SiteDirectory != "" {
+			siteStale, err := processor.writeSite(model, options...)
+			if err != nil {
+				return err
+			}
+
+			stale = append(stale, siteStale...)
+		}
+
+		for _, each := range documentation {
+			file, fileModel := each.file, each.model
+
+			// create the document and generate the content
+			documentationFile := docs.NewDocumentation(file)
+			if processor.Config.DocumentationTemplate != nil {
+				documentationFile.Template = processor.Config.DocumentationTemplate
+			}
+
+			if err := documentationFile.Generate(fileModel); err != nil {
+				return fmt.Errorf("error generating documentation file: [%s] - %w", file.Path(), err)
+			}
+
+			// only the documentation file which was given may be injected into
+			inject := processor.Config.InjectDocumentation && file == processor.Config.DocumentationFile
+
+			current, err := processor.writeDocumentation(documentationFile, inject, options...)
+			if err != nil {
+				return err
+			}
+
+			if !current {
+				stale = append(stale, file.File)
+			}
+		}
+	}
+
+	// write the graph of the policies if it was requested
+	if processor.Config.GraphFile != "" {
+		current, err := processor.writeGraph(policyMarkers, options...)
+		if err != nil {
+			return err
+		}
+
+		if !current {
+			stale = append(stale, processor.Config.GraphFile)
+		}
+	}
 
-		// create the document and generate the content
-		documentationFile := docs.NewDocumentation(processor.Config.DocumentationFile)
-		documentationFile.Generate(ToDocumentRows(policyMarkers)...)
+	// write the inventory of every permission if it was requested
+	if processor.Config.InventoryFile != "" {
+		current, err := processor.writeInventory(policyMarkers, options...)
+		if err != nil {
+			return err
+		}
 
-		// write the documentation to the specified path
-		if err := documentationFile.File.Write(files.ModePolicyFile, options...); err != nil {
-			return fmt.Errorf("error writing documentation file: [%s] - %w", documentationFile.File.Path(), err)
+		if !current {
+			stale = append(stale, processor.Config.InventoryFile)
 		}
 	}
 
+	if len(stale) > 0 {
+		return fmt.Errorf("%w - [%s]", ErrOutOfDate, strings.Join(stale, ", "))
+	}
+
 	return nil
 }
 
-// Parse parses a set of markers from a given path and returns the results.
-func (processor *Processor) Parse() ([]*parser.Result, error) {
+// writeDocumentation writes the generated documentation to its file, returning whether or not
+// the file is up to date.  When the documentation is injected, only the region of the existing
+// file between the begin and end comments is replaced.  In check mode, the documentation, or
+// only the region when it is injected, is compared with the existing file rather than written.
+func (processor *Processor) writeDocumentation(documentation *docs.Documentation, inject bool, options ...files.Option) (bool, error) {
+	file := documentation.File
+
+	if !inject {
+		return processor.writeDocumentationFile(file, options...)
+	}
+
+	existing, err := os.ReadFile(file.File)
+	if err != nil {
+		return false, fmt.Errorf("unable to read documentation file to inject into: [%s] - %w", file.File, err)
+	}
+
+	if processor.Config.Check {
+		processor.Log.Info().Msgf("checking documentation region of file: [%s]", file.Path())
+
+		region, err := docs.Region(existing)
+		if err != nil {
+			return false, fmt.Errorf("invalid documentation file: [%s] - %w", file.File, err)
+		}
+
+		return bytes.Equal(region, bytes.TrimSpace(file.Content)), nil
+	}
+
+	injected, err := docs.Inject(existing, file.Content)
+	if err != nil {
+		return false, fmt.Errorf("invalid documentation file: [%s] - %w", file.File, err)
+	}
+
+	processor.Log.Info().Msgf("writing documentation region of file: [%s]", file.Path())
+
+	// the file is expected to exist, so it is always overwritten
+	file.Content = injected
+
+	if err := file.Write(files.ModeDocumentFile, files.WithOverwrite); err != nil {
+		return false, fmt.Errorf("error writing documentation file: [%s] - %w", file.Path(), err)
+	}
+
+	return true, nil
+}
+
+// writeDocumentationFile writes a generated documentation file, or in check mode determines
+// whether the existing file is up to date.
+func (processor *Processor) writeDocumentationFile(file *files.File, options ...files.Option) (bool, error) {
+	if processor.Config.Check {
+		processor.Log.Info().Msgf("checking documentation file: [%s]", file.Path())
+
+		return upToDate(file.File, file.Content), nil
+	}
+
+	processor.Log.Info().Msgf("writing documentation file: [%s]", file.Path())
+
+	// write the documentation to the specified path
+	if err := file.Write(files.ModePolicyFile, options...); err != nil {
+		return false, fmt.Errorf("error writing documentation file: [%s] - %w", file.Path(), err)
+	}
+
+	return true, nil
+}
+
+// writeSite writes the pages of the static site of the documentation to the site directory,
+// which is created if it does not exist.  In check mode, the paths of the pages which are out of
+// date are returned instead.
+func (processor *Processor) writeSite(model *docs.Model, options ...files.Option) ([]string, error) {
+	siteFiles, err := docs.NewSite(processor.Config.SiteDirectory).Files(model)
+	if err != nil {
+		return nil, fmt.Errorf("error generating documentation site: [%s] - %w", processor.Config.SiteDirectory, err)
+	}
+
+	if !processor.Config.Check {
+		if err := os.MkdirAll(processor.Config.SiteDirectory, siteDirectoryPermissions); err != nil {
+			return nil, fmt.Errorf("unable to create documentation site directory: [%s] - %w", processor.Config.SiteDirectory, err)
+		}
+	}
+
+	stale := []string{}
+
+	for _, siteFile := range siteFiles {
+		current, err := processor.writeDocumentationFile(siteFile, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		if !current {
+			stale = append(stale, siteFile.File)
+		}
+	}
+
+	return stale, nil
+}
+
+// writeGraph writes the graph of the policies, services, actions and resources of a set of
+// markers to the graph file, or in check mode determines whether the existing file is up to date.
+func (processor *Processor) writeGraph(policyMarkers []policy.Marker, options ...files.Option) (bool, error) {
+	format, err := graph.FormatFor(processor.Config.GraphFile)
+	if err != nil {
+		return false, err
+	}
+
+	markerMap, err := processor.PolicyFileGenerator.ToPolicyMarkerMap(policyMarkers)
+	if err != nil {
+		return false, fmt.Errorf("unable to generate policy marker map - %w", err)
+	}
+
+	content, err := graph.New(markerMap).Render(format)
+	if err != nil {
+		return false, err
+	}
+
+	graphFile, err := files.NewFile(processor.Config.GraphFile)
+	if err != nil {
+		return false, fmt.Errorf("invalid graph file: [%s] - %w", processor.Config.GraphFile, err)
+	}
+
+	graphFile.Content = content
+
+	return processor.writeDocumentationFile(graphFile, options...)
+}
+
+// writeInventory writes the inventory of every permission of a set of markers to the inventory
+// file, or in check mode determines whether the existing file is up to date.
+func (processor *Processor) writeInventory(policyMarkers []policy.Marker, options ...files.Option) (bool, error) {
+	format, err := inventory.FormatFor(processor.Config.InventoryFile)
+	if err != nil {
+		return false, err
+	}
+
+	generator, ok := processor.PolicyFileGenerator.(*aws.PolicyDocumentGenerator)
+	if !ok {
+		return false, fmt.Errorf("unexpected policy generator type [%T] for inventory", processor.PolicyFileGenerator)
+	}
+
+	permissions, err := inventory.New(generator, policyMarkers)
+	if err != nil {
+		return false, fmt.Errorf("unable to create inventory - %w", err)
+	}
+
+	content, err := permissions.Render(format)
+	if err != nil {
+		return false, err
+	}
+
+	inventoryFile, err := files.NewFile(processor.Config.InventoryFile)
+	if err != nil {
+		return false, fmt.Errorf("invalid inventory file: [%s] - %w", processor.Config.InventoryFile, err)
+	}
+
+	inventoryFile.Content = content
+
+	return processor.writeDocumentationFile(inventoryFile, options...)
+}
+
+// upToDate determines whether the file at a path exists with the given content.
+func upToDate(path string, content []byte) bool {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(existing, content)
+}
+
+// Markers parses, validates and annotates the markers from the input path without writing
+// any files.  If entrypoints are configured, the markers are narrowed to those reachable from
+// each entrypoint.  The markers are found on first use and reused thereafter.
+func (processor *Processor) Markers() ([]policy.Marker, error) {
+	if processor.markers != nil {
+		return processor.markers, nil
+	}
+
+	// retrieve the marker results from the input path
+	results, err := processor.Parse()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error parsing marker results from input path [%s] - %w",
+			processor.Config.InputDirectory.Path,
+			err,
+		)
+	}
+
+	// convert our file markers into a set of policyMarkers markers
+	policyMarkers, err := processor.FindMarkers(results)
+	if err != nil {
+		return nil, fmt.Errorf("error converting results to markers - %w", err)
+	}
+
+	// annotate our markers with the code which encloses them
+	processor.Annotate(policyMarkers)
+
+	// narrow our markers to those reachable from each entrypoint if requested
+	if len(processor.Config.Entrypoints) > 0 {
+		policyMarkers, err = processor.FilterReachable(policyMarkers)
+		if err != nil {
+			return nil, fmt.Errorf("error filtering markers by entrypoint - %w", err)
+		}
+	}
+
+	processor.markers = policyMarkers
+
+	return policyMarkers, nil
+}
+
+// generationTime returns the time of the generation of the documentation.  The time is read
+// from the SOURCE_DATE_EPOCH environment variable, as for reproducible builds, or is otherwise
+// the time of the commit which is checked out for the input directory.  A zero time is returned
+// if neither is available, so that the documentation never depends on the clock and remains up
+// to date in check mode.
+func (processor *Processor) generationTime() time.Time {
+	if epoch := os.Getenv(sourceDateEpoch); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+
+		processor.Log.Warn().Msgf("ignoring invalid [%s] environment variable [%s] - %s", sourceDateEpoch, epoch, err)
+	}
+
+	commitTime, err := git.CommitTime(processor.Config.InputDirectory.Path)
+	if err != nil {
+		processor.Log.Debug().Msgf("unable to determine generation time - %s", err)
+
+		return time.Time{}
+	}
+
+	return commitTime
+}
+
+// Parse parses a set of markers from a given path and returns the results.  The results are
+// parsed on first use and reused thereafter.
+func (processor *Processor) Parse() ([]*Result, error) {
+	if processor.results != nil {
+		return processor.results, nil
+	}
+
 	processor.Log.Info().Msgf("parsing markers: [%s]", processor.Definition.Name)
 	processor.Log.Info().Msgf("collecting input for path: [%s]", processor.Config.InputDirectory.Path)
 
@@ -131,7 +497,7 @@ func (processor *Processor) Parse() ([]*parser.Result, error) {
 	}
 
 	// parse the content of each file and collect the results
-	results := []*parser.Result{}
+	results := []*Result{}
 
 	for path := range inputFiles {
 		processor.Log.Debug().Msgf("collecting marker results for file: [%s]", inputFiles[path])
@@ -144,10 +510,7 @@ func (processor *Processor) Parse() ([]*parser.Result, error) {
 
 		// only append text file content
 		if utf8.Valid(content) {
-			found := markers.NewParser(string(content), processor.Registry).Parse()
-			if len(found) != 0 {
-				results = append(results, found...)
-			}
+			results = append(results, processor.ParseContent(inputFiles[path], string(content))...)
 		}
 	}
 
@@ -159,36 +522,39 @@ func (processor *Processor) Parse() ([]*parser.Result, error) {
 			processor.Config.InputDirectory.Path,
 		)
 
-		return []*parser.Result{}, nil
+		processor.results = []*Result{}
+
+		return processor.results, nil
 	}
 
+	processor.results = results
+
 	return results, nil
 }
 
+// ParseContent parses a set of markers from the content of a single file and returns the results.
+func (processor *Processor) ParseContent(file, content string) []*Result {
+	return locate(file, content, markers.NewParser(content, processor.Registry).Parse())
+}
+
 // FindMarkers finds all the markers in a given set of parsed results.
-func (processor *Processor) FindMarkers(results []*parser.Result) ([]policy.Marker, error) {
+func (processor *Processor) FindMarkers(results []*Result) ([]policy.Marker, error) {
 	foundMarkers := make([]policy.Marker, len(results))
 
 	for i := range results {
-		// convert the marker to its underlying type
-		markerResult, err := utils.ConvertToMarker(results[i].Object)
+		markerResult, _, err := processor.toMarker(results[i])
 		if err != nil {
 			return nil, fmt.Errorf(
-				"found invalid marker with text [%s] at position [%d] - %w",
+				"found invalid marker with text [%s] at [%s] - %w",
 				results[i].MarkerText,
-				i,
+				results[i].Source,
 				err,
 			)
 		}
 
-		// ensure the marker we found is valid
-		if err := markerResult.Validate(); err != nil {
-			return nil, fmt.Errorf(
-				"found invalid marker with text [%s] at position [%d] - %w",
-				results[i].MarkerText,
-				i,
-				err,
-			)
+		// warn about markers which violate the justification rules when they are not enforced
+		if err := processor.Config.Project.JustificationRules().Check(markerResult); err != nil {
+			processor.Log.Warn().Msgf("marker at [%s] %s", results[i].Source, err)
 		}
 
 		processor.Log.Debug().Msgf("found marker: [%s]", results[i].MarkerText)
@@ -200,14 +566,116 @@ func (processor *Processor) FindMarkers(results []*parser.Result) ([]policy.Mark
 	return foundMarkers, nil
 }
 
-// ToDocumentRows converts a Markers object to a set of document row interfaces.  This is needed
-// to display markers in documentation.
-func ToDocumentRows(m []policy.Marker) []docs.Row {
-	markersSlice := make([]docs.Row, len(m))
+// DocumentationModel returns the model of the documentation for a set of markers, including the
+// policies which are generated from them.
+func (processor *Processor) DocumentationModel(policyMarkers []policy.Marker) (*docs.Model, error) {
+	model := docs.NewModel(processor.Config.Project.DocumentationColumns(), policyMarkers...)
+	model.ServiceRollup = processor.Config.ServiceRollup
+	model.Generation.Time = processor.generationTime()
+	model.Generation.SourceURL = processor.Config.SourceURL
+	model.Generation.SourceRef = processor.Config.SourceRef
+
+	markerMap, err := processor.PolicyFileGenerator.ToPolicyMarkerMap(policyMarkers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate policy marker map - %w", err)
+	}
+
+	for path, markers := range markerMap {
+		document, err := processor.PolicyFileGenerator.ToDocument(markers)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create document from markers for path [%s] - %w", path, err)
+		}
+
+		model.AddPolicy(markers[0].GetName(), path, document, markers...)
+	}
+
+	if processor.Config.MinimumAccessLevel != "" {
+		model.FilterAccessLevel(processor.Config.MinimumAccessLevel)
+	}
+
+	if processor.Config.DocumentationSort == docs.SortAccessLevel {
+		model.SortByAccessLevel()
+	}
+
+	return model, nil
+}
+
+// toMarker converts a parsed result into a valid marker which stores the location it was found
+// at.  If the result is not a valid marker, or violates the configured guardrails, the rule of
+// the diagnostic which describes the problem is returned along with the error.
+func (processor *Processor) toMarker(result *Result) (policy.Marker, string, error) {
+	// the parser returns an error as the object when the marker text could not be parsed
+	if err, ok := result.Object.(error); ok {
+		return nil, RuleMarkerParseError, err
+	}
+
+	// convert the marker to its underlying type
+	markerResult, err := utils.ConvertToMarker(result.Object)
+	if err != nil {
+		return nil, RuleMarkerParseError, err
+	}
+
+	// ensure the marker we found is valid
+	if err := markerResult.Validate(); err != nil {
+		return nil, RuleMarkerInvalid, err
+	}
+
+	// ensure the metadata keys of the marker we found are declared
+	if err := processor.Config.Project.CheckMetadata(markerResult); err != nil {
+		return nil, RuleMarkerInvalid, err
+	}
+
+	// ensure the marker we found satisfies the guardrails
+	if processor.Config.Guardrails != nil {
+		if err := processor.Config.Guardrails.Check(markerResult); err != nil {
+			var violation *guardrails.Violation
+			if errors.As(err, &violation) {
+				return nil, violation.Rule, err
+			}
+
+			return nil, RuleMarkerInvalid, err
+		}
+	}
+
+	// ensure the marker we found satisfies the justification rules if they are enforced
+	if rules := processor.Config.Project.JustificationRules(); rules.Enforced() {
+		if err := rules.Check(markerResult); err != nil {
+			var violation *justification.Violation
+			if errors.As(err, &violation) {
+				return nil, violation.Rule, err
+			}
+
+			return nil, RuleMarkerInvalid, err
+		}
+	}
+
+	// store the location of the marker
+	markerResult.SetSource(result.Source)
+
+	return markerResult, "", nil
+}
+
+// locate converts a set of parsed results from a file into a set of results including the
+// location of each marker.  Results are returned by the parser in the order in which they
+// appear in the content, so we search forward from the location of the previous marker.
+func locate(file, content string, found []*parser.Result) []*Result {
+	results := make([]*Result, len(found))
+	offset := 0
+
+	for i := range found {
+		source := &policy.Source{File: file}
+
+		// the marker text may span multiple lines, so we search for the first line only
+		text, _, _ := strings.Cut(strings.TrimSpace(found[i].MarkerText), "\n")
+
+		if index := strings.Index(content[offset:], text); text != "" && index >= 0 {
+			offset += index
+			source.Line = strings.Count(content[:offset], "\n") + 1
+			offset += len(text)
+		}
 
-	for i := range m {
-		markersSlice[i] = m[i]
+		results[i] = &Result{Result: found[i], Source: source}
 	}
 
-	return markersSlice
+	return results
 }
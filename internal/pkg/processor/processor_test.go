@@ -0,0 +1,274 @@
+package processor
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nukleros/markers/parser"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/git"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	testModuleDirectory = "../golang/test/input/module"
+)
+
+// newTestProcessor returns a processor of aws markers for the test go module.
+func newTestProcessor(t *testing.T, config *Config) *Processor {
+	t.Helper()
+
+	config.InputDirectory = &files.Directory{Path: testModuleDirectory}
+	config.Recursive = true
+	config.LogWriter = io.Discard
+
+	markerProcessor, err := NewProcessor(config, aws.MarkerDefinition(), aws.Marker{}, &aws.PolicyDocumentGenerator{})
+	if err != nil {
+		t.Fatalf("NewProcessor() error = %v", err)
+	}
+
+	return markerProcessor
+}
+
+func TestProcessor_FilterReachable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		entrypoints map[string]string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name: "ensure markers are named after each entrypoint they are reachable from",
+			entrypoints: map[string]string{
+				"uninstaller": testModuleDirectory + "/cmd/uninstaller",
+				"installer":   testModuleDirectory + "/cmd/installer",
+			},
+			want: []string{
+				"installer=s3:CreateBucket",
+				"installer=s3:PutBucketTagging",
+				"uninstaller=s3:DeleteBucket",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure markers reachable from multiple entrypoints are included in each policy",
+			entrypoints: map[string]string{
+				"installer": testModuleDirectory + "/cmd/installer",
+				"all":       testModuleDirectory + "/cmd/installer",
+			},
+			want: []string{
+				"all=s3:CreateBucket",
+				"all=s3:PutBucketTagging",
+				"installer=s3:CreateBucket",
+				"installer=s3:PutBucketTagging",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ensure an invalid entrypoint name returns an error",
+			entrypoints: map[string]string{
+				"not a valid name!": testModuleDirectory + "/cmd/installer",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			markerProcessor := newTestProcessor(t, &Config{Entrypoints: tt.entrypoints})
+
+			got, err := markerProcessor.Markers()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Processor.FilterReachable() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			named := []string{}
+			for _, reachable := range got {
+				named = append(named, reachable.GetName()+"="+reachable.PermissionColumn())
+			}
+
+			if !reflect.DeepEqual(named, tt.want) {
+				t.Errorf("Processor.FilterReachable() = %v, want %v", named, tt.want)
+			}
+		})
+	}
+}
+
+func Test_locate(t *testing.T) {
+	t.Parallel()
+
+	content := `package test
+
+// +policy-gen:aws:iam:policy:name=test,action=s3:GetObject
+func get() {}
+
+// +policy-gen:aws:iam:policy:name=test,action=s3:GetObject
+// +policy-gen:aws:iam:policy:name=test,action=s3:PutObject,
+//   reason=multiple lines
+func put() {}
+`
+
+	tests := []struct {
+		name  string
+		found []*parser.Result
+		want  []*policy.Source
+	}{
+		{
+			name: "ensure repeated markers are located in the order in which they appear",
+			found: []*parser.Result{
+				{MarkerText: "+policy-gen:aws:iam:policy:name=test,action=s3:GetObject"},
+				{MarkerText: "+policy-gen:aws:iam:policy:name=test,action=s3:GetObject"},
+			},
+			want: []*policy.Source{
+				{File: "test.go", Line: 3},
+				{File: "test.go", Line: 6},
+			},
+		},
+		{
+			name: "ensure markers spanning multiple lines are located by their first line",
+			found: []*parser.Result{
+				{MarkerText: "+policy-gen:aws:iam:policy:name=test,action=s3:PutObject,\n//   reason=multiple lines"},
+			},
+			want: []*policy.Source{
+				{File: "test.go", Line: 7},
+			},
+		},
+		{
+			name: "ensure markers which cannot be found have no line",
+			found: []*parser.Result{
+				{MarkerText: "+policy-gen:aws:iam:policy:name=missing"},
+			},
+			want: []*policy.Source{
+				{File: "test.go"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := []*policy.Source{}
+			for _, result := range locate("test.go", content, tt.found) {
+				got = append(got, result.Source)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("locate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessor_Markers(t *testing.T) {
+	t.Parallel()
+
+	markerProcessor := newTestProcessor(t, &Config{})
+
+	first, err := markerProcessor.Markers()
+	if err != nil {
+		t.Fatalf("Processor.Markers() error = %v", err)
+	}
+
+	second, err := markerProcessor.Markers()
+	if err != nil {
+		t.Fatalf("Processor.Markers() error = %v", err)
+	}
+
+	if len(first) == 0 || len(first) != len(second) || first[0] != second[0] {
+		t.Errorf("Processor.Markers() = %v, want the markers of the first call %v to be reused", second, first)
+	}
+}
+
+//nolint:paralleltest // the environment may not be set by parallel tests.
+func TestProcessor_generationTime(t *testing.T) {
+	commitTime, err := git.CommitTime(testModuleDirectory)
+	if err != nil {
+		t.Skipf("unable to determine commit time - %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		epoch string
+		want  time.Time
+	}{
+		{
+			name:  "ensure generation time is read from the source date epoch",
+			epoch: "1700000000",
+			want:  time.Unix(1700000000, 0).UTC(),
+		},
+		{
+			name:  "ensure generation time is the commit time without a source date epoch",
+			epoch: "",
+			want:  commitTime,
+		},
+		{
+			name:  "ensure generation time is the commit time with an invalid source date epoch",
+			epoch: "yesterday",
+			want:  commitTime,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(sourceDateEpoch, tt.epoch)
+
+			if got := newTestProcessor(t, &Config{}).generationTime(); !got.Equal(tt.want) {
+				t.Errorf("Processor.generationTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessor_writeInventory(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "inventory.json")
+	markerProcessor := newTestProcessor(t, &Config{InventoryFile: path})
+
+	policyMarkers, err := markerProcessor.Markers()
+	if err != nil {
+		t.Fatalf("Processor.Markers() error = %v", err)
+	}
+
+	// each step runs against the inventory file left by the previous step
+	for _, step := range []struct {
+		name  string
+		check bool
+		want  bool
+	}{
+		{name: "ensure missing inventory is out of date in check mode", check: true, want: false},
+		{name: "ensure inventory is written", check: false, want: true},
+		{name: "ensure written inventory is up to date in check mode", check: true, want: true},
+	} {
+		markerProcessor.Config.Check = step.check
+
+		got, err := markerProcessor.writeInventory(policyMarkers)
+		if err != nil {
+			t.Fatalf("%s: Processor.writeInventory() error = %v", step.name, err)
+		}
+
+		if got != step.want {
+			t.Errorf("%s: Processor.writeInventory() = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+// FilterReachable converts a set of markers into a set of markers per entrypoint.  Each marker
+// is attached to the Go function which encloses it and is included in the policy for an
+// entrypoint, named after the entrypoint, only if that function is reachable from the main
+// package of the entrypoint.  Markers which are not reachable from any entrypoint are reported.
+// The markers must be annotated prior to filtering.
+func (processor *Processor) FilterReachable(policyMarkers []policy.Marker) ([]policy.Marker, error) {
+	index, err := processor.GoIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	processor.Log.Info().Msgf("building call graph for module: [%s]", index.Module.Path)
+
+	// collect the markers reachable from each entrypoint in a consistent order
+	names := make([]string, 0, len(processor.Config.Entrypoints))
+	for name := range processor.Config.Entrypoints {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	reachableMarkers := []policy.Marker{}
+	reached := make([]bool, len(policyMarkers))
+
+	for _, name := range names {
+		reachable, err := index.Reachable(processor.Config.Entrypoints[name])
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine reachable functions for entrypoint [%s] - %w", name, err)
+		}
+
+		reachableSymbols := map[string]bool{}
+		for function := range reachable {
+			reachableSymbols[function.ID()] = true
+		}
+
+		for i := range policyMarkers {
+			source := policyMarkers[i].GetSource()
+			if !reachableSymbols[source.Symbol()] {
+				continue
+			}
+
+			processor.Log.Debug().Msgf(
+				"found marker reachable from entrypoint [%s] via [%s] at [%s]",
+				name,
+				source.Symbol(),
+				source,
+			)
+
+			// ensure the entrypoint name is valid as a policy name
+			renamed := policyMarkers[i].WithName(name)
+			if err := renamed.Validate(); err != nil {
+				return nil, fmt.Errorf("invalid policy name for entrypoint [%s] - %w", name, err)
+			}
+
+			reached[i] = true
+			reachableMarkers = append(reachableMarkers, renamed)
+		}
+	}
+
+	// report the markers which are not reachable from any entrypoint
+	for i := range policyMarkers {
+		if reached[i] {
+			continue
+		}
+
+		source := policyMarkers[i].GetSource()
+		if source.Symbol() == "" {
+			processor.Log.Warn().Msgf("found marker outside of a go function at [%s]", source)
+
+			continue
+		}
+
+		processor.Log.Warn().Msgf(
+			"found marker in function [%s] unreachable from any entrypoint at [%s]",
+			source.Symbol(),
+			source,
+		)
+	}
+
+	return reachableMarkers, nil
+}
@@ -0,0 +1,116 @@
+package processor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/golang"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	extensionGo = ".go"
+
+	// DefaultSourceFormat is the format of the links to the source of each marker when no
+	// format is configured, which is the format used by GitHub.
+	DefaultSourceFormat = "{url}/blob/{ref}/{path}#L{line}"
+)
+
+// Annotate annotates the source location of each marker with the function, method receiver and
+// package which encloses it when the marker is found within Go source code.  If a source URL
+// is configured, a link to the exact line of the marker is also added.  Markers found in other
+// languages, or in Go source code which cannot be indexed, are left with their file and line only.
+func (processor *Processor) Annotate(policyMarkers []policy.Marker) {
+	var (
+		index       *golang.Index
+		indexFailed bool
+	)
+
+	for i := range policyMarkers {
+		source := policyMarkers[i].GetSource()
+		if source == nil {
+			continue
+		}
+
+		if processor.Config.SourceURL != "" {
+			source.URL = processor.sourceURL(source)
+		}
+
+		if !strings.HasSuffix(source.File, extensionGo) {
+			continue
+		}
+
+		// only index the module once we know that we have go source code
+		if index == nil && !indexFailed {
+			var err error
+
+			if index, err = processor.GoIndex(); err != nil {
+				processor.Log.Warn().Msgf("unable to determine enclosing functions for go source - %s", err)
+
+				indexFailed = true
+			}
+		}
+
+		if index == nil {
+			continue
+		}
+
+		if function := index.Enclosing(source.File, source.Line); function != nil {
+			source.Package = function.Package
+			source.Receiver = function.Receiver
+			source.Function = function.Name
+		}
+	}
+}
+
+// GoIndex returns the index of the Go module which contains the input directory.  The index
+// is built on first use and reused thereafter.
+func (processor *Processor) GoIndex() (*golang.Index, error) {
+	if processor.index != nil {
+		return processor.index, nil
+	}
+
+	module, err := golang.FindModule(processor.Config.InputDirectory.Path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find go module for input path [%s] - %w", processor.Config.InputDirectory.Path, err)
+	}
+
+	processor.Log.Debug().Msgf("indexing go module: [%s]", module.Path)
+
+	index, err := golang.NewIndex(module)
+	if err != nil {
+		return nil, fmt.Errorf("unable to index go module [%s] - %w", module.Path, err)
+	}
+
+	processor.index = index
+
+	return index, nil
+}
+
+// sourceURL returns the URL which links to the exact line of a source location within the
+// configured repository and ref.  The URL is built from the configured source format, whose
+// {url}, {ref}, {path} and {line} placeholders are replaced.  When the line is not known, the
+// fragment of the format which contains the {line} placeholder is removed.
+func (processor *Processor) sourceURL(source *policy.Source) string {
+	path := files.RepositoryPath(source.File)
+
+	format := processor.Config.SourceFormat
+	if format == "" {
+		format = DefaultSourceFormat
+	}
+
+	if source.Line == 0 {
+		if base, fragment, found := strings.Cut(format, "#"); found && strings.Contains(fragment, "{line}") {
+			format = base
+		}
+	}
+
+	return strings.NewReplacer(
+		"{url}", strings.TrimSuffix(processor.Config.SourceURL, "/"),
+		"{ref}", processor.Config.SourceRef,
+		"{path}", path,
+		"{line}", strconv.Itoa(source.Line),
+	).Replace(format)
+}
@@ -0,0 +1,154 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/scottd018/policy-gen/internal/pkg/aws"
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+	"github.com/scottd018/policy-gen/internal/pkg/policy"
+)
+
+const (
+	testCloudFile = testModuleDirectory + "/pkg/cloud/cloud.go"
+	testCloudPath = "internal/pkg/golang/test/input/module/pkg/cloud/cloud.go"
+)
+
+func TestProcessor_Annotate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		inputDirectory string
+		sources        []*policy.Source
+		want           []*policy.Source
+	}{
+		{
+			name:           "ensure markers in go source are annotated with their enclosing function",
+			inputDirectory: testModuleDirectory,
+			sources: []*policy.Source{
+				{File: testCloudFile, Line: 20},
+				{File: "README.md", Line: 3},
+			},
+			want: []*policy.Source{
+				{
+					File:     testCloudFile,
+					Line:     20,
+					Package:  "example.com/module/pkg/cloud",
+					Receiver: "*Client",
+					Function: "Delete",
+					URL:      "https://github.com/org/repo/blob/main/" + testCloudPath + "#L20",
+				},
+				{
+					File: "README.md",
+					Line: 3,
+					URL:  "https://github.com/org/repo/blob/main/internal/pkg/processor/README.md#L3",
+				},
+			},
+		},
+		{
+			name:           "ensure every marker is linked when the go source cannot be indexed",
+			inputDirectory: "/",
+			sources: []*policy.Source{
+				{File: testCloudFile, Line: 20},
+				{File: testCloudFile, Line: 24},
+			},
+			want: []*policy.Source{
+				{
+					File: testCloudFile,
+					Line: 20,
+					URL:  "https://github.com/org/repo/blob/main/" + testCloudPath + "#L20",
+				},
+				{
+					File: testCloudFile,
+					Line: 24,
+					URL:  "https://github.com/org/repo/blob/main/" + testCloudPath + "#L24",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			markerProcessor := newTestProcessor(t, &Config{SourceURL: "https://github.com/org/repo", SourceRef: "main"})
+			markerProcessor.Config.InputDirectory = &files.Directory{Path: tt.inputDirectory}
+
+			policyMarkers := []policy.Marker{}
+
+			for _, source := range tt.sources {
+				marker := &aws.Marker{}
+				marker.SetSource(source)
+
+				policyMarkers = append(policyMarkers, marker)
+			}
+
+			markerProcessor.Annotate(policyMarkers)
+
+			for i, marker := range policyMarkers {
+				if got := marker.GetSource(); !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("Processor.Annotate() = %#v, want %#v", *got, *tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestProcessor_sourceURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *Config
+		source *policy.Source
+		want   string
+	}{
+		{
+			name:   "ensure the github format is used by default",
+			config: &Config{SourceURL: "https://github.com/org/repo/", SourceRef: "main"},
+			source: &policy.Source{File: testCloudFile, Line: 20},
+			want:   "https://github.com/org/repo/blob/main/" + testCloudPath + "#L20",
+		},
+		{
+			name:   "ensure the line fragment is removed when the line is not known",
+			config: &Config{SourceURL: "https://github.com/org/repo", SourceRef: "v1.0.0"},
+			source: &policy.Source{File: testCloudFile},
+			want:   "https://github.com/org/repo/blob/v1.0.0/" + testCloudPath,
+		},
+		{
+			name: "ensure a configured format is used",
+			config: &Config{
+				SourceURL:    "https://gitlab.com/org/repo",
+				SourceRef:    "main",
+				SourceFormat: "{url}/-/blob/{ref}/{path}#L{line}",
+			},
+			source: &policy.Source{File: testCloudFile, Line: 24},
+			want:   "https://gitlab.com/org/repo/-/blob/main/" + testCloudPath + "#L24",
+		},
+		{
+			name: "ensure a configured line fragment is removed when the line is not known",
+			config: &Config{
+				SourceURL:    "https://bitbucket.org/org/repo",
+				SourceRef:    "main",
+				SourceFormat: "{url}/src/{ref}/{path}#lines-{line}",
+			},
+			source: &policy.Source{File: testCloudFile},
+			want:   "https://bitbucket.org/org/repo/src/main/" + testCloudPath,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			markerProcessor := &Processor{Config: tt.config}
+
+			if got := markerProcessor.sourceURL(tt.source); got != tt.want {
+				t.Errorf("Processor.sourceURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package sarif
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/scottd018/policy-gen/internal/pkg/files"
+)
+
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+
+	ToolName           = "policy-gen"
+	ToolInformationURI = "https://github.com/scottd018/policy-gen"
+
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Log represents the top-level object of a SARIF 2.1.0 log file.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run represents a single invocation of an analysis tool.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool represents the analysis tool that was run.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver represents the component of the tool which contains the rules.
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules,omitempty"`
+}
+
+// Rule represents a rule which produced one or more results.
+type Rule struct {
+	ID                   string         `json:"id"`
+	ShortDescription     *Message       `json:"shortDescription,omitempty"`
+	DefaultConfiguration *Configuration `json:"defaultConfiguration,omitempty"`
+}
+
+// Configuration represents the default configuration of a rule.
+type Configuration struct {
+	Level string `json:"level"`
+}
+
+// Result represents a single finding produced by a rule.
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+
+	// Properties are additional properties of the result, such as the metadata of a marker.
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Message represents a plain text message.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Location represents the location of a result.
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+// PhysicalLocation represents the physical location of a result within a file.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+// ArtifactLocation represents the file which contains a result.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region represents the region of a file which contains a result.
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// NewLog creates a new SARIF log with a single run of policy-gen given a set of rules and the
+// results they produced.
+func NewLog(rules []Rule, results []Result) *Log {
+	if results == nil {
+		results = []Result{}
+	}
+
+	return &Log{
+		Version: Version,
+		Schema:  Schema,
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name:           ToolName,
+						InformationURI: ToolInformationURI,
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
+
+// NewRule creates a new rule with a description and default level.
+func NewRule(id, description, level string) Rule {
+	return Rule{
+		ID:                   id,
+		ShortDescription:     &Message{Text: description},
+		DefaultConfiguration: &Configuration{Level: level},
+	}
+}
+
+// NewResult creates a new result for a rule located at a given file and line.  The file is
+// located relative to the root of the repository which contains it, so that results may be
+// matched with the files of the repository.  The location is omitted if the file is unknown and
+// the region is omitted if the line is unknown.
+func NewResult(ruleID, level, message, file string, line int) Result {
+	result := Result{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: Message{Text: message},
+	}
+
+	if file == "" {
+		return result
+	}
+
+	location := Location{
+		PhysicalLocation: PhysicalLocation{
+			ArtifactLocation: ArtifactLocation{URI: files.RepositoryPath(file)},
+		},
+	}
+
+	if line > 0 {
+		location.PhysicalLocation.Region = &Region{StartLine: line}
+	}
+
+	result.Locations = []Location{location}
+
+	return result
+}
+
+// Marshal returns the indented JSON representation of a SARIF log.
+func (log *Log) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal sarif log - %w", err)
+	}
+
+	return data, nil
+}
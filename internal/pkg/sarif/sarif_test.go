@@ -0,0 +1,119 @@
+package sarif
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNewResult(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		file string
+		line int
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want []Location
+	}{
+		{
+			name: "ensure a result without a file has no location",
+			args: args{},
+			want: nil,
+		},
+		{
+			name: "ensure a result without a line has no region",
+			args: args{file: "sarif.go"},
+			want: []Location{
+				{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: "internal/pkg/sarif/sarif.go"}}},
+			},
+		},
+		{
+			name: "ensure a file is located relative to the repository root",
+			args: args{file: "../sarif/./sarif.go", line: 7},
+			want: []Location{
+				{
+					PhysicalLocation: PhysicalLocation{
+						ArtifactLocation: ArtifactLocation{URI: "internal/pkg/sarif/sarif.go"},
+						Region:           &Region{StartLine: 7},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NewResult("test-rule", LevelWarning, "test message", tt.args.file, tt.args.line)
+			if got.RuleID != "test-rule" || got.Level != LevelWarning || got.Message.Text != "test message" {
+				t.Errorf("NewResult() = %+v, want rule, level and message to be set", got)
+			}
+
+			if !reflect.DeepEqual(got.Locations, tt.want) {
+				t.Errorf("NewResult() locations = %+v, want %+v", got.Locations, tt.want)
+			}
+		})
+	}
+}
+
+func TestLog_Marshal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		results []Result
+		want    int
+	}{
+		{
+			name:    "ensure a log without results has an empty array of results",
+			results: nil,
+			want:    0,
+		},
+		{
+			name:    "ensure a log contains each result",
+			results: []Result{NewResult("test-rule", LevelError, "test message", "", 0)},
+			want:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := NewLog([]Rule{NewRule("test-rule", "test description", LevelError)}, tt.results).Marshal()
+			if err != nil {
+				t.Fatalf("Log.Marshal() error = %v", err)
+			}
+
+			got := map[string]interface{}{}
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Log.Marshal() returned invalid json - %v", err)
+			}
+
+			if got["version"] != Version || got["$schema"] != Schema {
+				t.Errorf("Log.Marshal() version = %v, schema = %v, want %v, %v", got["version"], got["$schema"], Version, Schema)
+			}
+
+			runs, ok := got["runs"].([]interface{})
+			if !ok || len(runs) != 1 {
+				t.Fatalf("Log.Marshal() runs = %v, want a single run", got["runs"])
+			}
+
+			results, ok := runs[0].(map[string]interface{})["results"].([]interface{})
+			if !ok {
+				t.Fatalf("Log.Marshal() results = %v, want an array", runs[0])
+			}
+
+			if len(results) != tt.want {
+				t.Errorf("Log.Marshal() results = %d, want %d", len(results), tt.want)
+			}
+		})
+	}
+}
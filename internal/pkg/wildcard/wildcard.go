@@ -0,0 +1,37 @@
+package wildcard
+
+const (
+	wildcardAny    = '*'
+	wildcardSingle = '?'
+)
+
+// Match matches a value against a pattern where '*' matches any sequence of characters
+// and '?' matches any single character.
+func Match(pattern, value string) bool {
+	patternIndex, valueIndex := 0, 0
+	starIndex, matchIndex := -1, 0
+
+	for valueIndex < len(value) {
+		switch {
+		case patternIndex < len(pattern) && (pattern[patternIndex] == wildcardSingle || pattern[patternIndex] == value[valueIndex]):
+			patternIndex++
+			valueIndex++
+		case patternIndex < len(pattern) && pattern[patternIndex] == wildcardAny:
+			starIndex = patternIndex
+			matchIndex = valueIndex
+			patternIndex++
+		case starIndex != -1:
+			patternIndex = starIndex + 1
+			matchIndex++
+			valueIndex = matchIndex
+		default:
+			return false
+		}
+	}
+
+	for patternIndex < len(pattern) && pattern[patternIndex] == wildcardAny {
+		patternIndex++
+	}
+
+	return patternIndex == len(pattern)
+}